@@ -22,7 +22,10 @@ package objectbox
 */
 import "C"
 import (
+	"context"
 	"errors"
+	"fmt"
+	"time"
 	"unsafe"
 )
 
@@ -82,28 +85,69 @@ func (async *AsyncBox) Close() error {
 }
 
 func (async *AsyncBox) put(object interface{}, mode int) (uint64, error) {
+	return async.putWithRetry(nil, object, mode)
+}
+
+// putWithRetry enqueues the object; if ctx is not nil, it retries while the queue is full until ctx is done
+func (async *AsyncBox) putWithRetry(ctx context.Context, object interface{}, mode int) (uint64, error) {
+	if err := async.box.check(); err != nil {
+		return 0, err
+	}
+
 	entity := async.box.entity
 	idFromObject, err := entity.binding.GetId(object)
 	if err != nil {
 		return 0, err
 	}
 
+	if idFromObject == 0 && async.box.requiresIds() {
+		return 0, ErrIdRequired
+	}
+
 	if entity.hasRelations {
 		return 0, errors.New("asynchronous Put/Insert/Update is currently not supported on entities that have" +
 			" relations because it could result in partial inserts/broken relations")
 	}
 
+	// before the retry loop so that the hook runs exactly once
+	if err := async.box.preSave(object); err != nil {
+		return 0, err
+	}
+
 	id, err := async.box.idForPut(idFromObject)
 	if err != nil {
 		return 0, err
 	}
 
-	err = async.box.withObjectBytes(object, id, func(bytes []byte) error {
-		return cCall(func() C.obx_err {
-			return C.obx_async_put5(async.cAsync, C.obx_id(id), unsafe.Pointer(&bytes[0]), C.size_t(len(bytes)),
-				C.OBXPutMode(mode))
+	var backoff = time.Millisecond
+	for {
+		var rc C.obx_err
+		err = async.box.withObjectBytes(object, id, func(bytes []byte) error {
+			if err := async.box.checkObjectSize(bytes); err != nil {
+				return err
+			}
+			return cCall(func() C.obx_err {
+				rc = C.obx_async_put5(async.cAsync, C.obx_id(id), unsafe.Pointer(&bytes[0]), C.size_t(len(bytes)),
+					C.OBXPutMode(mode))
+				return rc
+			})
 		})
-	})
+
+		// OBX_TIMEOUT: the queue is still full after the enqueue timeout
+		if err == nil || ctx == nil || rc != C.OBX_TIMEOUT {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, fmt.Errorf("async put abandoned while the queue is full: %w", ctx.Err())
+		case <-time.After(backoff):
+		}
+
+		if backoff < 100*time.Millisecond {
+			backoff *= 2
+		}
+	}
 
 	if err != nil {
 		return 0, err
@@ -124,6 +168,14 @@ func (async *AsyncBox) Put(object interface{}) (id uint64, err error) {
 	return async.put(object, cPutModePut)
 }
 
+// PutBlocking works like Put() but if the async queue is full, it doesn't return an error. Instead, it blocks and
+// retries until the queue accepts the object or the given context is done, in which case the returned error wraps
+// ctx.Err(). Use it for producers that must not drop data and should be slowed down (backpressure) instead.
+// Other errors are returned immediately, same as with Put().
+func (async *AsyncBox) PutBlocking(ctx context.Context, object interface{}) (id uint64, err error) {
+	return async.putWithRetry(ctx, object, cPutModePut)
+}
+
 // Insert a single object asynchronously.
 // The ID property on the passed object will be assigned a new ID the entity would hold if the insert is ultimately
 // successful. The newly assigned ID may not become valid if the insert fails.
@@ -151,15 +203,35 @@ func (async *AsyncBox) Remove(object interface{}) error {
 
 // RemoveId deletes a single object asynchronously.
 func (async *AsyncBox) RemoveId(id uint64) error {
+	if err := async.box.check(); err != nil {
+		return err
+	}
+
 	return cCall(func() C.obx_err {
 		return C.obx_async_remove(async.cAsync, C.obx_id(id))
 	})
 }
 
+// RemoveIds deletes the objects with the given IDs asynchronously, enqueuing a removal for each of them.
+// Returns the first error encountered (e.g. the queue is still full after the timeout); the removals enqueued before
+// are still executed.
+func (async *AsyncBox) RemoveIds(ids ...uint64) error {
+	for _, id := range ids {
+		if err := async.RemoveId(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // AwaitCompletion waits for all (including future) async submissions to be completed (the async queue becomes idle for
 // a moment). Currently this is not limited to the single entity this AsyncBox is working on but all entities in the
 // store. Returns an error if shutting down or an error occurred
 func (async *AsyncBox) AwaitCompletion() error {
+	if err := async.box.check(); err != nil {
+		return err
+	}
+
 	return cCallBool(func() bool {
 		return bool(C.obx_store_await_async_completion(async.box.ObjectBox.store))
 	})
@@ -169,6 +241,10 @@ func (async *AsyncBox) AwaitCompletion() error {
 // Currently this is not limited to the single entity this AsyncBox is working on but all entities in the store.
 // Returns an error if shutting down or an error occurred
 func (async *AsyncBox) AwaitSubmitted() error {
+	if err := async.box.check(); err != nil {
+		return err
+	}
+
 	return cCallBool(func() bool {
 		return bool(C.obx_store_await_async_submitted(async.box.ObjectBox.store))
 	})
@@ -0,0 +1,151 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+import (
+	"sync"
+)
+
+// AuditSink receives a record of each object change: the entity type, the operation ("put" or "remove") and the ID
+// of the object. See ObjectBox.SetAuditSink().
+type AuditSink func(typeId TypeId, op string, id uint64)
+
+type auditRecord struct {
+	typeId TypeId
+	op     string
+	id     uint64
+}
+
+// auditLog keeps the sink and the records of the currently open write transactions until they're committed
+type auditLog struct {
+	mutex   sync.Mutex
+	sink    AuditSink
+	threads map[uintptr]*auditTx // the write transaction open on each OS thread, see currentThread()
+}
+
+// auditTx collects the records of a write transaction, including the ones nested in it
+type auditTx struct {
+	depth   int
+	pending []auditRecord
+}
+
+// SetAuditSink sets a function called for each object stored or removed by Box.Put() (and Insert, Update), PutMany()
+// and their variants, Remove(), RemoveId(), RemoveIds() and RemoveManyReport(); pass nil to stop auditing.
+// RemoveAll() is reported as a single "remove" record with ID 0.
+//
+// The sink is only called after the change was committed, i.e. it reflects durable changes: changes made inside
+// RunInWriteTx() are reported once the transaction has been committed and dropped if it's rolled back.
+// The sink is called synchronously from the goroutine that made (or committed) the changes; it can't affect the
+// transaction anymore and a panic inside the sink is recovered and ignored. Keep it fast, e.g. pass the records to
+// a channel, and don't write to the store from the sink.
+//
+// Note: asynchronous puts (Box.PutAsync(), AsyncBox) are not reported.
+func (ob *ObjectBox) SetAuditSink(sink AuditSink) {
+	ob.audit.mutex.Lock()
+	defer ob.audit.mutex.Unlock()
+	ob.audit.sink = sink
+	for _, tx := range ob.audit.threads {
+		tx.pending = nil
+	}
+}
+
+// auditing reports whether there's an audit sink set; used to skip collecting the records otherwise
+func (ob *ObjectBox) auditing() bool {
+	ob.audit.mutex.Lock()
+	defer ob.audit.mutex.Unlock()
+	return ob.audit.sink != nil
+}
+
+// auditChange reports a successful change: directly if it's already been committed (a standalone operation) or once
+// the write transaction open on the caller's thread is committed. Transactions of other goroutines don't matter: a
+// goroutine running a transaction is locked to its thread, i.e. no other goroutine can run on it meanwhile.
+func (ob *ObjectBox) auditChange(typeId TypeId, op string, ids ...uint64) {
+	var thread = currentThread()
+
+	ob.audit.mutex.Lock()
+	var sink = ob.audit.sink
+	if sink == nil {
+		ob.audit.mutex.Unlock()
+		return
+	}
+
+	if tx := ob.audit.threads[thread]; tx != nil {
+		for _, id := range ids {
+			tx.pending = append(tx.pending, auditRecord{typeId, op, id})
+		}
+		ob.audit.mutex.Unlock()
+		return
+	}
+	ob.audit.mutex.Unlock()
+
+	for _, id := range ids {
+		callAuditSink(sink, auditRecord{typeId, op, id})
+	}
+}
+
+// auditTxStarted is called by runInTxn() when a write transaction has been opened, on its (locked) OS thread
+func (ob *ObjectBox) auditTxStarted() {
+	var thread = currentThread()
+
+	ob.audit.mutex.Lock()
+	defer ob.audit.mutex.Unlock()
+	if ob.audit.threads == nil {
+		ob.audit.threads = make(map[uintptr]*auditTx)
+	}
+	var tx = ob.audit.threads[thread]
+	if tx == nil {
+		tx = &auditTx{}
+		ob.audit.threads[thread] = tx
+	}
+	tx.depth++
+}
+
+// auditTxFinished is called by runInTxn() when a write transaction is finished, on the same OS thread it was started.
+// Once the outermost transaction of the thread is finished, emits the collected records if it was committed, drops
+// them otherwise; a nested transaction is only committed (or rolled back) together with the outermost one.
+func (ob *ObjectBox) auditTxFinished(committed bool) {
+	var thread = currentThread()
+
+	ob.audit.mutex.Lock()
+	var tx = ob.audit.threads[thread]
+	if tx == nil {
+		ob.audit.mutex.Unlock()
+		return
+	}
+	if tx.depth--; tx.depth > 0 {
+		ob.audit.mutex.Unlock()
+		return
+	}
+	delete(ob.audit.threads, thread)
+	var sink = ob.audit.sink
+	var records = tx.pending
+	ob.audit.mutex.Unlock()
+
+	if sink == nil || !committed {
+		return
+	}
+
+	for _, record := range records {
+		callAuditSink(sink, record)
+	}
+}
+
+func callAuditSink(sink AuditSink, record auditRecord) {
+	// the change is already committed, nothing the sink does can affect it
+	defer func() { _ = recover() }()
+	sink(record.typeId, record.op, record.id)
+}
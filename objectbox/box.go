@@ -23,23 +23,54 @@ package objectbox
 import "C"
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"runtime"
+	"sync/atomic"
+	"time"
 	"unsafe"
 
 	"github.com/google/flatbuffers/go"
 )
 
-// Box provides CRUD access to objects of a common type
+// Box provides CRUD access to objects of a common type.
+//
+// A Box is bound to the ObjectBox instance it was obtained from and shares its lifecycle: after ObjectBox.Close(),
+// all operations on the box (and on its queries and async box) return ErrStoreClosed, even if the same database is
+// opened again by a new ObjectBox instance. Long-running apps that close and reopen the store, e.g. after restoring a
+// backup, need to obtain the boxes again from the new instance, using the generated BoxFor*() functions or
+// Box.Reacquire().
 type Box struct {
 	ObjectBox *ObjectBox
 	entity    *entity
 	cBox      *C.OBX_box
 	async     *AsyncBox
+
+	countCache countCache
+
+	idsRequired         int32 // atomic boolean, see RequireIds()
+	resequenceRelations int32 // atomic boolean, see AllowResequenceWithRelations()
+	maxObjectSize       int64 // atomic, see SetMaxObjectSizeBytes(); 0 = unlimited
+
+	hooks  boxHooks
+	expiry boxExpiry
 }
 
+// ErrIdRequired is returned when putting an object without an ID (0) to a box that requires explicit IDs,
+// see Box.RequireIds().
+var ErrIdRequired = errors.New("the object has no ID but this box requires explicit IDs")
+
+// ErrObjectTooLarge is returned (wrapped, use errors.Is) when putting an object whose serialized size exceeds the limit
+// configured by Box.SetMaxObjectSizeBytes().
+var ErrObjectTooLarge = errors.New("the object exceeds the maximum object size")
+
+// ErrNotFound is returned by operations requiring an existing object if there's no object with the given ID,
+// e.g. Box.PutMerge().
+var ErrNotFound = errors.New("object not found")
+
 const defaultSliceCapacity = 16
 
 func newBox(ob *ObjectBox, entityId TypeId) (*Box, error) {
@@ -47,6 +78,7 @@ func newBox(ob *ObjectBox, entityId TypeId) (*Box, error) {
 		ObjectBox: ob,
 		entity:    ob.getEntityById(entityId),
 	}
+	box.initExpiry()
 
 	if err := cCallBool(func() bool {
 		box.cBox = C.obx_box(ob.store, C.obx_schema_id(entityId))
@@ -70,6 +102,39 @@ func newBox(ob *ObjectBox, entityId TypeId) (*Box, error) {
 	return box, nil
 }
 
+// check returns ErrStoreClosed if the ObjectBox this box belongs to has been closed
+func (box *Box) check() error {
+	return box.ObjectBox.check()
+}
+
+// Reacquire returns the box for the same entity type from the given ObjectBox, typically one opened after the store
+// this box belongs to was closed. It's a shortcut to keep using a stored *Box reference after a reopen; generated
+// code can call the BoxFor*() function with the new instance instead. Returns an error if the entity isn't part of
+// the model of the given ObjectBox or if that one has been closed as well (ErrStoreClosed).
+func (box *Box) Reacquire(ob *ObjectBox) (*Box, error) {
+	if err := ob.check(); err != nil {
+		return nil, err
+	}
+
+	var entity = ob.entitiesById[box.entity.id]
+	if entity == nil || entity.name != box.entity.name {
+		return nil, fmt.Errorf("entity %s (ID %d) is not part of the model of the given ObjectBox", box.entity.name,
+			box.entity.id)
+	}
+
+	return ob.box(box.entity.id)
+}
+
+// EntityId returns the ID of the entity (type) this box represents, as defined in the model
+func (box *Box) EntityId() TypeId {
+	return box.entity.id
+}
+
+// EntityName returns the name of the entity (type) this box represents, as defined in the model
+func (box *Box) EntityName() string {
+	return box.entity.name
+}
+
 // Async provides access to the default Async Box for asynchronous operations. See AsyncBox for more information.
 func (box *Box) Async() *AsyncBox {
 	return box.async
@@ -89,6 +154,10 @@ func (box *Box) Query(conditions ...Condition) *Query {
 
 // QueryOrError is like Query() but with error handling; e.g. when you build conditions dynamically that may fail.
 func (box *Box) QueryOrError(conditions ...Condition) (query *Query, err error) {
+	if err := box.check(); err != nil {
+		return nil, err
+	}
+
 	builder := newQueryBuilder(box.ObjectBox, box.entity.id)
 
 	defer func() {
@@ -104,10 +173,56 @@ func (box *Box) QueryOrError(conditions ...Condition) (query *Query, err error)
 	}
 
 	query, err = builder.Build(box)
+	if query != nil {
+		query.conditions = append([]Condition{}, conditions...)
+	}
 
 	return // NOTE result might be overwritten by the deferred "closer" function
 }
 
+// QueryIdGreaterThan creates a query for objects with an ID greater than the given one, ordered by the ID, e.g. for
+// keyset pagination: box.QueryIdGreaterThan(lastSeenId).Limit(100).Find(). Conditions on the ID use the primary key
+// directly, i.e. they don't need an index and don't scan the whole box.
+// To fetch the next page with the same query, pass the new ID using SetInt64Params() with the ID property.
+// Note: like Query(), this function panics if the query can't be created.
+func (box *Box) QueryIdGreaterThan(id uint64) *Query {
+	var property = PropertyUint64{BaseProperty: box.entity.idProperty()}
+	return box.Query(property.GreaterThan(id), property.OrderAsc())
+}
+
+// Page reads up to size objects with IDs greater than afterId, ordered by the ID ("keyset pagination"). Use 0 to get
+// the first page and the returned nextCursor (the ID of the last object on the page) to get the following one.
+// As opposed to Offset(), reading a page takes the same time regardless of its position.
+// The returned nextCursor is 0 if the page isn't full, i.e. there are no more objects; note that a full page may
+// still be the last one, the following call then returns an empty slice.
+// Objects put after the cursor was returned are included in the following pages if their ID is greater.
+//
+// Returns a slice of objects that should be cast to the appropriate type.
+func (box *Box) Page(afterId uint64, size int) (slice interface{}, nextCursor uint64, err error) {
+	if size <= 0 {
+		return nil, 0, fmt.Errorf("invalid page size %d, must be positive", size)
+	}
+
+	var property = PropertyUint64{BaseProperty: box.entity.idProperty()}
+	query, err := box.QueryOrError(property.GreaterThan(afterId), property.OrderAsc())
+	if err != nil {
+		return nil, 0, err
+	}
+	defer query.Close()
+
+	if slice, err = query.Limit(uint64(size)).Find(); err != nil {
+		return nil, 0, err
+	}
+
+	var objects = box.objectSlice(slice)
+	if objects.len() == size {
+		if nextCursor, err = box.entity.binding.GetId(objects.index(size - 1)); err != nil {
+			return nil, 0, err
+		}
+	}
+	return slice, nextCursor, nil
+}
+
 func (box *Box) idForPut(idCandidate uint64) (id uint64, err error) {
 	id = uint64(C.obx_box_id_for_put(box.cBox, C.obx_id(idCandidate)))
 
@@ -125,6 +240,52 @@ func (box *Box) idForPut(idCandidate uint64) (id uint64, err error) {
 	return
 }
 
+// RequireIds configures whether objects put to this box must have an ID set. When required, putting an object with
+// ID 0 fails with ErrIdRequired instead of assigning a new ID automatically (from the box's ID sequence). This applies to
+// all put variants, including PutMany (the whole transaction is rolled back) and async puts.
+//
+// This is useful e.g. when IDs are assigned by an external authority and a locally generated ID would collide with the
+// IDs assigned elsewhere. The setting is kept for the lifetime of the ObjectBox instance (boxes are shared, see
+// ObjectBox.InternalBox()). By default, IDs are assigned automatically.
+func (box *Box) RequireIds(required bool) {
+	if required {
+		atomic.StoreInt32(&box.idsRequired, aTrue)
+	} else {
+		atomic.StoreInt32(&box.idsRequired, aFalse)
+	}
+}
+
+func (box *Box) requiresIds() bool {
+	return atomic.LoadInt32(&box.idsRequired) == aTrue
+}
+
+// SetMaxObjectSizeBytes limits the size of objects put to this box, e.g. as a safety valve against accidentally storing
+// a huge user-uploaded blob. Putting an object whose serialized size (see SerializedSize()) exceeds the limit fails
+// with ErrObjectTooLarge; for PutMany, the whole transaction is rolled back. This applies to all put variants,
+// including async puts. Pass 0 to remove the limit (the default).
+//
+// The limit can be changed at any time, e.g. from another goroutine, and affects the puts started afterwards.
+// Like RequireIds(), it's kept for the lifetime of the ObjectBox instance.
+func (box *Box) SetMaxObjectSizeBytes(maxBytes int) {
+	if maxBytes < 0 {
+		maxBytes = 0
+	}
+	atomic.StoreInt64(&box.maxObjectSize, int64(maxBytes))
+}
+
+// MaxObjectSizeBytes returns the limit configured by SetMaxObjectSizeBytes(); 0 means no limit.
+func (box *Box) MaxObjectSizeBytes() int {
+	return int(atomic.LoadInt64(&box.maxObjectSize))
+}
+
+// checkObjectSize returns ErrObjectTooLarge if the serialized object exceeds the configured limit
+func (box *Box) checkObjectSize(bytes []byte) error {
+	if limit := atomic.LoadInt64(&box.maxObjectSize); limit > 0 && int64(len(bytes)) > limit {
+		return fmt.Errorf("%w: %d bytes, the limit is %d bytes", ErrObjectTooLarge, len(bytes), limit)
+	}
+	return nil
+}
+
 func (box *Box) idsForPut(count int) (firstId uint64, err error) {
 	if count == 0 {
 		return 0, nil
@@ -142,11 +303,32 @@ func (box *Box) idsForPut(count int) (firstId uint64, err error) {
 }
 
 func (box *Box) put(object interface{}, alreadyInTx bool, putMode C.OBXPutMode) (id uint64, err error) {
+	if err := box.check(); err != nil {
+		return 0, err
+	}
+
+	if err := box.preSave(object); err != nil {
+		return 0, err
+	}
+	return box.putPrepared(object, alreadyInTx, putMode, nil)
+}
+
+// putPrepared is like put() but expects the pre-save hook to have been applied already, see SetPreSave().
+// If outBytes is given, it receives a copy of the stored data.
+func (box *Box) putPrepared(object interface{}, alreadyInTx bool, putMode C.OBXPutMode, outBytes *[]byte) (id uint64, err error) {
+	if err := box.check(); err != nil {
+		return 0, err
+	}
+
 	idFromObject, err := box.entity.binding.GetId(object)
 	if err != nil {
 		return 0, err
 	}
 
+	if idFromObject == 0 && box.requiresIds() {
+		return 0, ErrIdRequired
+	}
+
 	if putMode == cPutModeUpdate {
 		id = idFromObject
 		if idFromObject == 0 {
@@ -162,10 +344,10 @@ func (box *Box) put(object interface{}, alreadyInTx bool, putMode C.OBXPutMode)
 	// for entities with relations, execute all Put/PutRelated inside a single transaction
 	if box.entity.hasRelations && !alreadyInTx {
 		err = box.ObjectBox.RunInWriteTx(func() error {
-			return box.putOne(id, object, putMode)
+			return box.putOne(id, object, putMode, outBytes)
 		})
 	} else {
-		err = box.putOne(id, object, putMode)
+		err = box.putOne(id, object, putMode, outBytes)
 	}
 
 	// update the id on the object
@@ -175,12 +357,14 @@ func (box *Box) put(object interface{}, alreadyInTx bool, putMode C.OBXPutMode)
 
 	if err != nil {
 		id = 0
+	} else {
+		box.ObjectBox.auditChange(box.entity.id, "put", id)
 	}
 
-	return id, err
+	return id, withOperation(err, "put", box.entity.name)
 }
 
-func (box *Box) putOne(id uint64, object interface{}, putMode C.OBXPutMode) error {
+func (box *Box) putOne(id uint64, object interface{}, putMode C.OBXPutMode, outBytes *[]byte) error {
 	if box.entity.hasRelations { // In that case, the caller already ensured to be inside a TX
 		if err := box.entity.binding.PutRelated(box.ObjectBox, object, id); err != nil {
 			return err
@@ -188,15 +372,33 @@ func (box *Box) putOne(id uint64, object interface{}, putMode C.OBXPutMode) erro
 	}
 
 	return box.withObjectBytes(object, id, func(bytes []byte) error {
-		return cCall(func() C.obx_err {
+		if err := box.checkObjectSize(bytes); err != nil {
+			return err
+		}
+		if err := cCall(func() C.obx_err {
 			return C.obx_box_put5(box.cBox, C.obx_id(id), unsafe.Pointer(&bytes[0]), C.size_t(len(bytes)), putMode)
-		})
+		}); err != nil {
+			return err
+		}
+
+		if outBytes != nil {
+			// the builder is reused after the put so the bytes need to be copied
+			*outBytes = make([]byte, len(bytes))
+			copy(*outBytes, bytes)
+		}
+		return nil
 	})
 }
 
 func (box *Box) withObjectBytes(object interface{}, id uint64, fn func([]byte) error) error {
 	var fbb = fbbPool.Get().(*flatbuffers.Builder)
+	err := box.flattenWith(fbb, object, id, fn)
+	fbbPoolPut(fbb)
+	return err
+}
 
+// flattenWith is like withObjectBytes but uses the given builder, which is reset afterwards so it can be reused
+func (box *Box) flattenWith(fbb *flatbuffers.Builder, object interface{}, id uint64, fn func([]byte) error) error {
 	err := box.entity.binding.Flatten(object, fbb, id)
 
 	if err == nil {
@@ -204,12 +406,7 @@ func (box *Box) withObjectBytes(object interface{}, id uint64, fn func([]byte) e
 		err = fn(fbb.FinishedBytes())
 	}
 
-	// put the fbb back to the pool for the others to use if it's reasonably small; don't use defer, it's slower
-	if cap(fbb.Bytes) < 1024*1024 {
-		fbb.Reset()
-		fbbPool.Put(fbb)
-	}
-
+	fbb.Reset()
 	return err
 }
 
@@ -219,13 +416,98 @@ func (box *Box) PutAsync(object interface{}) (id uint64, err error) {
 	return box.async.Put(object)
 }
 
+// PutAsyncBlocking asynchronously inserts/updates a single object, blocking while the async queue is full.
+// As opposed to PutAsync (and Async().Put()), which return an error if the object can't be enqueued in time,
+// this waits until the queue accepts the object or the given context is done. See AsyncBox.PutBlocking().
+func (box *Box) PutAsyncBlocking(ctx context.Context, object interface{}) (id uint64, err error) {
+	return box.async.PutBlocking(ctx, object)
+}
+
+// RemoveAsync asynchronously removes a single object, e.g. for a best-effort cleanup that doesn't need to wait for the
+// transaction to finish. Same as Async().RemoveId(); the same throttling and timeout apply as for PutAsync: if the
+// queue is still full after the timeout, an error is returned. Removing an object that doesn't exist fails silently.
+//
+// Like other async operations, there's a small time window in which the removal isn't committed (durably) yet and
+// the object is still returned by reads; use AwaitAsyncCompletion() to wait until it's processed.
+func (box *Box) RemoveAsync(id uint64) error {
+	return box.async.RemoveId(id)
+}
+
+// RemoveAsyncMany asynchronously removes the objects with the given IDs, see RemoveAsync().
+// Returns the first error encountered; the removals enqueued before are still executed.
+func (box *Box) RemoveAsyncMany(ids ...uint64) error {
+	return box.async.RemoveIds(ids...)
+}
+
+// AwaitAsyncCompletion waits until the asynchronous operations submitted for this box (e.g. PutAsync) are processed,
+// so that subsequent reads see their results.
+// Note: the native library only offers a store-wide flush so this waits for async operations of all boxes.
+func (box *Box) AwaitAsyncCompletion() error {
+	return box.async.AwaitCompletion()
+}
+
 // Put synchronously inserts/updates a single object.
 // In case the ID is not specified, it would be assigned automatically (auto-increment).
 // When inserting, the ID property on the passed object will be assigned the new ID as well.
+//
+// See ObjectBox.EnableWriteCoalescing() to share transactions between Put() calls from multiple goroutines.
 func (box *Box) Put(object interface{}) (id uint64, err error) {
+	if coalescer := box.ObjectBox.activeCoalescer(); coalescer != nil {
+		// the hook runs here, exactly once, even if the coalesced transaction has to be retried per object
+		if err := box.preSave(object); err != nil {
+			return 0, err
+		}
+		return coalescer.put(box, object)
+	}
 	return box.put(object, false, cPutModePut)
 }
 
+// PutAndBytes is like Put() but additionally returns the data as it was stored (in the FlatBuffers format, same as
+// returned by GetBytes()), e.g. for a write-through cache, without serializing the object again or reading it back.
+// The returned slice is a copy owned by the caller. Puts issued this way aren't coalesced, see
+// ObjectBox.EnableWriteCoalescing().
+func (box *Box) PutAndBytes(object interface{}) (id uint64, data []byte, err error) {
+	if err := box.check(); err != nil {
+		return 0, nil, err
+	} else if err := box.preSave(object); err != nil {
+		return 0, nil, err
+	}
+
+	if id, err = box.putPrepared(object, false, cPutModePut, &data); err != nil {
+		return 0, nil, err
+	}
+	return id, data, nil
+}
+
+// PutReportMode works like Put, additionally reporting whether an existing object was replaced (wasUpdate=true)
+// or a new one was inserted, e.g. to collect insert/update metrics.
+// An object with a non-zero ID that isn't stored yet is reported as an insert.
+func (box *Box) PutReportMode(object interface{}) (id uint64, wasUpdate bool, err error) {
+	idFromObject, err := box.entity.binding.GetId(object)
+	if err != nil {
+		return 0, false, err
+	}
+
+	// check and put in a single transaction so that a concurrent put/remove can't change the outcome in between
+	err = box.ObjectBox.RunInWriteTx(func() error {
+		if idFromObject != 0 {
+			var err error
+			if wasUpdate, err = box.Contains(idFromObject); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		id, err = box.put(object, true, cPutModePut)
+		return err
+	})
+
+	if err != nil {
+		return 0, false, err
+	}
+	return id, wasUpdate, nil
+}
+
 // Insert synchronously inserts a single object.
 // As opposed to Put, Insert will fail if an object with the same ID already exists.
 // In case the ID is not specified, it would be assigned automatically (auto-increment).
@@ -241,6 +523,90 @@ func (box *Box) Update(object interface{}) error {
 	return err
 }
 
+// PutMerge applies a partial update to the stored object with the given ID, e.g. for PATCH-style endpoints: the object
+// is read, the given properties (by their names as defined in the model, see objectbox-model.json) are set to the new
+// values and the object is put again, all in a single write transaction. Other properties keep their stored values.
+//
+// The values follow the same rules as in Query.SetProperty(), e.g. a nil value sets a pointer field to nil.
+// Unknown property names (and the ID property) are reported before anything is written; ErrNotFound is returned if
+// there's no object with the given ID.
+func (box *Box) PutMerge(id uint64, updates map[string]interface{}) error {
+	for name := range updates {
+		var property = box.entity.propertyByName(name)
+		if property == nil {
+			return fmt.Errorf("unknown property '%s' on entity %s", name, box.entity.name)
+		} else if property.id == box.entity.idPropertyId {
+			return fmt.Errorf("the ID property of entity %s can't be updated", box.entity.name)
+		}
+	}
+
+	return box.ObjectBox.RunInWriteTx(func() error {
+		object, err := box.Get(id)
+		if err != nil {
+			return err
+		} else if object == nil {
+			return ErrNotFound
+		}
+
+		for name, value := range updates {
+			if err := setFieldValue(object, name, value); err != nil {
+				return fmt.Errorf("can't set property %s on entity %s: %s", name, box.entity.name, err)
+			}
+		}
+
+		_, err = box.put(object, true, cPutModeUpdate)
+		return err
+	})
+}
+
+// IncrementProperty atomically adds delta (which may be negative) to an integer property of the stored object with the
+// given ID and returns the new value, e.g. for view counters. The object is read, changed and put again in a single
+// write transaction, so concurrent increments (from any goroutine) are serialized and none of them is lost.
+//
+// The property is given by its name as defined in the model. A nil pointer field counts as 0. An error is returned if
+// the property isn't an integer, if the result overflows (or doesn't fit the property type, e.g. a negative value for
+// an unsigned field) and ErrNotFound if there's no object with the given ID; nothing is written in that case.
+func (box *Box) IncrementProperty(id uint64, property string, delta int64) (newValue int64, err error) {
+	var info = box.entity.propertyByName(property)
+	if info == nil {
+		return 0, fmt.Errorf("unknown property '%s' on entity %s", property, box.entity.name)
+	} else if info.id == box.entity.idPropertyId {
+		return 0, fmt.Errorf("the ID property of entity %s can't be updated", box.entity.name)
+	}
+
+	err = box.ObjectBox.RunInWriteTx(func() error {
+		object, err := box.Get(id)
+		if err != nil {
+			return err
+		} else if object == nil {
+			return ErrNotFound
+		}
+
+		current, err := intFieldValue(object, info.name)
+		if err != nil {
+			return fmt.Errorf("can't increment property %s on entity %s: %s", info.name, box.entity.name, err)
+		}
+
+		newValue = current + delta
+		if (delta > 0 && newValue < current) || (delta < 0 && newValue > current) {
+			return fmt.Errorf("can't increment property %s on entity %s: %d + %d overflows", info.name,
+				box.entity.name, current, delta)
+		}
+
+		if err := setFieldValue(object, info.name, newValue); err != nil {
+			return fmt.Errorf("can't increment property %s on entity %s: %s", info.name, box.entity.name, err)
+		}
+
+		_, err = box.put(object, true, cPutModeUpdate)
+		return err
+	})
+
+	if err != nil {
+		return 0, err
+	}
+	return newValue, nil
+}
+
 // PutMany inserts multiple objects in a single transaction.
 // The given argument must be a slice of the object type this Box represents (pointers to objects).
 // In case IDs are not set on the objects, they would be assigned automatically (auto-increment).
@@ -251,9 +617,305 @@ func (box *Box) Update(object interface{}) error {
 // even though the transaction has been rolled back and the objects are not stored under those IDs.
 //
 // Note: The slice may be empty or even nil; in both cases, an empty IDs slice and no error is returned.
+//
+// Errors caused by one of the objects are returned as *PutManyError, identifying the object by its index; for errors
+// reported by the database for the objects put together (e.g. a unique constraint violation), it identifies the range
+// of the objects containing the failing one.
 func (box *Box) PutMany(objects interface{}) (ids []uint64, err error) {
-	var slice = reflect.ValueOf(objects)
-	var count = slice.Len()
+	return box.putMany(objects, nil)
+}
+
+// PutAllReportInserts works like PutMany, i.e. it puts all objects in a single transaction, and additionally reports
+// which of the objects were inserts: inserted[i] is true if the object at index i had no ID (0) and was assigned a new
+// one, false if it had an ID already (an update, or an insert under an explicitly set ID). This is determined from the
+// IDs before the put, e.g. for sync reconciliation.
+func (box *Box) PutAllReportInserts(slice interface{}) (ids []uint64, inserted []bool, err error) {
+	if err := box.check(); err != nil {
+		return nil, nil, err
+	}
+
+	var objects = box.objectSlice(slice)
+	inserted = make([]bool, objects.len())
+	for i := range inserted {
+		id, err := box.entity.binding.GetId(objects.index(i))
+		if err != nil {
+			return nil, nil, &PutManyError{Index: i, Err: err}
+		}
+		inserted[i] = id == 0
+	}
+
+	if ids, err = box.putMany(slice, nil); err != nil {
+		return nil, nil, err
+	}
+	return ids, inserted, nil
+}
+
+// putProgressInterval is the number of objects between two PutAllProgress() callback invocations
+const putProgressInterval = 1000
+
+// PutAllProgress works like PutMany, i.e. it inserts multiple objects in a single transaction, and additionally
+// reports the progress by calling onProgress periodically (every 1000 objects and once all objects have been put).
+// The arguments passed to the callback are the number of objects put so far and the total number of objects.
+//
+// The callback is invoked from inside the write transaction, on the same thread: it must not issue any store
+// operations (e.g. Box or Query calls, another transaction) and should return quickly - use it to update the UI, etc.
+//
+// Note: the progress only reflects the data written in the (uncommitted) transaction; if an error occurs, the whole
+// transaction is rolled back and no objects are stored.
+func (box *Box) PutAllProgress(slice interface{}, onProgress func(done, total int)) ([]uint64, error) {
+	return box.putMany(slice, onProgress)
+}
+
+// putFromChanBatchSize is the number of objects put in a single transaction by PutAllFromChan()
+const putFromChanBatchSize = 10000
+
+// PutAllFromChan puts all objects received from the given channel until it's closed, e.g. produced by another goroutine.
+// The objects are put in batches of 10000, each batch in its own write transaction (like PutMany), so that a large or
+// never-ending producer doesn't make a single transaction grow without bounds. A batch is also written as soon as there
+// are no more objects waiting in the channel, i.e. objects aren't held back while the producer is slow.
+//
+// Note: because of the batching, this is not atomic: if an error occurs, the batches put so far stay committed and
+// their IDs are returned together with the error (a *PutManyError's Index is relative to all received objects).
+// The channel isn't drained after an error, make sure the producer doesn't block forever, e.g. using a context.
+//
+// Returns: IDs of the put objects (in the order they were received); an empty slice for a nil channel.
+func (box *Box) PutAllFromChan(ch <-chan interface{}) (ids []uint64, err error) {
+	ids = []uint64{}
+	if ch == nil {
+		return ids, nil
+	}
+
+	var binding = box.entity.binding
+	for {
+		// wait for the first object, then take all those available without blocking, up to the batch size
+		object, ok := <-ch
+		if !ok {
+			return ids, nil
+		}
+
+		var batch = binding.AppendToSlice(binding.MakeSlice(defaultSliceCapacity), object)
+		var count = 1
+		var closed = false
+	collect:
+		for count < putFromChanBatchSize {
+			select {
+			case object, ok := <-ch:
+				if !ok {
+					closed = true
+					break collect
+				}
+				batch = binding.AppendToSlice(batch, object)
+				count++
+			default:
+				break collect
+			}
+		}
+
+		batchIds, err := box.putMany(batch, nil)
+		if err != nil {
+			if putErr, isPutErr := err.(*PutManyError); isPutErr {
+				var copied = *putErr
+				copied.Index += len(ids)
+				if copied.EndIndex != 0 {
+					copied.EndIndex += len(ids)
+				}
+				err = &copied
+			}
+			return ids, err
+		}
+		ids = append(ids, batchIds...)
+
+		if closed {
+			return ids, nil
+		}
+	}
+}
+
+// PutAllWithinMemory puts all objects of the given slice in batches, each in its own write transaction (like PutMany),
+// limiting the accumulated serialized size of a batch (see SerializedSize()) to maxBytesPerTx. This keeps the peak
+// memory usage and the size of a transaction bounded regardless of the size of the objects, e.g. when importing data.
+// An object larger than the limit on its own is put in a separate transaction.
+//
+// Note: because of the batching, this is not atomic: if an error occurs, the batches put so far stay committed and
+// their IDs are returned together with the error (a *PutManyError's Index is relative to the given slice).
+//
+// Returns: IDs of the put objects (in the same order).
+func (box *Box) PutAllWithinMemory(slice interface{}, maxBytesPerTx int) (ids []uint64, err error) {
+	if maxBytesPerTx <= 0 {
+		return nil, fmt.Errorf("invalid memory budget %d, must be positive", maxBytesPerTx)
+	}
+
+	var objects = box.objectSlice(slice)
+	var count = objects.len()
+	ids = make([]uint64, 0, count)
+
+	var binding = box.entity.binding
+	var batch interface{}
+	var batchCount, batchBytes int
+
+	var flush = func() error {
+		batchIds, err := box.putMany(batch, nil)
+		if err != nil {
+			if putErr, isPutErr := err.(*PutManyError); isPutErr {
+				var copied = *putErr
+				copied.Index += len(ids)
+				if copied.EndIndex != 0 {
+					copied.EndIndex += len(ids)
+				}
+				err = &copied
+			}
+			return err
+		}
+		ids = append(ids, batchIds...)
+		batch, batchCount, batchBytes = nil, 0, 0
+		return nil
+	}
+
+	for i := 0; i < count; i++ {
+		var object = objects.index(i)
+		size, err := box.SerializedSize(object)
+		if err != nil {
+			return ids, &PutManyError{Index: i, Err: err}
+		}
+
+		if batchCount > 0 && batchBytes+size > maxBytesPerTx {
+			if err := flush(); err != nil {
+				return ids, err
+			}
+		}
+
+		if batch == nil {
+			batch = binding.MakeSlice(defaultSliceCapacity)
+		}
+		batch = binding.AppendToSlice(batch, object)
+		batchCount++
+		batchBytes += size
+	}
+
+	if batchCount > 0 {
+		if err := flush(); err != nil {
+			return ids, err
+		}
+	}
+	return ids, nil
+}
+
+// copyBatchSize is the number of objects read and written in a single transaction by Box.CopyTo()
+const copyBatchSize = 1000
+
+// CopyTo copies all objects of this box to the given box of another ObjectBox instance, e.g. for sharding or a
+// migration to a new store, preserving the object IDs: objects with the same ID in the destination are overwritten.
+// Returns the number of copied objects.
+//
+// The objects are copied in the stored (FlatBuffers) format, without converting them to Go structs and back, in
+// batches of up to 1000 objects: each batch is read in a read transaction of this store and written in a write
+// transaction of the destination. If an error occurs, the batches written before stay in the destination.
+//
+// The destination must represent the same entity with the same properties (e.g. use the same model). Standalone
+// (many-to-many) relations are not copied; to-one relations are, as they're stored as part of the object.
+func (box *Box) CopyTo(dest *Box) (copied uint64, err error) {
+	if err := box.check(); err != nil {
+		return 0, err
+	} else if err := dest.check(); err != nil {
+		return 0, err
+	}
+
+	if dest.ObjectBox == box.ObjectBox {
+		return 0, fmt.Errorf("can't copy entity %s to the same store", box.entity.name)
+	} else if !box.entity.sameDataLayout(dest.entity) {
+		return 0, fmt.Errorf("can't copy entity %s to %s: the entities' properties differ", box.entity.name,
+			dest.entity.name)
+	}
+
+	var idProperty = PropertyUint64{BaseProperty: box.entity.idProperty()}
+	var lastId uint64
+	for {
+		var ids []uint64
+		var objectsBytes [][]byte
+		if err := box.ObjectBox.RunInReadTx(func() error {
+			query, err := box.QueryOrError(idProperty.GreaterThan(lastId))
+			if err != nil {
+				return err
+			}
+			defer query.Close()
+
+			if ids, err = query.Limit(copyBatchSize).FindIds(); err != nil {
+				return err
+			}
+
+			objectsBytes = make([][]byte, len(ids))
+			for i, id := range ids {
+				if objectsBytes[i], err = box.getBytesCopy(id); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return copied, withOperation(err, "copy", box.entity.name)
+		}
+
+		if len(ids) == 0 {
+			return copied, nil
+		}
+
+		if err := dest.ObjectBox.RunInWriteTx(func() error {
+			return dest.putManyBytes(ids, objectsBytes)
+		}); err != nil {
+			return copied, withOperation(err, "copy", box.entity.name)
+		}
+
+		copied += uint64(len(ids))
+		lastId = ids[len(ids)-1]
+	}
+}
+
+// putManyBytes stores the given objects, already in the stored format, under the given IDs.
+// Requires to be called inside a write transaction.
+func (box *Box) putManyBytes(ids []uint64, objectsBytes [][]byte) error {
+	for _, bytes := range objectsBytes {
+		if err := box.checkObjectSize(bytes); err != nil {
+			return err
+		}
+	}
+
+	bytesArray, err := goBytesArrayToC(objectsBytes)
+	if err != nil {
+		return err
+	}
+	defer bytesArray.free()
+
+	if err := cCall(func() C.obx_err {
+		return C.obx_box_put_many(box.cBox, bytesArray.cBytesArray, goUint64ArrayToCObxId(ids), cPutModePut)
+	}); err != nil {
+		return err
+	}
+
+	box.ObjectBox.auditChange(box.entity.id, "put", ids...)
+	return nil
+}
+
+// SerializedSize returns the size of the given object in bytes as it would be stored in the database (FlatBuffers),
+// e.g. to estimate the size of a transaction before putting the objects.
+func (box *Box) SerializedSize(object interface{}) (size int, err error) {
+	id, err := box.entity.binding.GetId(object)
+	if err != nil {
+		return 0, err
+	}
+
+	err = box.withObjectBytes(object, id, func(bytes []byte) error {
+		size = len(bytes)
+		return nil
+	})
+	return size, err
+}
+
+func (box *Box) putMany(objects interface{}, onProgress func(done, total int)) (ids []uint64, err error) {
+	if err := box.check(); err != nil {
+		return nil, err
+	}
+
+	var slice = box.objectSlice(objects)
+	var count = slice.len()
 
 	// a little optimization for the edge case
 	if count == 0 {
@@ -268,7 +930,10 @@ func (box *Box) PutMany(objects interface{}) (ids []uint64, err error) {
 	err = box.ObjectBox.RunInWriteTx(func() error {
 		if supportsResultArray {
 			// Process the data in chunks so that we don't consume too much memory.
-			const chunkSize = 10000 // 10k is the limit currently enforced by obx_box_ids_for_put, maybe make configurable
+			var chunkSize = 10000 // 10k is the limit currently enforced by obx_box_ids_for_put, maybe make configurable
+			if onProgress != nil {
+				chunkSize = putProgressInterval
+			}
 
 			var chunks = count / chunkSize
 			if count%chunkSize != 0 {
@@ -285,31 +950,73 @@ func (box *Box) PutMany(objects interface{}) (ids []uint64, err error) {
 				if err := box.putManyObjects(slice, ids, start, end); err != nil {
 					return err
 				}
+
+				if onProgress != nil {
+					onProgress(end, count)
+				}
 			}
 		} else {
 			for i := 0; i < count; i++ {
-				id, err := box.put(slice.Index(i).Interface(), true, cPutModePut)
+				var object = slice.index(i)
+				id, err := box.put(object, true, cPutModePut)
 				if err != nil {
-					return err
+					var objectId, _ = box.entity.binding.GetId(object)
+					return &PutManyError{Index: i, Id: objectId, Err: err}
 				}
 				ids[i] = id
+
+				if onProgress != nil && ((i+1)%putProgressInterval == 0 || i+1 == count) {
+					onProgress(i+1, count)
+				}
 			}
 		}
 
 		return nil
 	})
 
-	if err != nil {
-		ids = nil
+	if err != nil {
+		ids = nil
+	}
+
+	return ids, err
+}
+
+// PutManyError is returned by PutMany (and PutAllProgress) if putting one of the objects fails.
+// The whole transaction is rolled back, i.e. none of the objects are stored.
+type PutManyError struct {
+	// Index of the failing object in the given slice
+	Index int
+
+	// EndIndex is only set (non-zero) if the failing object couldn't be identified, e.g. a unique constraint violation
+	// reported by the database for a whole chunk of objects: the failing one is between Index and EndIndex (exclusive).
+	EndIndex int
+
+	// Id of the failing object, if known (0 otherwise); for new objects, it's the ID reserved during the transaction
+	Id uint64
+
+	// Err is the underlying cause
+	Err error
+}
+
+func (err *PutManyError) Error() string {
+	if err.EndIndex != 0 {
+		return fmt.Sprintf("objectbox: PutMany failed at index %d to %d: %s", err.Index, err.EndIndex-1, err.Err)
 	}
+	if err.Id != 0 {
+		return fmt.Sprintf("objectbox: PutMany failed at index %d (id %d): %s", err.Index, err.Id, err.Err)
+	}
+	return fmt.Sprintf("objectbox: PutMany failed at index %d: %s", err.Index, err.Err)
+}
 
-	return ids, err
+// Unwrap returns the underlying cause, e.g. for errors.Is() and errors.As()
+func (err *PutManyError) Unwrap() error {
+	return err.Err
 }
 
 // putManyObjects inserts a subset of objects, setting their IDs as an outArgument.
 // Requires to be called inside a write transaction, i.e. from the ObjectBox.RunInWriteTx() callback.
 // The caller of this method (PutMany) already sliced up the data into chunks to mitigate memory consumption.
-func (box *Box) putManyObjects(objects reflect.Value, outIds []uint64, start, end int) error {
+func (box *Box) putManyObjects(objects objectSlice, outIds []uint64, start, end int) error {
 	var binding = box.entity.binding
 	var count = end - start
 
@@ -322,12 +1029,14 @@ func (box *Box) putManyObjects(objects reflect.Value, outIds []uint64, start, en
 	// find out outIds of all the objects & whether they're new objects or updates
 	for i := 0; i < count; i++ {
 		var index = start + i
-		var object = objects.Index(index).Interface()
+		var object = objects.index(index)
 		if id, err := binding.GetId(object); err != nil {
-			return err
+			return &PutManyError{Index: index, Err: err}
 		} else if id > 0 {
 			outIds[index] = id
 			putMode = cPutModePut
+		} else if box.requiresIds() {
+			return &PutManyError{Index: index, Err: ErrIdRequired}
 		} else {
 			indexesNewObjects = append(indexesNewObjects, index)
 		}
@@ -342,26 +1051,39 @@ func (box *Box) putManyObjects(objects reflect.Value, outIds []uint64, start, en
 		outIds[indexesNewObjects[i]] = firstNewId + uint64(i)
 	}
 
-	// flatten all the objects
+	// flatten all the objects, using a single builder (instead of a pooled one per object); it grows by doubling until
+	// it fits the largest object and isn't reallocated afterwards, so sizing it up front (which would need an extra
+	// pass over the objects) doesn't make a measurable difference. The flattened objects are copied to shared chunks
+	// of memory instead of an allocation per object.
+	var fbb = fbbPool.Get().(*flatbuffers.Builder)
+	defer fbbPoolPut(fbb)
+	var arena putManyArena
+
 	var objectsBytes = make([][]byte, count)
 	for i := 0; i < count; i++ {
 		var key = start + i
-		var object = objects.Index(key).Interface()
+		var object = objects.index(key)
 
 		// put related entities for the single object
 		if box.entity.hasRelations {
 			if err := binding.PutRelated(box.ObjectBox, object, outIds[key]); err != nil {
-				return err
+				return &PutManyError{Index: key, Id: outIds[key], Err: err}
 			}
 		}
 
+		if err := box.preSave(object); err != nil {
+			return &PutManyError{Index: key, Id: outIds[key], Err: err}
+		}
+
 		// flatten each object to bytes, already with the new ID (if it's an insert)
-		if err := box.withObjectBytes(object, outIds[key], func(bytes []byte) error {
-			objectsBytes[i] = make([]byte, len(bytes))
-			copy(objectsBytes[i], bytes)
+		if err := box.flattenWith(fbb, object, outIds[key], func(bytes []byte) error {
+			if err := box.checkObjectSize(bytes); err != nil {
+				return err
+			}
+			objectsBytes[i] = arena.copy(bytes, count-i)
 			return nil
 		}); err != nil {
-			return err
+			return &PutManyError{Index: key, Id: outIds[key], Err: err}
 		}
 	}
 
@@ -378,16 +1100,19 @@ func (box *Box) putManyObjects(objects reflect.Value, outIds []uint64, start, en
 	if err := cCall(func() C.obx_err {
 		return C.obx_box_put_many(box.cBox, bytesArray.cBytesArray, idsArray, C.OBXPutMode(putMode))
 	}); err != nil {
-		return err
+		// the native call doesn't tell which object failed (e.g. a unique constraint violation), only report the chunk;
+		// the caller rolls back the whole transaction so none of the objects (nor the ones in previous chunks) are stored
+		return &PutManyError{Index: start, EndIndex: end, Err: err}
 	}
 
 	// set IDs on the new objects
 	for _, index := range indexesNewObjects {
-		if err := binding.SetId(objects.Index(index).Interface(), outIds[index]); err != nil {
-			return fmt.Errorf("setting ID on objects[%v] failed: %s", index, err)
+		if err := binding.SetId(objects.index(index), outIds[index]); err != nil {
+			return &PutManyError{Index: index, Id: outIds[index], Err: fmt.Errorf("setting ID failed: %s", err)}
 		}
 	}
 
+	box.ObjectBox.auditChange(box.entity.id, "put", outIds[start:end]...)
 	return nil
 }
 
@@ -403,9 +1128,17 @@ func (box *Box) Remove(object interface{}) error {
 
 // RemoveId deletes a single object
 func (box *Box) RemoveId(id uint64) error {
-	return cCall(func() C.obx_err {
+	if err := box.check(); err != nil {
+		return err
+	}
+
+	err := cCall(func() C.obx_err {
 		return C.obx_box_remove(box.cBox, C.obx_id(id))
 	})
+	if err == nil {
+		box.ObjectBox.auditChange(box.entity.id, "remove", id)
+	}
+	return withOperation(err, "remove", box.entity.name)
 }
 
 // RemoveIds deletes multiple objects at once.
@@ -414,6 +1147,18 @@ func (box *Box) RemoveId(id uint64) error {
 // In case you need to strictly check whether all of the objects exist before removing them,
 // you can execute multiple box.Contains() and box.Remove() inside a single write transaction.
 func (box *Box) RemoveIds(ids ...uint64) (uint64, error) {
+	if err := box.check(); err != nil {
+		return 0, err
+	} else if len(ids) == 0 {
+		return 0, nil
+	}
+
+	// the native call doesn't tell which of the objects existed so remove them one by one to report the removed ones
+	if box.ObjectBox.auditing() {
+		removed, _, err := box.RemoveManyReport(ids...)
+		return uint64(len(removed)), err
+	}
+
 	cIds, err := goIdsArrayToC(ids)
 	if err != nil {
 		return 0, err
@@ -424,15 +1169,59 @@ func (box *Box) RemoveIds(ids ...uint64) (uint64, error) {
 		defer cIds.free()
 		return C.obx_box_remove_many(box.cBox, cIds.cArray, &cResult)
 	})
-	return uint64(cResult), err
+	return uint64(cResult), withOperation(err, "remove", box.entity.name)
+}
+
+// RemoveManyReport deletes the objects with the given IDs in a single write transaction, like RemoveIds(), additionally
+// reporting which of them were actually removed and which didn't exist, e.g. when reconciling with an external list.
+// Both slices keep the order of the given IDs; an ID given multiple times is reported as removed only once, its
+// following occurrences are reported as not found.
+func (box *Box) RemoveManyReport(ids ...uint64) (removed []uint64, notFound []uint64, err error) {
+	removed = make([]uint64, 0, len(ids))
+	notFound = make([]uint64, 0)
+	if len(ids) == 0 {
+		if err := box.check(); err != nil {
+			return nil, nil, err
+		}
+		return removed, notFound, nil
+	}
+
+	err = box.ObjectBox.RunInWriteTx(func() error {
+		for _, id := range ids {
+			// NOTE: no need for manual runtime.LockOSThread() because we're inside a transaction
+			var rc = C.obx_box_remove(box.cBox, C.obx_id(id))
+			if rc == 0 {
+				removed = append(removed, id)
+				box.ObjectBox.auditChange(box.entity.id, "remove", id)
+			} else if rc == C.OBX_NOT_FOUND {
+				notFound = append(notFound, id)
+			} else {
+				return createError()
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, nil, withOperation(err, "remove", box.entity.name)
+	}
+	return removed, notFound, nil
 }
 
 // RemoveAll removes all stored objects.
 // This is much faster than removing objects one by one in a loop.
 func (box *Box) RemoveAll() error {
-	return cCall(func() C.obx_err {
+	if err := box.check(); err != nil {
+		return err
+	}
+
+	err := cCall(func() C.obx_err {
 		return C.obx_box_remove_all(box.cBox, nil)
 	})
+	if err == nil {
+		box.ObjectBox.auditChange(box.entity.id, "remove", 0)
+	}
+	return withOperation(err, "remove", box.entity.name)
 }
 
 // Count returns a number of objects stored
@@ -443,15 +1232,23 @@ func (box *Box) Count() (uint64, error) {
 // CountMax returns a number of objects stored (up to a given maximum)
 // passing limit=0 is the same as calling Count() - counts all objects without a limit
 func (box *Box) CountMax(limit uint64) (uint64, error) {
+	if err := box.check(); err != nil {
+		return 0, err
+	}
+
 	var cResult C.uint64_t
 	if err := cCall(func() C.obx_err { return C.obx_box_count(box.cBox, C.uint64_t(limit), &cResult) }); err != nil {
-		return 0, err
+		return 0, withOperation(err, "count", box.entity.name)
 	}
 	return uint64(cResult), nil
 }
 
 // IsEmpty checks whether the box contains any objects
 func (box *Box) IsEmpty() (bool, error) {
+	if err := box.check(); err != nil {
+		return false, err
+	}
+
 	var cResult C.bool
 	if err := cCall(func() C.obx_err { return C.obx_box_is_empty(box.cBox, &cResult) }); err != nil {
 		return false, err
@@ -464,6 +1261,11 @@ func (box *Box) IsEmpty() (bool, error) {
 // Returns an interface that should be cast to the appropriate type.
 // Returns nil in case the object with the given ID doesn't exist.
 // The cast is done automatically when using the generated BoxFor* code.
+//
+// The returned object is fully owned by the caller: all its values, including strings and byte vectors, are copied
+// out of the database memory while the read transaction is open. It stays valid after the transaction is closed and
+// can be freely modified or retained without affecting the stored data or other objects. The same applies to objects
+// returned by GetMany(), GetAll(), queries, etc.; use GetBytes() to access the raw (also copied) data.
 func (box *Box) Get(id uint64) (object interface{}, err error) {
 	// we need a read-transaction to keep the data in dataPtr untouched (by concurrent write) until we can read it
 	// as well as making sure the relations read in binding.Load represent a consistent state
@@ -476,7 +1278,7 @@ func (box *Box) Get(id uint64) (object interface{}, err error) {
 		if rc == 0 {
 			var bytes []byte
 			cVoidPtrToByteSlice(dataPtr, int(dataSize), &bytes)
-			object, err = box.entity.binding.Load(box.ObjectBox, bytes)
+			object, err = box.load(bytes)
 			return err
 		} else if rc == C.OBX_NOT_FOUND {
 			object = nil
@@ -489,7 +1291,123 @@ func (box *Box) Get(id uint64) (object interface{}, err error) {
 
 	})
 
-	return object, err
+	return object, withOperation(err, "get", box.entity.name)
+}
+
+// GetBytes reads the stored (FlatBuffers serialized) data of a single object without deserializing it,
+// e.g. to send it to another database. The returned slice is a copy, owned by the caller.
+// Returns nil in case the object with the given ID doesn't exist.
+func (box *Box) GetBytes(id uint64) (bytes []byte, err error) {
+	err = box.ObjectBox.RunInReadTx(func() error {
+		bytes, err = box.getBytesCopy(id)
+		return err
+	})
+	return bytes, withOperation(err, "get", box.entity.name)
+}
+
+// GetBytesMany reads the stored data of multiple objects, like GetBytes(), in a single read transaction.
+// Returns a slice of the same length as the given IDs, with nil entries for the objects that don't exist.
+func (box *Box) GetBytesMany(ids ...uint64) (slice [][]byte, err error) {
+	slice = make([][]byte, len(ids))
+	if len(ids) == 0 {
+		return slice, box.check()
+	}
+	err = box.ObjectBox.RunInReadTx(func() error {
+		for i, id := range ids {
+			var err error
+			if slice[i], err = box.getBytesCopy(id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		slice = nil
+	}
+	return slice, withOperation(err, "get", box.entity.name)
+}
+
+// getBytesCopy must be called inside a read transaction; the data is only valid until the transaction is closed so
+// it's copied to Go memory
+func (box *Box) getBytesCopy(id uint64) ([]byte, error) {
+	var data *C.void
+	var dataSize C.size_t
+	var dataPtr = unsafe.Pointer(data)
+
+	var rc = C.obx_box_get(box.cBox, C.obx_id(id), &dataPtr, &dataSize)
+	if rc == C.OBX_NOT_FOUND {
+		return nil, nil
+	} else if rc != 0 {
+		return nil, createError()
+	}
+
+	var bytes []byte
+	cVoidPtrToByteSlice(dataPtr, int(dataSize), &bytes)
+	var result = make([]byte, len(bytes))
+	copy(result, bytes)
+	return result, nil
+}
+
+// GetContext reads a single object, like Get(), but gives up waiting when the given context is done, e.g. if all
+// reader slots are in use by other reads (see Builder.MaxReaders()). In that case, the returned error wraps ctx.Err()
+// (use errors.Is) and nothing is read.
+//
+// Concurrent calls of GetContext() (of all boxes of the store) are limited to the number of reader slots: a call waits
+// for one of the running ones to finish, or for the context to be done, before it starts its read transaction. This
+// keeps requests that have given up from piling up on the store. Once started, the read isn't interrupted anymore;
+// reads by other functions, e.g. Get(), aren't limited and don't wait for a slot.
+func (box *Box) GetContext(ctx context.Context, id uint64) (object interface{}, err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("get %d abandoned: %w", id, err)
+	}
+
+	var slots = box.ObjectBox.readSlots
+	select {
+	case slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("get %d abandoned: %w", id, ctx.Err())
+	}
+	defer func() { <-slots }()
+
+	return box.Get(id)
+}
+
+// WaitForId waits until an object with the given ID is stored and returns it, e.g. when the object is expected to
+// arrive from a sync server. The box is checked right away and then every poll interval.
+// Returns ctx.Err() if the context is done (cancelled or its deadline exceeded) before the object appears.
+func (box *Box) WaitForId(ctx context.Context, id uint64, poll time.Duration) (object interface{}, err error) {
+	if poll <= 0 {
+		return nil, fmt.Errorf("invalid poll interval %v, must be positive", poll)
+	}
+
+	var ticker = time.NewTicker(poll)
+	defer ticker.Stop()
+
+	for {
+		if object, err = box.Get(id); err != nil || object != nil {
+			return object, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetAfterAsync reads a single object, like Get(), after waiting for previously submitted async operations to be
+// processed. This provides "read-your-writes" semantics for objects put using Async(), e.g. box.Async().Put().
+//
+// The guarantee is that all async operations submitted (on any box of the store) before this call are committed
+// before the object is read. As opposed to ObjectBox.AwaitAsyncCompletion(), this doesn't wait for the async queue
+// to become idle, i.e. operations submitted concurrently (by other goroutines) after this call don't cause a delay.
+func (box *Box) GetAfterAsync(id uint64) (object interface{}, err error) {
+	if err := box.async.AwaitSubmitted(); err != nil {
+		return nil, err
+	}
+	return box.Get(id)
 }
 
 // GetMany reads multiple objects at once.
@@ -500,7 +1418,9 @@ func (box *Box) Get(id uint64) (object interface{}, err error) {
 //  is nil or an empty object (depends on the binding)
 func (box *Box) GetMany(ids ...uint64) (slice interface{}, err error) {
 	const existingOnly = false
-	if cIds, err := goIdsArrayToC(ids); err != nil {
+	if len(ids) == 0 {
+		return box.emptySlice()
+	} else if cIds, err := goIdsArrayToC(ids); err != nil {
 		return nil, err
 	} else if supportsResultArray {
 		defer cIds.free()
@@ -520,7 +1440,9 @@ func (box *Box) GetMany(ids ...uint64) (slice interface{}, err error) {
 // The cast is done automatically when using the generated BoxFor* code.
 func (box *Box) GetManyExisting(ids ...uint64) (slice interface{}, err error) {
 	const existingOnly = true
-	if cIds, err := goIdsArrayToC(ids); err != nil {
+	if len(ids) == 0 {
+		return box.emptySlice()
+	} else if cIds, err := goIdsArrayToC(ids); err != nil {
 		return nil, err
 	} else if supportsResultArray {
 		defer cIds.free()
@@ -534,6 +1456,14 @@ func (box *Box) GetManyExisting(ids ...uint64) (slice interface{}, err error) {
 	}
 }
 
+// emptySlice returns the result of bulk reads without any IDs given (after checking the store is open)
+func (box *Box) emptySlice() (interface{}, error) {
+	if err := box.check(); err != nil {
+		return nil, err
+	}
+	return box.entity.binding.MakeSlice(0), nil
+}
+
 // GetAll reads all stored objects.
 //
 // Returns a slice of objects that should be cast to the appropriate type.
@@ -550,6 +1480,138 @@ func (box *Box) GetAll() (slice interface{}, err error) {
 	return box.readUsingVisitor(existingOnly, cFn)
 }
 
+// GetAllWhere reads all objects for which the given predicate returns true, streaming through the box so that only
+// the matching objects are collected in the resulting slice (of the same type as returned by GetAll).
+//
+// Note: this is always a full scan with each object loaded just to evaluate the predicate; use a Query instead if the
+// filter can be expressed as query conditions, especially on indexed properties.
+// The predicate is called inside a read transaction and must not issue any store operations.
+func (box *Box) GetAllWhere(predicate func(object interface{}) bool) (slice interface{}, err error) {
+	var objects = box.newSliceBuilder(defaultSliceCapacity)
+	var visitor uint32
+	visitor, err = dataVisitorRegister(func(bytes []byte) bool {
+		object, err2 := box.load(bytes)
+		if err2 != nil {
+			err = err2
+			return false
+		}
+		if object != nil && predicate(object) {
+			objects.append(object)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer dataVisitorUnregister(visitor)
+
+	// use another `error` variable as `err` may be set by the visitor callback above
+	var err2 = box.ObjectBox.RunInReadTx(func() error {
+		return cCall(func() C.obx_err {
+			return C.obx_box_visit_all(box.cBox, dataVisitor, unsafe.Pointer(&visitor))
+		})
+	})
+
+	if err2 != nil {
+		return nil, err2
+	} else if err != nil {
+		return nil, err
+	}
+	return objects.get(), nil
+}
+
+// ForEachReverse calls fn for each stored object in descending ID order, i.e. the most recently inserted objects first
+// (IDs are assigned in an ascending order). The objects are streamed one by one inside a single read transaction,
+// so it's suitable for boxes too large to be read at once. Return an error from fn to stop the iteration early,
+// e.g. once the "latest N" objects have been processed; the error is then returned by ForEachReverse.
+//
+// The order is applied by the native query (ordering by the ID property), the objects aren't collected in Go.
+// fn is called inside the read transaction and must not issue any write operations.
+func (box *Box) ForEachReverse(fn func(object interface{}) error) error {
+	var idProperty = box.entity.idProperty()
+	query, err := box.QueryOrError(&orderClosure{
+		apply: func(qb *QueryBuilder) error {
+			return qb.orderDesc(idProperty)
+		},
+	})
+	if err != nil {
+		return err
+	}
+	defer query.Close()
+
+	var visitor uint32
+	visitor, err = dataVisitorRegister(func(bytes []byte) bool {
+		object, err2 := box.load(bytes)
+		if err2 == nil && object != nil {
+			err2 = fn(object)
+		}
+		if err2 != nil {
+			err = err2
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	defer dataVisitorUnregister(visitor)
+
+	// use another `error` variable as `err` may be set by the visitor callback above
+	var err2 = box.ObjectBox.RunInReadTx(func() error {
+		return cCall(func() C.obx_err {
+			return C.obx_query_visit(query.cQuery, dataVisitor, unsafe.Pointer(&visitor))
+		})
+	})
+
+	if err2 != nil {
+		return err2
+	}
+	return err
+}
+
+// GetIdRange reads all objects with IDs between lo and hi (including lo and hi).
+// The condition is evaluated on the primary key so only the objects in the given range are visited (no full scan).
+// This is useful for processing a box in chunks or for keyset pagination by ID. Use math.MaxUint64 as hi to read all
+// objects starting at lo; an error is returned if lo is greater than hi.
+//
+// Returns a slice of objects that should be cast to the appropriate type.
+// The cast is done automatically when using the generated BoxFor* code.
+func (box *Box) GetIdRange(lo, hi uint64) (slice interface{}, err error) {
+	query, err := box.QueryIdRange(lo, hi)
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	return query.Find()
+}
+
+// QueryIdRange creates the query used by GetIdRange(), e.g. to count the objects in the range or to inspect the query
+// using DescribeParams(). The returned query must be closed.
+func (box *Box) QueryIdRange(lo, hi uint64) (*Query, error) {
+	return box.QueryOrError(box.idBetween(lo, hi))
+}
+
+// idBetween creates a condition matching objects with IDs between lo and hi (including lo and hi); hi is limited to
+// the largest ID the native library assigns (math.MaxInt64) as the condition works on int64 values
+func (box *Box) idBetween(lo, hi uint64) Condition {
+	return &conditionClosure{
+		apply: func(qb *QueryBuilder) (ConditionId, error) {
+			if lo > hi {
+				return 0, fmt.Errorf("invalid ID range %d..%d, lo must not be greater than hi", lo, hi)
+			} else if lo > math.MaxInt64 {
+				return 0, fmt.Errorf("invalid ID range %d..%d, lo exceeds the maximum ID %d", lo, hi,
+					uint64(math.MaxInt64))
+			}
+			var max = hi
+			if max > math.MaxInt64 {
+				max = math.MaxInt64
+			}
+			return qb.IntBetween(box.entity.idProperty(), int64(lo), int64(max))
+		},
+	}
+}
+
 func (box *Box) readManyObjects(existingOnly bool, cFn func() *C.OBX_bytes_array) (slice interface{}, err error) {
 	// we need a read-transaction to keep the data in dataPtr untouched (by concurrent write) until we can read it
 	// as well as making sure the relations read in binding.Load represent a consistent state
@@ -559,23 +1621,25 @@ func (box *Box) readManyObjects(existingOnly bool, cFn func() *C.OBX_bytes_array
 			return err
 		}
 
-		var binding = box.entity.binding
-		slice = binding.MakeSlice(len(bytesArray))
+		var objects = box.newSliceBuilder(len(bytesArray))
 		for _, bytesData := range bytesArray {
 			if bytesData == nil {
 				// may be nil if an object on this index was not found (can happen with GetMany)
 				if !existingOnly {
-					slice = binding.AppendToSlice(slice, nil)
+					objects.append(nil)
 				}
 				continue
 			}
 
-			object, err := binding.Load(box.ObjectBox, bytesData)
+			object, err := box.load(bytesData)
 			if err != nil {
 				return err
+			} else if object == nil && existingOnly {
+				continue // expired
 			}
-			slice = binding.AppendToSlice(slice, object)
+			objects.append(object)
 		}
+		slice = objects.get()
 		return nil
 	})
 
@@ -588,23 +1652,25 @@ func (box *Box) readManyObjects(existingOnly bool, cFn func() *C.OBX_bytes_array
 
 // this is a utility function to fetch objects using an obx_data_visitor
 func (box *Box) readUsingVisitor(existingOnly bool, cFn func(visitorArg unsafe.Pointer) C.obx_err) (slice interface{}, err error) {
-	var binding = box.entity.binding
+	var objects = box.newSliceBuilder(defaultSliceCapacity)
 	var visitor uint32
 	visitor, err = dataVisitorRegister(func(bytes []byte) bool {
 		// may be nil if an object on this index was not found (can happen with GetMany)
 		if bytes == nil {
 			if !existingOnly {
-				slice = binding.AppendToSlice(slice, nil)
+				objects.append(nil)
 			}
 			return true
 		}
 
-		object, err2 := binding.Load(box.ObjectBox, bytes)
+		object, err2 := box.load(bytes)
 		if err2 != nil {
 			err = err2
 			return false
+		} else if object == nil && existingOnly {
+			return true // expired
 		}
-		slice = binding.AppendToSlice(slice, object)
+		objects.append(object)
 		return true
 	})
 	if err != nil {
@@ -612,8 +1678,6 @@ func (box *Box) readUsingVisitor(existingOnly bool, cFn func(visitorArg unsafe.P
 	}
 	defer dataVisitorUnregister(visitor)
 
-	slice = binding.MakeSlice(defaultSliceCapacity)
-
 	// we need a read-transaction to keep the data in dataPtr untouched (by concurrent write) until we can read it
 	// as well as making sure the relations read in binding.Load represent a consistent state
 	// use another `error` variable as `err` may be set by the visitor callback above
@@ -626,12 +1690,16 @@ func (box *Box) readUsingVisitor(existingOnly bool, cFn func(visitorArg unsafe.P
 	} else if err != nil {
 		return nil, err
 	} else {
-		return slice, nil
+		return objects.get(), nil
 	}
 }
 
 // Contains checks whether an object with the given ID is stored.
 func (box *Box) Contains(id uint64) (bool, error) {
+	if err := box.check(); err != nil {
+		return false, err
+	}
+
 	var cResult C.bool
 	if err := cCall(func() C.obx_err { return C.obx_box_contains(box.cBox, C.obx_id(id), &cResult) }); err != nil {
 		return false, err
@@ -641,6 +1709,12 @@ func (box *Box) Contains(id uint64) (bool, error) {
 
 // ContainsIds checks whether all of the given objects are stored in DB.
 func (box *Box) ContainsIds(ids ...uint64) (bool, error) {
+	if err := box.check(); err != nil {
+		return false, err
+	} else if len(ids) == 0 {
+		return true, nil
+	}
+
 	cIds, err := goIdsArrayToC(ids)
 	if err != nil {
 		return false, err
@@ -654,8 +1728,157 @@ func (box *Box) ContainsIds(ids ...uint64) (bool, error) {
 	return bool(cResult), err
 }
 
+// AllIds returns the IDs of all stored objects in ascending order. Only the IDs are read (from the primary key), the
+// objects aren't loaded. For large boxes, consider ForEachId() to avoid holding all IDs in memory at once.
+func (box *Box) AllIds() ([]uint64, error) {
+	if err := box.check(); err != nil {
+		return nil, err
+	}
+
+	var idProperty = PropertyUint64{BaseProperty: box.entity.idProperty()}
+	query, err := box.QueryOrError(idProperty.OrderAsc())
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	return query.FindIds()
+}
+
+// idBatchSize is the number of IDs read in a single transaction by Box.ForEachId()
+const idBatchSize = 10000
+
+// ForEachId calls fn for the ID of each stored object in ascending order, without loading the objects. The IDs are
+// read in batches, each in its own read transaction (i.e. like ReadLatest), so fn may issue any store operations;
+// objects put or removed meanwhile are reflected from the next batch on if their ID is greater than the last one
+// passed to fn. Return an error from fn to stop the iteration early; the error is then returned by ForEachId.
+func (box *Box) ForEachId(fn func(id uint64) error) error {
+	if err := box.check(); err != nil {
+		return err
+	}
+
+	var idProperty = PropertyUint64{BaseProperty: box.entity.idProperty()}
+	query, err := box.QueryOrError(idProperty.GreaterThan(0), idProperty.OrderAsc())
+	if err != nil {
+		return err
+	}
+	defer query.Close()
+	query.Limit(idBatchSize)
+
+	for {
+		ids, err := query.FindIds()
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			if err := fn(id); err != nil {
+				return err
+			}
+		}
+
+		if len(ids) < idBatchSize {
+			return nil
+		} else if err := query.SetInt64Params(idProperty, int64(ids[len(ids)-1])); err != nil {
+			return err
+		}
+	}
+}
+
+// ReadConsistency selects which state of the database a scan reading in batches sees, see Box.ForEachBatch().
+type ReadConsistency int
+
+const (
+	// ReadLatest reads each batch in its own read transaction: every batch is consistent in itself and reflects the
+	// changes committed before it was read (objects with an ID greater than the last one already processed). The
+	// callback may issue any store operations and space of changed data can be reused during the scan.
+	ReadLatest ReadConsistency = iota
+
+	// ReadSnapshot reads all batches in a single read transaction: the whole scan sees a consistent snapshot of the
+	// database as of its start, writes committed meanwhile aren't visible. The callback runs inside the transaction
+	// and must not issue write operations; the database can't reuse the space of data changed until the scan ends,
+	// so it may grow if there are many concurrent writes during a long scan.
+	ReadSnapshot
+)
+
+// ForEachBatch reads all stored objects in ascending ID order in batches of up to batchSize objects, calling fn with
+// each batch (a slice of the same type as returned by GetAll), so that a box too large to be read at once can be
+// processed. The consistency determines whether all batches are read from the same snapshot or each one reflects the
+// latest state, see ReadSnapshot and ReadLatest. Return an error from fn to stop the iteration early; the error is
+// then returned by ForEachBatch.
+func (box *Box) ForEachBatch(batchSize int, consistency ReadConsistency, fn func(slice interface{}) error) error {
+	if batchSize <= 0 {
+		return fmt.Errorf("invalid batch size %d, must be positive", batchSize)
+	} else if consistency != ReadLatest && consistency != ReadSnapshot {
+		return fmt.Errorf("unknown read consistency %d", consistency)
+	} else if err := box.check(); err != nil {
+		return err
+	}
+
+	var idProperty = PropertyUint64{BaseProperty: box.entity.idProperty()}
+	query, err := box.QueryOrError(idProperty.GreaterThan(0), idProperty.OrderAsc())
+	if err != nil {
+		return err
+	}
+	defer query.Close()
+	query.Limit(uint64(batchSize))
+
+	// readBatch returns the objects of the next batch and whether there may be more
+	var readBatch = func() (slice interface{}, more bool, err error) {
+		ids, err := query.FindIds()
+		if err != nil || len(ids) == 0 {
+			return nil, false, err
+		}
+		if slice, err = box.GetManyExisting(ids...); err != nil {
+			return nil, false, err
+		}
+		if len(ids) == batchSize {
+			err = query.SetInt64Params(idProperty, int64(ids[len(ids)-1]))
+		}
+		return slice, len(ids) == batchSize, err
+	}
+
+	var scan = func() error {
+		for {
+			var slice interface{}
+			var more bool
+			var err error
+			if consistency == ReadSnapshot {
+				slice, more, err = readBatch()
+			} else {
+				err = box.ObjectBox.RunInReadTx(func() error {
+					slice, more, err = readBatch()
+					return err
+				})
+			}
+			if err != nil {
+				return err
+			}
+
+			// skip batches without objects, i.e. if all of them have expired, see SetTTL()
+			if slice != nil && box.objectSlice(slice).len() > 0 {
+				if err := fn(slice); err != nil {
+					return err
+				}
+			}
+			if !more {
+				return nil
+			}
+		}
+	}
+
+	if consistency == ReadSnapshot {
+		return box.ObjectBox.RunInReadTx(scan)
+	}
+	return scan()
+}
+
 // RelationIds returns IDs of all target objects related to the given source object ID
 func (box *Box) RelationIds(relation *RelationToMany, sourceId uint64) ([]uint64, error) {
+	if err := box.check(); err != nil {
+		return nil, err
+	}
+
 	targetBox, err := box.ObjectBox.box(relation.Target.Id)
 	if err != nil {
 		return nil, err
@@ -751,6 +1974,10 @@ func (box *Box) RelationReplace(relation *RelationToMany, sourceId uint64, sourc
 
 // RelationPut creates a relation between the given source & target objects
 func (box *Box) RelationPut(relation *RelationToMany, sourceId, targetId uint64) error {
+	if err := box.check(); err != nil {
+		return err
+	}
+
 	return cCall(func() C.obx_err {
 		return C.obx_box_rel_put(box.cBox, C.obx_schema_id(relation.Id), C.obx_id(sourceId), C.obx_id(targetId))
 	})
@@ -758,6 +1985,10 @@ func (box *Box) RelationPut(relation *RelationToMany, sourceId, targetId uint64)
 
 // RelationRemove removes a relation between the given source & target objects
 func (box *Box) RelationRemove(relation *RelationToMany, sourceId, targetId uint64) error {
+	if err := box.check(); err != nil {
+		return err
+	}
+
 	return cCall(func() C.obx_err {
 		return C.obx_box_rel_remove(box.cBox, C.obx_schema_id(relation.Id), C.obx_id(sourceId), C.obx_id(targetId))
 	})
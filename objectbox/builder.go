@@ -23,11 +23,68 @@ package objectbox
 import "C"
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"runtime"
+	"strings"
+	"sync"
 	"unsafe"
 )
 
+// ErrAlreadyOpen is returned by Builder.BuildOrError() if there's already an open ObjectBox in this process using the
+// same directory. Close() the existing instance first or reuse it instead of opening a new one.
+var ErrAlreadyOpen = errors.New("an ObjectBox using the same directory is already open in this process")
+
+// ErrDirectoryMissing is returned by Builder.BuildOrError() (wrapped, use errors.Is) if the parent of the database
+// directory doesn't exist. Use Builder.CreateDirectory() to create the whole path automatically.
+var ErrDirectoryMissing = errors.New("database directory can't be created, its parent directory doesn't exist")
+
+// ErrCorrupt is matched (use errors.Is) by errors caused by corrupted or invalid database files, e.g. returned by
+// Builder.BuildOrError(). Consider restoring the database from a backup or see Builder.RecoverMode().
+var ErrCorrupt = errors.New("database files are corrupted")
+
+// defaultDirectory is used by the C-API if no directory is configured
+const defaultDirectory = "objectbox"
+
+// openDirectories keeps track of directories of ObjectBox instances open in this process
+var openDirectories = struct {
+	sync.Mutex
+	dirs map[string]bool
+}{dirs: make(map[string]bool)}
+
+// registerDirectory marks the given directory as open; returns ErrAlreadyOpen if it's already marked
+func registerDirectory(dir string) error {
+	openDirectories.Lock()
+	defer openDirectories.Unlock()
+
+	if openDirectories.dirs[dir] {
+		return ErrAlreadyOpen
+	}
+	openDirectories.dirs[dir] = true
+	return nil
+}
+
+func unregisterDirectory(dir string) {
+	openDirectories.Lock()
+	defer openDirectories.Unlock()
+
+	delete(openDirectories.dirs, dir)
+}
+
+// directoryKey normalizes the directory so that different paths to the same location are treated equally
+func directoryKey(dir string) string {
+	if strings.HasPrefix(dir, "memory:") {
+		return dir
+	}
+
+	if abs, err := filepath.Abs(dir); err == nil {
+		return abs
+	}
+	return filepath.Clean(dir)
+}
+
 // Builder provides tools to fully configure and construct ObjectBox
 type Builder struct {
 	model *Model
@@ -35,9 +92,19 @@ type Builder struct {
 
 	// these options are used when creating the underlying store using the C-api calls
 	// pointers are used to distinguish whether a value is present or not
-	directory   *string
-	maxSizeInKb *uint64
-	maxReaders  *uint
+	directory       *string
+	createDirectory *os.FileMode
+	fileMode        *os.FileMode
+	maxSizeInKb     *uint64
+	maxReaders      *uint
+	validatePages   *uint
+	validateKv      bool
+	recoverMode     bool
+	readOnly        bool
+
+	// checked and stored in the directory by BuildOrError(), see SchemaVersion()
+	schemaVersion    *uint
+	maxSchemaVersion *uint
 
 	// these options are passed-through to the created ObjectBox struct
 	options
@@ -71,19 +138,129 @@ func (builder *Builder) Directory(path string) *Builder {
 	return builder
 }
 
+// CreateDirectory makes sure the database directory exists before opening the store, creating it together with any
+// missing parent directories using the given permissions (e.g. 0755). Without this option, only the last path
+// element is created if necessary and ErrDirectoryMissing is returned if its parent doesn't exist.
+func (builder *Builder) CreateDirectory(perm os.FileMode) *Builder {
+	builder.createDirectory = &perm
+	return builder
+}
+
+// FileMode sets the permissions of the database files (default: 0644).
+// Note: the native library keeps the data and the lock file in the same directory; these can't be separated.
+func (builder *Builder) FileMode(mode os.FileMode) *Builder {
+	builder.fileMode = &mode
+	return builder
+}
+
 // MaxSizeInKb defines maximum size the database can take on disk (default: 1 GByte).
 func (builder *Builder) MaxSizeInKb(maxSizeInKb uint64) *Builder {
 	builder.maxSizeInKb = &maxSizeInKb
 	return builder
 }
 
-// MaxReaders defines maximum concurrent readers (default: 126).
+// MaxReaders defines maximum concurrent readers (default: 126, also used if 0 is given).
 // Increase only if you are getting errors (highly concurrent scenarios).
 func (builder *Builder) MaxReaders(maxReaders uint) *Builder {
 	builder.maxReaders = &maxReaders
 	return builder
 }
 
+// ValidateOnOpen checks up to the given number of database pages when opening the store to detect corrupted files
+// early (BuildOrError() then returns an error matching ErrCorrupt). Usually a low number (e.g. 1-20) is sufficient
+// and doesn't noticeably impact the startup time. Relevant mostly for unreliable file systems or hardware.
+func (builder *Builder) ValidateOnOpen(pageLimit uint) *Builder {
+	builder.validatePages = &pageLimit
+	builder.validateKv = false
+	return builder
+}
+
+// ValidationLevel selects how thoroughly the database is checked when opening the store, see Builder.ValidationLevel()
+type ValidationLevel int
+
+const (
+	// ValidationNone disables the validation; the fastest startup. Consistency is still guaranteed by the ACID storage,
+	// given that the file system and the hardware work correctly.
+	ValidationNone ValidationLevel = iota
+
+	// ValidationQuick checks a small number of database pages (including leaf pages), catching the most common
+	// file-level corruptions with a barely noticeable impact on the startup time. Same as ValidateOnOpen(20).
+	ValidationQuick
+
+	// ValidationFull checks all database pages and additionally the stored key/value pairs against the internal
+	// specification. This reads the whole database file so the startup time grows with the database size; intended for
+	// debugging or CI rather than for production use.
+	ValidationFull
+)
+
+// validationQuickPages is the number of pages checked by ValidationQuick
+const validationQuickPages = 20
+
+// ValidationLevel configures the validation executed when opening the store (BuildOrError() returns an error matching
+// ErrCorrupt if it fails); overrides ValidateOnOpen(). The native library doesn't offer checksums so the levels differ in
+// the number of checked pages and whether the key/value pairs are validated as well, see the constants for details.
+func (builder *Builder) ValidationLevel(level ValidationLevel) *Builder {
+	var pageLimit uint
+	switch level {
+	case ValidationNone:
+		pageLimit = 0
+	case ValidationQuick:
+		pageLimit = validationQuickPages
+	case ValidationFull:
+		pageLimit = ^uint(0) // i.e. no limit
+	default:
+		builder.Error = fmt.Errorf("unknown validation level %d", level)
+		return builder
+	}
+
+	builder.validatePages = &pageLimit
+	builder.validateKv = level == ValidationFull
+	return builder
+}
+
+// RecoverMode opens the store read-only using the previous committed state instead of the latest one. It may allow
+// to open a database that can't be opened normally (e.g. due to ErrCorrupt) to save its data into a new store or to
+// decide whether to restore it from a backup.
+//
+// Note: the recovery is lossy - the data from the latest committed transaction is missing and the previous state may
+// be damaged as well. Always copy the database files before trying the recovery. Write transactions fail in this mode.
+func (builder *Builder) RecoverMode() *Builder {
+	builder.recoverMode = true
+	return builder
+}
+
+// ReadOnly opens the store in read-only mode: the database files aren't modified (no schema update) and write
+// transactions fail. The native library doesn't acquire the write lock in this mode, so another process may keep
+// writing to the same database meanwhile. See also OpenReadOnly().
+func (builder *Builder) ReadOnly() *Builder {
+	builder.readOnly = true
+	return builder
+}
+
+// OpenReadOnly opens the database at the given path in read-only mode, e.g. to verify a backup (a copy of the
+// database files) can be opened and contains the expected data before relying on it. The path is either the
+// database directory or the data file (data.mdb) inside it. The returned ObjectBox is independent of any other open
+// store; Close() it once done.
+//
+// Note: a database directory can only be opened once per process, so verify a copy instead of the directory of a
+// store open in this process (ErrAlreadyOpen is returned otherwise).
+func OpenReadOnly(path string, model *Model) (*ObjectBox, error) {
+	var directory = path
+	if info, err := os.Stat(path); err != nil {
+		return nil, err
+	} else if !info.IsDir() {
+		if filepath.Base(path) != dataFileName {
+			return nil, fmt.Errorf("%s is neither a database directory nor a %s file", path, dataFileName)
+		}
+		directory = filepath.Dir(path)
+	}
+
+	return NewBuilder().Model(model).Directory(directory).ReadOnly().BuildOrError()
+}
+
+// dataFileName is the name of the database file within the database directory
+const dataFileName = "data.mdb"
+
 // asyncTimeoutTBD configures the default enqueue timeout for async operations (default is 1 second).
 // See Box.PutAsync method doc for more information.
 // TODO: implement this option in core and use it
@@ -110,6 +287,47 @@ func (builder *Builder) Model(model *Model) *Builder {
 	return builder
 }
 
+// RegisterEntity adds an entity, described by the given binding, to the model configured by Model(), e.g. for an
+// entity defined by a plugin or a binding written by hand instead of being generated. The model's last entity,
+// index and relation IDs are raised to include the entity's ones if necessary. Call it after Model(); the model is
+// validated again as a whole.
+//
+// See ObjectBinding for the contract the binding has to fulfil. Access the objects using ObjectBox.InternalBox() with
+// the entity ID, e.g. ob.InternalBox(id).Put(object).
+func (builder *Builder) RegisterEntity(binding ObjectBinding) *Builder {
+	if builder.Error != nil {
+		return builder
+	} else if builder.model == nil {
+		builder.Error = errors.New("model is not defined, call Model() before RegisterEntity()")
+		return builder
+	}
+
+	var model = builder.model
+	model.RegisterBinding(binding)
+	if model.Error != nil {
+		builder.Error = model.Error
+		return builder
+	}
+
+	var entity = model.entities[len(model.entities)-1]
+	if entity.id > model.lastEntityId {
+		model.LastEntityId(entity.id, entity.uid)
+	}
+	for _, property := range entity.properties {
+		if property.indexId > model.lastIndexId {
+			model.LastIndexId(property.indexId, property.indexUid)
+		}
+	}
+	for _, relation := range entity.relations {
+		if relation.id > model.lastRelationId {
+			model.LastRelationId(relation.id, relation.uid)
+		}
+	}
+
+	builder.Error = model.validate()
+	return builder
+}
+
 // Build validates the configuration and tries to init the ObjectBox.
 // This call panics on failures; if ObjectBox is optional for your app, consider BuildOrError().
 func (builder *Builder) Build() (*ObjectBox, error) {
@@ -121,6 +339,10 @@ func (builder *Builder) Build() (*ObjectBox, error) {
 }
 
 // BuildOrError validates the configuration and tries to init the ObjectBox.
+// Returns ErrAlreadyOpen if another ObjectBox using the same directory is currently open in this process
+// and ErrDirectoryMissing (wrapped) if the directory can't be created because its parent doesn't exist.
+// Errors caused by corrupted database files match ErrCorrupt (use errors.Is) and a database opened by a program with
+// a newer schema version results in an error matching ErrSchemaTooNew, see SchemaVersion().
 func (builder *Builder) BuildOrError() (*ObjectBox, error) {
 	if builder.Error != nil {
 		return nil, builder.Error
@@ -130,6 +352,84 @@ func (builder *Builder) BuildOrError() (*ObjectBox, error) {
 		return nil, fmt.Errorf("model is not defined")
 	}
 
+	var directory = defaultDirectory
+	if builder.directory != nil {
+		directory = *builder.directory
+	}
+
+	if err := builder.prepareDirectory(directory); err != nil {
+		return nil, err
+	}
+
+	directory = directoryKey(directory)
+
+	if err := registerDirectory(directory); err != nil {
+		return nil, err
+	}
+
+	if err := builder.checkSchemaVersion(directory); err != nil {
+		unregisterDirectory(directory)
+		return nil, err
+	}
+
+	objectBox, err := builder.open()
+	if err != nil {
+		unregisterDirectory(directory)
+		return nil, err
+	}
+
+	if err := builder.storeSchemaVersion(directory); err != nil {
+		objectBox.Close()
+		unregisterDirectory(directory)
+		return nil, err
+	}
+
+	objectBox.directory = directory
+	return objectBox, nil
+}
+
+// prepareDirectory creates the directory (if configured) or checks its parent exists so that we can report a clear error
+func (builder *Builder) prepareDirectory(dir string) error {
+	if strings.HasPrefix(dir, "memory:") {
+		return nil
+	}
+
+	if builder.createDirectory != nil {
+		if err := os.MkdirAll(dir, *builder.createDirectory); err != nil {
+			return fmt.Errorf("can't create database directory %s: %s", dir, err)
+		}
+		return nil
+	}
+
+	var parent = filepath.Dir(filepath.Clean(dir))
+	if _, err := os.Stat(parent); os.IsNotExist(err) {
+		return fmt.Errorf("%w: %s", ErrDirectoryMissing, parent)
+	}
+	return nil
+}
+
+// Secondary error codes of OBX_ERROR_STORAGE_GENERAL reported by the storage engine (LMDB) for invalid database files
+const (
+	mdbInvalid      = -30793 // MDB_INVALID: the file is not an LMDB file (e.g. garbage or a truncated header)
+	mdbPageNotFound = -30797 // MDB_PAGE_NOTFOUND: a requested page was not found, usually corruption
+	mdbCorrupted    = -30796 // MDB_CORRUPTED: located a page of the wrong type
+)
+
+// isCorruptOnOpen recognizes errors of obx_store_open() caused by invalid database files; apart from the dedicated
+// error codes (see StorageError.Is()), the native library reports some of those as general storage errors with the
+// storage engine's error as the secondary code
+func isCorruptOnOpen(err *StorageError) bool {
+	if err.Code != C.OBX_ERROR_STORAGE_GENERAL {
+		return false
+	}
+	switch err.secondaryCode {
+	case mdbInvalid, mdbPageNotFound, mdbCorrupted:
+		return true
+	}
+	return false
+}
+
+func (builder *Builder) open() (*ObjectBox, error) {
 	// for native calls/createError()
 	runtime.LockOSThread()
 	defer runtime.UnlockOSThread()
@@ -148,6 +448,10 @@ func (builder *Builder) BuildOrError() (*ObjectBox, error) {
 		}
 	}
 
+	if builder.fileMode != nil {
+		C.obx_opt_file_mode(cOptions, C.uint(*builder.fileMode))
+	}
+
 	if builder.maxSizeInKb != nil {
 		C.obx_opt_max_db_size_in_kb(cOptions, C.uint64_t(*builder.maxSizeInKb))
 	}
@@ -156,22 +460,51 @@ func (builder *Builder) BuildOrError() (*ObjectBox, error) {
 		C.obx_opt_max_readers(cOptions, C.uint(*builder.maxReaders))
 	}
 
+	if builder.validatePages != nil {
+		C.obx_opt_validate_on_open_pages(cOptions, C.size_t(*builder.validatePages),
+			C.OBXValidateOnOpenPagesFlags_VisitLeafPages)
+	}
+
+	if builder.validateKv {
+		C.obx_opt_validate_on_open_kv(cOptions, C.OBXValidateOnOpenKvFlags_None)
+	}
+
+	if builder.recoverMode {
+		C.obx_opt_read_only(cOptions, true)
+		C.obx_opt_use_previous_commit(cOptions, true)
+	} else if builder.readOnly {
+		C.obx_opt_read_only(cOptions, true)
+	}
+
 	C.obx_opt_model(cOptions, builder.model.cModel)
 
 	// cOptions is consumed by obx_store_open() so no need to free it
 	cStore := C.obx_store_open(cOptions)
 	if cStore == nil {
-		return nil, createError()
+		var err = createError()
+		if storageErr, ok := err.(*StorageError); ok {
+			storageErr.corrupt = isCorruptOnOpen(storageErr)
+		}
+		return nil, err
 	}
 
 	ob := &ObjectBox{
 		store:          cStore,
 		entitiesById:   builder.model.entitiesById,
 		entitiesByName: builder.model.entitiesByName,
+		model:          builder.model,
 		boxes:          make(map[TypeId]*Box, len(builder.model.entitiesById)),
+		observers:      make(map[*Observer]struct{}),
+		maxReaders:     defaultMaxReaders,
 		options:        builder.options,
 	}
 
+	// zero lets the native library use its default
+	if builder.maxReaders != nil && *builder.maxReaders > 0 {
+		ob.maxReaders = int(*builder.maxReaders)
+	}
+	ob.readSlots = make(chan struct{}, ob.maxReaders)
+
 	for _, entity := range builder.model.entitiesById {
 		entity.objectBox = ob
 	}
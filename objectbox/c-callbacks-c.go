@@ -22,6 +22,7 @@ package objectbox
 
 /*
 #include <stdbool.h>
+#include <stddef.h>
 #include <stdint.h>
 */
 import "C"
@@ -62,3 +63,17 @@ func cVoidConstVoidCallbackDispatch(callbackIdPtr C.uintptr_t, arg unsafe.Pointe
 		callback.callVoidConstVoid(arg)
 	}
 }
+
+//export cVoidTypeIdsCallbackDispatch
+func cVoidTypeIdsCallbackDispatch(typeIds *C.uint32_t, count C.size_t, callbackIdPtr C.uintptr_t) {
+	var callback = cCallbackLookup(callbackIdPtr)
+	if callback != nil {
+		var ids = make([]TypeId, int(count))
+		var cArrayStart = unsafe.Pointer(typeIds)
+		var cItemSize = unsafe.Sizeof(*typeIds)
+		for i := uintptr(0); i < uintptr(count); i++ {
+			ids[i] = TypeId(*(*uint32)(unsafe.Pointer(uintptr(cArrayStart) + i*cItemSize))) // make a copy
+		}
+		callback.callVoidTypeIds(ids)
+	}
+}
@@ -59,6 +59,10 @@ typedef void cVoidInt64Callback(uintptr_t callbackId, int64_t arg);
 // void return, const uintptr_t argument
 extern void cVoidConstVoidCallbackDispatch(uintptr_t callbackId);
 typedef void cVoidConstVoidCallback(uintptr_t callbackId, const void* arg);
+
+// void return, type IDs argument; note: the callbackId is the last argument, as required by obx_observer
+extern void cVoidTypeIdsCallbackDispatch(const obx_schema_id* typeIds, size_t count, uintptr_t callbackId);
+typedef void cVoidTypeIdsCallback(const obx_schema_id* typeIds, size_t count, uintptr_t callbackId);
 */
 import "C"
 import (
@@ -75,6 +79,7 @@ type cCallable interface {
 	callVoidUint64(uint64)
 	callVoidInt64(int64)
 	callVoidConstVoid(unsafe.Pointer)
+	callVoidTypeIds([]TypeId)
 }
 
 // programming error - using an incorrect `cCallable` (arguments and return-type combination)
@@ -86,6 +91,7 @@ func (fn cVoidCallback) callVoid()                        { fn() }
 func (fn cVoidCallback) callVoidUint64(uint64)            { panic(cCallablePanicMsg) }
 func (fn cVoidCallback) callVoidInt64(int64)              { panic(cCallablePanicMsg) }
 func (fn cVoidCallback) callVoidConstVoid(unsafe.Pointer) { panic(cCallablePanicMsg) }
+func (fn cVoidCallback) callVoidTypeIds([]TypeId)         { panic(cCallablePanicMsg) }
 
 var cVoidCallbackDispatchPtr = (*C.cVoidCallback)(unsafe.Pointer(C.cVoidCallbackDispatch))
 
@@ -95,6 +101,7 @@ func (fn cVoidUint64Callback) callVoid()                        { panic(cCallabl
 func (fn cVoidUint64Callback) callVoidUint64(arg uint64)        { fn(arg) }
 func (fn cVoidUint64Callback) callVoidInt64(int64)              { panic(cCallablePanicMsg) }
 func (fn cVoidUint64Callback) callVoidConstVoid(unsafe.Pointer) { panic(cCallablePanicMsg) }
+func (fn cVoidUint64Callback) callVoidTypeIds([]TypeId)         { panic(cCallablePanicMsg) }
 
 var cVoidUint64CallbackDispatchPtr = (*C.cVoidUint64Callback)(unsafe.Pointer(C.cVoidUint64CallbackDispatch))
 
@@ -104,6 +111,7 @@ func (fn cVoidInt64Callback) callVoid()                        { panic(cCallable
 func (fn cVoidInt64Callback) callVoidUint64(uint64)            { panic(cCallablePanicMsg) }
 func (fn cVoidInt64Callback) callVoidInt64(arg int64)          { fn(arg) }
 func (fn cVoidInt64Callback) callVoidConstVoid(unsafe.Pointer) { panic(cCallablePanicMsg) }
+func (fn cVoidInt64Callback) callVoidTypeIds([]TypeId)         { panic(cCallablePanicMsg) }
 
 var cVoidInt64CallbackDispatchPtr = (*C.cVoidInt64Callback)(unsafe.Pointer(C.cVoidInt64CallbackDispatch))
 
@@ -113,9 +121,20 @@ func (fn cVoidConstVoidCallback) callVoid()                            { panic(c
 func (fn cVoidConstVoidCallback) callVoidUint64(uint64)                { panic(cCallablePanicMsg) }
 func (fn cVoidConstVoidCallback) callVoidInt64(int64)                  { panic(cCallablePanicMsg) }
 func (fn cVoidConstVoidCallback) callVoidConstVoid(arg unsafe.Pointer) { fn(arg) }
+func (fn cVoidConstVoidCallback) callVoidTypeIds([]TypeId)             { panic(cCallablePanicMsg) }
 
 var cVoidConstVoidCallbackDispatchPtr = (*C.cVoidConstVoidCallback)(unsafe.Pointer(C.cVoidConstVoidCallbackDispatch))
 
+type cVoidTypeIdsCallback func([]TypeId)
+
+func (fn cVoidTypeIdsCallback) callVoid()                        { panic(cCallablePanicMsg) }
+func (fn cVoidTypeIdsCallback) callVoidUint64(uint64)            { panic(cCallablePanicMsg) }
+func (fn cVoidTypeIdsCallback) callVoidInt64(int64)              { panic(cCallablePanicMsg) }
+func (fn cVoidTypeIdsCallback) callVoidConstVoid(unsafe.Pointer) { panic(cCallablePanicMsg) }
+func (fn cVoidTypeIdsCallback) callVoidTypeIds(typeIds []TypeId) { fn(typeIds) }
+
+var cVoidTypeIdsCallbackDispatchPtr = (*C.cVoidTypeIdsCallback)(unsafe.Pointer(C.cVoidTypeIdsCallbackDispatch))
+
 type cCallbackId uint32
 
 var cCallbackLastId cCallbackId
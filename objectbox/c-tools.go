@@ -22,7 +22,6 @@ package objectbox
 */
 import "C"
 import (
-	"errors"
 	"runtime"
 )
 
@@ -306,13 +305,62 @@ func cGetFloat32s(fn func() *C.OBX_float_array) (items []float32, err error) {
 	return items, err
 }
 
+// StorageError is returned for errors reported by the native ObjectBox library, giving access to the native error code,
+// e.g. for logging and telemetry. Use errors.As() to extract it, also from errors wrapping it (e.g. PutManyError).
+type StorageError struct {
+	// Code is the native error code, e.g. 10201 (OBX_ERROR_UNIQUE_VIOLATED); see objectbox.h for the full list
+	Code int
+
+	// Message is the error message provided by the native library
+	Message string
+
+	// Op is the name of the failed operation, e.g. "put" or "get"; empty if not known
+	Op string
+
+	// Entity is the name of the entity the operation was executed on; empty if not known
+	Entity string
+
+	// secondaryCode is the underlying (e.g. storage engine) error code of general errors, 0 if not available
+	secondaryCode int
+
+	// corrupt is set when opening the store failed due to invalid database files, see ErrCorrupt
+	corrupt bool
+}
+
+// Error returns the native error message
+func (err *StorageError) Error() string {
+	return err.Message
+}
+
+// Is lets errors.Is(err, ErrCorrupt) match errors caused by corrupted database files
+func (err *StorageError) Is(target error) bool {
+	if target != ErrCorrupt {
+		return false
+	}
+	return err.corrupt || err.Code == C.OBX_ERROR_FILE_CORRUPT || err.Code == C.OBX_ERROR_FILE_PAGES_CORRUPT
+}
+
 // createError fetches the latest error that happened in the c-api on a current-thread.
 // The c-api uses thread-local storage for the latest error so we need to lock the current goroutine to a thread.
 // Must only be called when runtime.LockOSThread() is active. Either use one of the above cCall-style functions or a TX.
 func createError() error {
-	msg := C.obx_last_error_message()
-	if msg == nil {
-		return errors.New("no error info available; please report")
+	var err = &StorageError{Code: int(C.obx_last_error_code()), secondaryCode: int(C.obx_last_error_secondary())}
+	if msg := C.obx_last_error_message(); msg == nil {
+		err.Message = "no error info available; please report"
+	} else {
+		err.Message = C.GoString(msg)
 	}
-	return errors.New(C.GoString(msg))
+	return err
+}
+
+// withOperation fills in the operation and the entity name if the given error is a StorageError without them.
+// A copy is returned so that errors passed around already aren't modified.
+func withOperation(err error, op string, entity string) error {
+	if storageErr, ok := err.(*StorageError); ok && storageErr.Op == "" {
+		var copied = *storageErr
+		copied.Op = op
+		copied.Entity = entity
+		return &copied
+	}
+	return err
 }
@@ -0,0 +1,179 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// coalescedPutsMax limits the number of puts committed in a single coalesced transaction
+const coalescedPutsMax = 1000
+
+// writeCoalescer collects Box.Put() calls from multiple goroutines and commits them in a shared write transaction.
+type writeCoalescer struct {
+	ob       *ObjectBox
+	window   time.Duration
+	requests chan *coalescedPut
+	stop     chan struct{} // closed to stop the loop
+	stopped  chan struct{} // closed by the loop once it has finished
+}
+
+type coalescedPut struct {
+	box    *Box
+	object interface{}
+	id     uint64
+	err    error
+	done   chan struct{}
+}
+
+// EnableWriteCoalescing makes Box.Put() calls issued concurrently by multiple goroutines share a write transaction:
+// the first put waits up to the given window for other puts to arrive and then all of them are committed together.
+// Each Put() still returns only after its object has been committed, with its own ID and error. This trades a little
+// latency (up to the window) for a considerably higher throughput of many small concurrent writes.
+//
+// If a put in the shared transaction fails (e.g. a unique constraint violation), the transaction is rolled back and
+// the objects are put again one by one so that only the failing Put() returns an error.
+//
+// Only top-level Box.Put() calls are coalesced; puts inside RunInWriteTx(), PutMany() etc. are executed directly.
+func (ob *ObjectBox) EnableWriteCoalescing(window time.Duration) error {
+	if window <= 0 {
+		return fmt.Errorf("invalid write coalescing window %v, must be positive", window)
+	}
+
+	ob.coalescerMutex.Lock()
+	defer ob.coalescerMutex.Unlock()
+
+	if ob.coalescer != nil {
+		return errors.New("write coalescing is already enabled")
+	}
+
+	ob.coalescer = &writeCoalescer{
+		ob:       ob,
+		window:   window,
+		requests: make(chan *coalescedPut),
+		stop:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	go ob.coalescer.loop()
+	return nil
+}
+
+// DisableWriteCoalescing stops coalescing Box.Put() calls, waiting for the pending ones to be committed.
+// It's a no-op if write coalescing isn't enabled; it's also called by Close().
+func (ob *ObjectBox) DisableWriteCoalescing() {
+	ob.coalescerMutex.Lock()
+	var coalescer = ob.coalescer
+	ob.coalescer = nil
+	ob.coalescerMutex.Unlock()
+
+	if coalescer != nil {
+		close(coalescer.stop)
+		<-coalescer.stopped
+	}
+}
+
+// activeCoalescer returns the coalescer to use for a Put() or nil if the put should be executed directly
+func (ob *ObjectBox) activeCoalescer() *writeCoalescer {
+	// a Put() while a write transaction is open is either issued from inside the transaction (e.g. PutRelated() of
+	// the coalesced transaction itself) or would have to wait for the transaction anyway
+	if atomic.LoadInt32(&ob.writeTxDepth) > 0 {
+		return nil
+	}
+
+	ob.coalescerMutex.RLock()
+	defer ob.coalescerMutex.RUnlock()
+	return ob.coalescer
+}
+
+// put passes the object to the loop and waits until it's committed
+func (coalescer *writeCoalescer) put(box *Box, object interface{}) (uint64, error) {
+	var request = &coalescedPut{box: box, object: object, done: make(chan struct{})}
+
+	select {
+	case coalescer.requests <- request:
+	case <-coalescer.stop:
+		return box.putPrepared(object, false, cPutModePut, nil)
+	}
+
+	<-request.done
+	return request.id, request.err
+}
+
+func (coalescer *writeCoalescer) loop() {
+	defer close(coalescer.stopped)
+
+	for {
+		select {
+		case request := <-coalescer.requests:
+			var batch = []*coalescedPut{request}
+			var timer = time.NewTimer(coalescer.window)
+
+		collect:
+			for len(batch) < coalescedPutsMax {
+				select {
+				case request := <-coalescer.requests:
+					batch = append(batch, request)
+				case <-timer.C:
+					break collect
+				case <-coalescer.stop:
+					break collect
+				}
+			}
+
+			timer.Stop()
+			coalescer.commit(batch)
+
+		case <-coalescer.stop:
+			return
+		}
+	}
+}
+
+// commit puts all objects in a single transaction; if that fails, each object is put in its own transaction instead
+func (coalescer *writeCoalescer) commit(batch []*coalescedPut) {
+	// IDs of the objects before the put; new objects are assigned an ID which must be reset if the TX is rolled back
+	var idsBefore = make([]uint64, len(batch))
+	var ids = make([]uint64, len(batch))
+
+	var err = coalescer.ob.RunInWriteTx(func() error {
+		for i, request := range batch {
+			var err error
+			if idsBefore[i], err = request.box.entity.binding.GetId(request.object); err != nil {
+				return err
+			}
+			if ids[i], err = request.box.putPrepared(request.object, true, cPutModePut, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	for i, request := range batch {
+		if err == nil {
+			request.id = ids[i]
+		} else {
+			if ids[i] != 0 && idsBefore[i] != ids[i] {
+				_ = request.box.entity.binding.SetId(request.object, idsBefore[i])
+			}
+			request.id, request.err = request.box.putPrepared(request.object, false, cPutModePut, nil)
+		}
+		close(request.done)
+	}
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"sync"
+)
+
+// codec is a pair of functions registered using RegisterCodec()
+type codec struct {
+	compress   func(data []byte) ([]byte, error)
+	decompress func(data []byte) ([]byte, error)
+}
+
+var codecs = struct {
+	sync.RWMutex
+	byName map[string]*codec
+}{byName: map[string]*codec{
+	"gzip": {compress: gzipCompress, decompress: gzipDecompress},
+}}
+
+// RegisterCodec registers a compression codec under the given name, to be used through Compress() and Decompress().
+// The "gzip" codec is registered by default. Registering a codec with an already registered name replaces it.
+//
+// Together with converter functions, this allows to store fields compressed with any codec, like the built-in
+// GzipStringConvert and GzipBytesConvert converters do with gzip:
+//
+//	type Article struct {
+//		Id   uint64
+//		Text string `objectbox:"type:[]byte converter:zstdText"`
+//	}
+//
+//	func zstdTextToEntityProperty(dbValue []byte) (string, error) {
+//		data, err := objectbox.Decompress("zstd", dbValue)
+//		return string(data), err
+//	}
+//
+//	func zstdTextToDatabaseValue(goValue string) ([]byte, error) {
+//		return objectbox.Compress("zstd", []byte(goValue))
+//	}
+func RegisterCodec(name string, compress func(data []byte) ([]byte, error), decompress func(data []byte) ([]byte, error)) error {
+	if name == "" {
+		return fmt.Errorf("can't register a codec without a name")
+	} else if compress == nil || decompress == nil {
+		return fmt.Errorf("can't register codec %s: both functions must be given", name)
+	}
+
+	codecs.Lock()
+	defer codecs.Unlock()
+	codecs.byName[name] = &codec{compress: compress, decompress: decompress}
+	return nil
+}
+
+func registeredCodec(name string) (*codec, error) {
+	codecs.RLock()
+	defer codecs.RUnlock()
+
+	if c := codecs.byName[name]; c != nil {
+		return c, nil
+	}
+	return nil, fmt.Errorf("no codec registered as %s, see RegisterCodec()", name)
+}
+
+// Compress compresses the given data using the codec registered with the given name, see RegisterCodec().
+func Compress(codec string, data []byte) ([]byte, error) {
+	c, err := registeredCodec(codec)
+	if err != nil {
+		return nil, err
+	}
+	return c.compress(data)
+}
+
+// Decompress decompresses data created by Compress() with the same codec, see RegisterCodec().
+func Decompress(codec string, data []byte) ([]byte, error) {
+	c, err := registeredCodec(codec)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.decompress(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s data: %s", codec, err)
+	}
+	return result, nil
+}
+
+func gzipCompress(data []byte) ([]byte, error) {
+	var buffer bytes.Buffer
+	var writer = gzip.NewWriter(&buffer)
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	} else if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func gzipDecompress(data []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
+
+// GzipStringConvertToEntityProperty decompresses a string stored by GzipStringConvertToDatabaseValue().
+// Use it by annotating a field `objectbox:"type:[]byte converter:objectbox.GzipStringConvert"` to store large texts
+// compressed. The stored value is the compressed form so the field can't be used in query conditions.
+func GzipStringConvertToEntityProperty(dbValue []byte) (string, error) {
+	if len(dbValue) == 0 {
+		return "", nil
+	}
+	data, err := Decompress("gzip", dbValue)
+	return string(data), err
+}
+
+// GzipStringConvertToDatabaseValue compresses a string using gzip; an empty string is stored as nil.
+func GzipStringConvertToDatabaseValue(goValue string) ([]byte, error) {
+	if goValue == "" {
+		return nil, nil
+	}
+	return Compress("gzip", []byte(goValue))
+}
+
+// GzipBytesConvertToEntityProperty decompresses a byte vector stored by GzipBytesConvertToDatabaseValue().
+// Use it by annotating a field `objectbox:"type:[]byte converter:objectbox.GzipBytesConvert"` to store large blobs
+// compressed. The stored value is the compressed form so the field can't be used in query conditions.
+func GzipBytesConvertToEntityProperty(dbValue []byte) ([]byte, error) {
+	if len(dbValue) == 0 {
+		return nil, nil
+	}
+	data, err := Decompress("gzip", dbValue)
+	if err == nil && data == nil {
+		data = []byte{}
+	}
+	return data, err
+}
+
+// GzipBytesConvertToDatabaseValue compresses a byte vector using gzip; a nil vector is stored as nil while an empty
+// one is read back as an empty vector.
+func GzipBytesConvertToDatabaseValue(goValue []byte) ([]byte, error) {
+	if goValue == nil {
+		return nil, nil
+	}
+	return Compress("gzip", goValue)
+}
@@ -30,6 +30,9 @@ type Condition interface {
 
 	// As sets a string alias for the given condition. It can later be used in Query.Set*Params() methods.
 	As(alias *alias) Condition
+
+	// negated returns a condition matching exactly the objects this condition doesn't match, see Not()
+	negated() (Condition, error)
 }
 
 // ConditionId is a condition identifier type, used when building queries
@@ -39,8 +42,9 @@ const conditionIdFakeOrder = -1
 const conditionIdFakeLink = -2
 
 type conditionClosure struct {
-	apply func(qb *QueryBuilder) (ConditionId, error)
-	alias *string
+	apply  func(qb *QueryBuilder) (ConditionId, error)
+	negate func(qb *QueryBuilder) (ConditionId, error) // optional, nil if the condition can't be negated
+	alias  *string
 }
 
 func (condition *conditionClosure) applyTo(qb *QueryBuilder, isRoot bool) (ConditionId, error) {
@@ -71,6 +75,18 @@ func (condition *conditionClosure) As(alias *alias) Condition {
 	return condition
 }
 
+func (condition *conditionClosure) negated() (Condition, error) {
+	if condition.negate == nil {
+		return nil, errors.New("negation of this condition is not supported")
+	}
+
+	return &conditionClosure{
+		apply:  condition.negate,
+		negate: condition.apply,
+		alias:  condition.alias,
+	}, nil
+}
+
 // Combines multiple conditions with an operator
 type conditionCombination struct {
 	or         bool // AND by default
@@ -143,6 +159,27 @@ func (condition *conditionCombination) As(alias *alias) Condition {
 	return condition
 }
 
+// negated applies De Morgan's laws: NOT (a AND b) = (NOT a) OR (NOT b); NOT (a OR b) = (NOT a) AND (NOT b)
+func (condition *conditionCombination) negated() (Condition, error) {
+	if len(condition.conditions) == 0 {
+		return nil, errors.New("negation of an empty combination of conditions is not supported")
+	}
+
+	var conditions = make([]Condition, len(condition.conditions))
+	for i, sub := range condition.conditions {
+		var err error
+		if conditions[i], err = sub.negated(); err != nil {
+			return nil, err
+		}
+	}
+
+	return &conditionCombination{
+		or:         !condition.or,
+		conditions: conditions,
+		alias:      condition.alias,
+	}, nil
+}
+
 // Any provides a way to combine multiple query conditions (equivalent to OR logical operator)
 func Any(conditions ...Condition) Condition {
 	return &conditionCombination{
@@ -158,6 +195,91 @@ func All(conditions ...Condition) Condition {
 	}
 }
 
+// Not negates the given condition, i.e. the resulting condition matches exactly the objects the given one doesn't.
+// For example, Not(All(a, b)) is evaluated as Any(Not(a), Not(b)) and Not(E.Int.Equals(47)) as E.Int.NotEquals(47).
+//
+// There's no native negation so only conditions that have a complement can be negated - e.g. equality, comparisons,
+// Between, In/NotIn (integers), IsNil/IsNotNil and combinations of those. Others, e.g. string Contains or HasPrefix
+// and relation links, result in an error when creating the query.
+//
+// Note: objects with a nil value (pointer fields) don't match comparisons, regardless of the negation; combine the
+// negated condition with IsNil() if they should be included.
+func Not(condition Condition) Condition {
+	return &conditionNegation{condition: condition}
+}
+
+// IsNull matches objects that don't have a value of the given property stored, e.g. a nil pointer field or a property
+// added to the entity after the object was put. A stored zero value (e.g. a pointer to 0 or "") is not null.
+// This is the same as calling IsNil() on the property.
+func IsNull(property Property) Condition {
+	return baseProperty(property).IsNil()
+}
+
+// NotNull matches objects that have a value of the given property stored, including zero values.
+// This is the same as calling IsNotNil() on the property.
+func NotNull(property Property) Condition {
+	return baseProperty(property).IsNotNil()
+}
+
+// WithinBox matches objects with coordinates (in degrees) inside the given bounding box, borders included, e.g.
+//
+//	box.Query(objectbox.WithinBox(Place_.Lat, Place_.Lon, 48.1, 48.3, 16.2, 16.5))
+//
+// If minLon is greater than maxLon, the box is considered to cross the antimeridian (180°), i.e. it matches longitudes
+// from minLon up to 180 and from -180 up to maxLon.
+//
+// This is a plain combination of range conditions, not a spatial index: only one of the properties' indexes is used
+// (if any) and the remaining objects in the latitude (or longitude) band are filtered one by one. It works well for
+// small boxes over indexed properties but there's no distance-based search nor ordering by distance.
+// Objects without a value (nil) are never matched.
+func WithinBox(latProperty, lonProperty *PropertyFloat64, minLat, maxLat, minLon, maxLon float64) Condition {
+	var lon Condition
+	if minLon <= maxLon {
+		lon = lonProperty.Between(minLon, maxLon)
+	} else {
+		lon = Any(lonProperty.GreaterOrEqual(minLon), lonProperty.LessOrEqual(maxLon))
+	}
+	return All(latProperty.Between(minLat, maxLat), lon)
+}
+
+func baseProperty(property Property) BaseProperty {
+	return BaseProperty{Id: property.propertyId(), Entity: &Entity{Id: property.entityId()}}
+}
+
+type conditionNegation struct {
+	condition Condition
+	alias     *string
+}
+
+func (condition *conditionNegation) applyTo(qb *QueryBuilder, isRoot bool) (ConditionId, error) {
+	negated, err := condition.condition.negated()
+	if err != nil {
+		return 0, err
+	}
+
+	if condition.alias != nil {
+		negated.Alias(*condition.alias)
+	}
+
+	return negated.applyTo(qb, isRoot)
+}
+
+// Alias sets a string alias for the given condition. It can later be used in Query.Set*Params() methods.
+func (condition *conditionNegation) Alias(alias string) Condition {
+	condition.alias = &alias
+	return condition
+}
+
+// As sets an alias for the given condition. It can later be used in Query.Set*Params() methods.
+func (condition *conditionNegation) As(alias *alias) Condition {
+	condition.alias = alias.alias()
+	return condition
+}
+
+func (condition *conditionNegation) negated() (Condition, error) {
+	return condition.condition, nil
+}
+
 // implements propertyOrAlias
 type alias struct {
 	string
@@ -206,3 +328,8 @@ func (order *orderClosure) As(alias *alias) Condition {
 	order.alias = alias.alias()
 	return order
 }
+
+// negated keeps the order as is, it doesn't influence which objects are matched
+func (order *orderClosure) negated() (Condition, error) {
+	return order, nil
+}
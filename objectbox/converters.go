@@ -17,8 +17,12 @@
 package objectbox
 
 import (
+	"encoding/json"
 	"fmt"
+	"net"
+	"reflect"
 	"strconv"
+	"sync"
 	"time"
 )
 
@@ -96,3 +100,179 @@ func TimeBinaryConvertToDatabaseValue(goValue time.Time) ([]byte, error) {
 	}
 	return bytes, err
 }
+
+// IPBytesConvertToEntityProperty converts a byte vector (4 bytes for IPv4, 16 bytes for IPv6) to net.IP.
+// Use it by annotating a field `objectbox:"type:[]byte converter:objectbox.IPBytesConvert"`.
+func IPBytesConvertToEntityProperty(dbValue []byte) (net.IP, error) {
+	if len(dbValue) == 0 {
+		return nil, nil
+	} else if len(dbValue) != net.IPv4len && len(dbValue) != net.IPv6len {
+		return nil, fmt.Errorf("invalid IP address length %d, expected %d or %d bytes", len(dbValue), net.IPv4len, net.IPv6len)
+	}
+	var ip = make(net.IP, len(dbValue))
+	copy(ip, dbValue)
+	return ip, nil
+}
+
+// IPBytesConvertToDatabaseValue converts net.IP to a byte vector, using the 4-byte representation for IPv4 addresses.
+func IPBytesConvertToDatabaseValue(goValue net.IP) ([]byte, error) {
+	if len(goValue) == 0 {
+		return nil, nil
+	} else if ip4 := goValue.To4(); ip4 != nil {
+		return ip4, nil
+	} else if len(goValue) != net.IPv6len {
+		return nil, fmt.Errorf("invalid IP address %v", goValue)
+	}
+	return goValue, nil
+}
+
+// DurationInt64ConvertToEntityProperty converts nanoseconds to time.Duration.
+// Use it by annotating a field `objectbox:"type:int64 converter:objectbox.DurationInt64Convert"`.
+func DurationInt64ConvertToEntityProperty(dbValue int64) (time.Duration, error) {
+	return time.Duration(dbValue), nil
+}
+
+// DurationInt64ConvertToDatabaseValue converts time.Duration to nanoseconds.
+func DurationInt64ConvertToDatabaseValue(goValue time.Duration) (int64, error) {
+	return int64(goValue), nil
+}
+
+// StringMapBytesConvertToEntityProperty decodes a map stored by StringMapBytesConvertToDatabaseValue (JSON).
+// Use it by annotating a field `objectbox:"type:[]byte converter:objectbox.StringMapBytesConvert"`.
+func StringMapBytesConvertToEntityProperty(dbValue []byte) (map[string]string, error) {
+	if dbValue == nil {
+		return nil, nil
+	}
+	var goValue map[string]string
+	if err := json.Unmarshal(dbValue, &goValue); err != nil {
+		return nil, fmt.Errorf("invalid string map: %s", err)
+	}
+	return goValue, nil
+}
+
+// StringMapBytesConvertToDatabaseValue encodes a map as a JSON object (with sorted keys); a nil map is stored as nil
+// while an empty one is read back as an empty map.
+func StringMapBytesConvertToDatabaseValue(goValue map[string]string) ([]byte, error) {
+	if goValue == nil {
+		return nil, nil
+	}
+	return json.Marshal(goValue)
+}
+
+// converter is a pair of functions registered using RegisterConverter()
+type converter struct {
+	toDb   func(interface{}) interface{}
+	fromDb func(interface{}) interface{}
+	dbType reflect.Type
+}
+
+var converters = struct {
+	sync.RWMutex
+	byType map[reflect.Type]*converter
+}{byType: make(map[reflect.Type]*converter)}
+
+// isSupportedDbType checks whether values of the given type can be stored directly, without a conversion
+func isSupportedDbType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64, reflect.String:
+		return t.PkgPath() == "" // only the builtin types, not their named variants
+	case reflect.Slice:
+		return t == reflect.TypeOf([]byte{}) || t == reflect.TypeOf([]string{})
+	}
+	return false
+}
+
+// RegisterConverter registers a pair of functions converting values of the given Go type to a database value and back.
+// The database value type is determined by calling toDb with the zero value of goType and must be one of the types
+// ObjectBox stores natively: bool, int8-64, uint8-64, float32, float64, string, []byte or []string.
+// Registering a converter for an already registered type replaces the previous one.
+//
+// Registered converters are used through ConvertToDatabaseValue() and ConvertToEntityProperty(), e.g. from a pair of
+// functions referenced by the `converter` annotation of a field:
+//
+//	type Product struct {
+//		Id    uint64
+//		Price Money `objectbox:"type:int64 converter:money"`
+//	}
+//
+//	func moneyToEntityProperty(dbValue int64) (Money, error) {
+//		value, err := objectbox.ConvertToEntityProperty(reflect.TypeOf(Money{}), dbValue)
+//		if err != nil {
+//			return Money{}, err
+//		}
+//		return value.(Money), nil
+//	}
+//
+//	func moneyToDatabaseValue(goValue Money) (int64, error) {
+//		value, err := objectbox.ConvertToDatabaseValue(goValue)
+//		if err != nil {
+//			return 0, err
+//		}
+//		return value.(int64), nil
+//	}
+func RegisterConverter(goType reflect.Type, toDb func(interface{}) interface{}, fromDb func(interface{}) interface{}) error {
+	if goType == nil {
+		return fmt.Errorf("can't register a converter for a nil type")
+	} else if toDb == nil || fromDb == nil {
+		return fmt.Errorf("can't register a converter for %v: both conversion functions must be given", goType)
+	}
+
+	var sample = toDb(reflect.Zero(goType).Interface())
+	if sample == nil {
+		return fmt.Errorf("can't register a converter for %v: converting a zero value returned nil", goType)
+	}
+
+	var dbType = reflect.TypeOf(sample)
+	if !isSupportedDbType(dbType) {
+		return fmt.Errorf("can't register a converter for %v: database value type %v is not supported", goType, dbType)
+	}
+
+	converters.Lock()
+	defer converters.Unlock()
+	converters.byType[goType] = &converter{toDb: toDb, fromDb: fromDb, dbType: dbType}
+	return nil
+}
+
+func registeredConverter(goType reflect.Type) (*converter, error) {
+	converters.RLock()
+	defer converters.RUnlock()
+
+	if conv := converters.byType[goType]; conv != nil {
+		return conv, nil
+	}
+	return nil, fmt.Errorf("no converter registered for %v, see RegisterConverter()", goType)
+}
+
+// ConvertToDatabaseValue converts the given value using a converter registered for its type, see RegisterConverter().
+func ConvertToDatabaseValue(goValue interface{}) (interface{}, error) {
+	conv, err := registeredConverter(reflect.TypeOf(goValue))
+	if err != nil {
+		return nil, err
+	}
+
+	var dbValue = conv.toDb(goValue)
+	if dbValue == nil || reflect.TypeOf(dbValue) != conv.dbType {
+		return nil, fmt.Errorf("converter for %T returned %T instead of %v", goValue, dbValue, conv.dbType)
+	}
+	return dbValue, nil
+}
+
+// ConvertToEntityProperty converts the given database value to goType using a registered converter,
+// see RegisterConverter().
+func ConvertToEntityProperty(goType reflect.Type, dbValue interface{}) (interface{}, error) {
+	conv, err := registeredConverter(goType)
+	if err != nil {
+		return nil, err
+	}
+
+	if dbValue == nil || reflect.TypeOf(dbValue) != conv.dbType {
+		return nil, fmt.Errorf("converter for %v expects a database value of type %v, got %T", goType, conv.dbType, dbValue)
+	}
+
+	var goValue = conv.fromDb(dbValue)
+	if goValue == nil || reflect.TypeOf(goValue) != goType {
+		return nil, fmt.Errorf("converter for %v returned %T", goType, goValue)
+	}
+	return goValue, nil
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// countCache holds the state behind Box.CachedCount()
+type countCache struct {
+	// incremented by the observer on each change notification; first to keep it 64-bit aligned for atomic access
+	generation uint64
+
+	mutex    sync.Mutex
+	observer *Observer
+
+	// the generation the cached count was read at
+	cachedGeneration uint64
+	count            uint64
+	valid            bool
+}
+
+// CachedCount returns the number of objects in the box, like Count(), but only counts them again if the box has
+// changed since the last call. This is useful when the count is polled frequently, e.g. by a dashboard.
+//
+// The first call subscribes the box to change notifications (see Subscribe()), which stays active until the store is
+// closed (after ObjectBox.UnsubscribeAll(), the next call subscribes again). Notifications are delivered asynchronously after a transaction is committed, so the returned count may lag
+// behind a just-committed change until its notification arrives - usually a fraction of a millisecond. Use Count() when
+// the result must reflect the latest commit.
+func (box *Box) CachedCount() (uint64, error) {
+	var cache = &box.countCache
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	// (re)subscribe, e.g. after ObjectBox.UnsubscribeAll(); changes made meanwhile weren't observed
+	if cache.observer == nil || cache.observer.closed() {
+		cache.valid = false
+		observer, err := box.Subscribe(func() {
+			atomic.AddUint64(&cache.generation, 1)
+		})
+		if err != nil {
+			return 0, err
+		}
+		cache.observer = observer
+	}
+
+	// read the generation before counting; a change committed meanwhile causes a recount on the next call
+	var generation = atomic.LoadUint64(&cache.generation)
+	if cache.valid && cache.cachedGeneration == generation {
+		return cache.count, nil
+	}
+
+	count, err := box.Count()
+	if err != nil {
+		return 0, err
+	}
+
+	cache.count = count
+	cache.cachedGeneration = generation
+	cache.valid = true
+	return count, nil
+}
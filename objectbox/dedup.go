@@ -0,0 +1,411 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include <stdlib.h>
+#include "objectbox.h"
+*/
+import "C"
+import (
+	"fmt"
+
+	"github.com/google/flatbuffers/go"
+	"github.com/objectbox/objectbox-go/objectbox/fbutils"
+)
+
+// PutAllDedup inserts or updates multiple objects in a single transaction, like PutMany, using the given key property
+// to find existing objects: if an object with the same key value is already stored (or is earlier in the given slice),
+// it's updated instead of inserting a duplicate. The ID of such object is set to the ID of the existing one.
+// Objects without a key value (nil, an empty string or zero) are put as they are, i.e. objects that don't have the key
+// set aren't merged into a single one. The key is read after applying the pre-save hook (see SetPreSave()).
+//
+// The key property is referenced by its name as defined in the model (see objectbox-model.json) and must be a string
+// (compared case-sensitively) or an integer property. It should be indexed (`objectbox:"index"` or `unique`),
+// otherwise each object causes a full scan of the box, which is very slow for anything but small boxes.
+//
+// Returns: IDs of the put objects (in the same order).
+func (box *Box) PutAllDedup(slice interface{}, keyProperty string) (ids []uint64, err error) {
+	var key = box.entity.propertyByName(keyProperty)
+	if key == nil {
+		return nil, fmt.Errorf("unknown property '%s' on entity %s", keyProperty, box.entity.name)
+	}
+
+	if !key.isKeyType() {
+		return nil, fmt.Errorf("property '%s' on entity %s has a type %d that can't be used as a key, "+
+			"only string and integer properties are supported", keyProperty, box.entity.name, key.propertyType)
+	}
+
+	var objects = box.objectSlice(slice)
+	var count = objects.len()
+	if count == 0 {
+		return []uint64{}, nil
+	}
+
+	query, property, err := box.keyQuery(key)
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	ids = make([]uint64, count)
+
+	err = box.ObjectBox.RunInWriteTx(func() error {
+		// IDs of objects put in this transaction, by their key value; covers duplicates inside the given slice
+		var putIds = make(map[interface{}]uint64)
+
+		for i := 0; i < count; i++ {
+			var object = objects.index(i)
+
+			// the hook may set or change the key so it must be applied before the lookup (and only once)
+			if err := box.preSave(object); err != nil {
+				return err
+			}
+
+			var keyValue interface{}
+			if err := box.withObjectBytes(object, 0, func(bytes []byte) error {
+				keyValue = key.keyValue(&flatbuffers.Table{
+					Bytes: bytes,
+					Pos:   flatbuffers.GetUOffsetT(bytes),
+				})
+				return nil
+			}); err != nil {
+				return err
+			}
+
+			// the generated bindings always store strings and integers so an unset key is read as "" or 0
+			if keyValue == "" || keyValue == int64(0) {
+				keyValue = nil
+			}
+
+			if keyValue != nil {
+				var existingId = putIds[keyValue]
+				if existingId == 0 {
+					var err error
+					if existingId, err = box.findIdByKey(query, property, keyValue); err != nil {
+						return err
+					}
+				}
+
+				if existingId != 0 {
+					if err := box.entity.binding.SetId(object, existingId); err != nil {
+						return err
+					}
+				}
+			}
+
+			id, err := box.putPrepared(object, true, cPutModePut, nil)
+			if err != nil {
+				return err
+			}
+			ids[i] = id
+
+			if keyValue != nil {
+				putIds[keyValue] = id
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		ids = nil
+	}
+
+	return ids, err
+}
+
+// PutByKey inserts or updates a single object identified by the given unique key property instead of its ID ("upsert"):
+// if an object with the same key value is already stored, it's updated, i.e. the given object's ID is set to the ID of
+// the existing object; otherwise it's inserted. The lookup and the put are executed in a single write transaction.
+//
+// The key property is referenced by its name as defined in the model (see objectbox-model.json) and must be a unique
+// (`objectbox:"unique"`) string (compared case-sensitively) or integer property. An object without a key value (nil)
+// is put as it is. The key is read after applying the pre-save hook (see SetPreSave()).
+func (box *Box) PutByKey(object interface{}, keyProperty string) (id uint64, err error) {
+	var key = box.entity.propertyByName(keyProperty)
+	if key == nil {
+		return 0, fmt.Errorf("unknown property '%s' on entity %s", keyProperty, box.entity.name)
+	}
+
+	if !key.isKeyType() {
+		return 0, fmt.Errorf("property '%s' on entity %s has a type %d that can't be used as a key, "+
+			"only string and integer properties are supported", keyProperty, box.entity.name, key.propertyType)
+	}
+
+	if key.flags&C.OBXPropertyFlags_UNIQUE == 0 {
+		return 0, fmt.Errorf("property '%s' on entity %s can't be used as a key because it isn't unique, "+
+			"add the `objectbox:\"unique\"` annotation", keyProperty, box.entity.name)
+	}
+
+	query, property, err := box.keyQuery(key)
+	if err != nil {
+		return 0, err
+	}
+	defer query.Close()
+
+	err = box.ObjectBox.RunInWriteTx(func() error {
+		// the hook may set or change the key so it must be applied before the lookup (and only once)
+		if err := box.preSave(object); err != nil {
+			return err
+		}
+
+		var keyValue interface{}
+		if err := box.withObjectBytes(object, 0, func(bytes []byte) error {
+			keyValue = key.keyValue(&flatbuffers.Table{
+				Bytes: bytes,
+				Pos:   flatbuffers.GetUOffsetT(bytes),
+			})
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		if keyValue != nil {
+			existingId, err := box.findIdByKey(query, property, keyValue)
+			if err != nil {
+				return err
+			} else if existingId != 0 {
+				if err := box.entity.binding.SetId(object, existingId); err != nil {
+					return err
+				}
+			}
+		}
+
+		id, err = box.putPrepared(object, true, cPutModePut, nil)
+		return err
+	})
+
+	if err != nil {
+		id = 0
+	}
+	return id, err
+}
+
+// PutAllWithConflict inserts or updates multiple objects in a single transaction, like PutMany, letting the caller
+// resolve conflicts with already stored objects: if an object has the same value of a unique property (other than
+// the ID) as an existing object, resolve is called with both of them. The object returned by resolve (e.g. one of
+// the two or a merge of them) is then stored in place of the existing object, i.e. with its ID. If resolve returns
+// nil, the incoming object is skipped. An error returned by resolve rolls back the whole transaction.
+//
+// Conflicts on unique string and integer properties are detected; those properties are indexed implicitly.
+// If an object conflicts with multiple stored objects (on different unique properties), resolve is called for the
+// first one found and storing the result may fail on the other unique property.
+//
+// The unique values are read after applying the pre-save hook (see SetPreSave()) to the incoming object. If resolve
+// returns another object, the hook is applied to that one too before it's stored.
+//
+// Returns: IDs of the put objects in the same order as the given slice; 0 for skipped objects.
+func (box *Box) PutAllWithConflict(slice interface{},
+	resolve func(incoming, existing interface{}) (interface{}, error)) (ids []uint64, err error) {
+
+	var objects = box.objectSlice(slice)
+	var count = objects.len()
+	if count == 0 {
+		return []uint64{}, nil
+	}
+
+	type uniqueKey struct {
+		info     *propertyInfo
+		property *BaseProperty
+		query    *Query
+	}
+	var keys []uniqueKey
+	defer func() {
+		for _, key := range keys {
+			key.query.Close()
+		}
+	}()
+
+	for _, info := range box.entity.properties {
+		if info.flags&C.OBXPropertyFlags_UNIQUE == 0 || info.id == box.entity.idPropertyId || !info.isKeyType() {
+			continue
+		}
+
+		query, property, err := box.keyQuery(info)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, uniqueKey{info, property, query})
+	}
+
+	ids = make([]uint64, count)
+
+	err = box.ObjectBox.RunInWriteTx(func() error {
+		for i := 0; i < count; i++ {
+			var object = objects.index(i)
+
+			// the hook may set or change the unique values so it must be applied before the lookup
+			if err := box.preSave(object); err != nil {
+				return &PutManyError{Index: i, Err: err}
+			}
+
+			objectId, err := box.entity.binding.GetId(object)
+			if err != nil {
+				return err
+			}
+
+			var keyValues = make([]interface{}, len(keys))
+			if err := box.withObjectBytes(object, objectId, func(bytes []byte) error {
+				var table = &flatbuffers.Table{
+					Bytes: bytes,
+					Pos:   flatbuffers.GetUOffsetT(bytes),
+				}
+				for k, key := range keys {
+					keyValues[k] = key.info.keyValue(table)
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+
+			// find the first stored object (other than the incoming one) with the same value of a unique property
+			var existingId uint64
+			for k, key := range keys {
+				if keyValues[k] == nil {
+					continue
+				}
+				if existingId, err = box.findIdByKey(key.query, key.property, keyValues[k]); err != nil {
+					return err
+				} else if existingId != 0 && existingId != objectId {
+					break
+				}
+				existingId = 0
+			}
+
+			if existingId != 0 {
+				existing, err := box.Get(existingId)
+				if err != nil {
+					return err
+				}
+
+				var incoming = object
+				if object, err = resolve(incoming, existing); err != nil {
+					return err
+				} else if object == nil {
+					continue
+				} else if object != incoming {
+					if err := box.preSave(object); err != nil {
+						return &PutManyError{Index: i, Id: existingId, Err: err}
+					}
+				}
+
+				if err := box.entity.binding.SetId(object, existingId); err != nil {
+					return err
+				}
+			}
+
+			if ids[i], err = box.putPrepared(object, true, cPutModePut, nil); err != nil {
+				return &PutManyError{Index: i, Id: existingId, Err: err}
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		ids = nil
+	}
+
+	return ids, err
+}
+
+// keyQuery creates a query with a single equality condition on the given property, see findIdByKey()
+func (box *Box) keyQuery(key *propertyInfo) (*Query, *BaseProperty, error) {
+	var property = &BaseProperty{Id: key.id, Entity: &Entity{Id: box.entity.id}}
+	var condition = &conditionClosure{
+		apply: func(qb *QueryBuilder) (ConditionId, error) {
+			if key.propertyType == C.OBXPropertyType_String {
+				return qb.StringEquals(property, "", true)
+			}
+			return qb.IntEqual(property, 0)
+		},
+	}
+
+	query, err := box.QueryOrError(condition)
+	return query, property, err
+}
+
+// findIdByKey returns an ID of an object with the given key value or 0 if there's no such object.
+func (box *Box) findIdByKey(query *Query, property *BaseProperty, keyValue interface{}) (uint64, error) {
+	var err error
+	switch value := keyValue.(type) {
+	case string:
+		err = query.SetStringParams(property, value)
+	case int64:
+		err = query.SetInt64Params(property, value)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	ids, err := query.FindIds()
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	return ids[0], nil
+}
+
+// isKeyType reports whether the property can be used to look up objects by PutAllDedup and PutAllWithConflict
+func (property *propertyInfo) isKeyType() bool {
+	return property.propertyType == C.OBXPropertyType_String || property.isInteger()
+}
+
+func (property *propertyInfo) isInteger() bool {
+	switch property.propertyType {
+	case C.OBXPropertyType_Byte, C.OBXPropertyType_Short, C.OBXPropertyType_Char, C.OBXPropertyType_Int,
+		C.OBXPropertyType_Long, C.OBXPropertyType_Date, C.OBXPropertyType_DateNano:
+		return true
+	}
+	return false
+}
+
+// keyValue reads the property value usable by PutAllDedup: a string or an int64 (the raw value for unsigned types).
+// Returns nil if the value isn't present.
+func (property *propertyInfo) keyValue(table *flatbuffers.Table) interface{} {
+	var slot = property.slot()
+	switch property.propertyType {
+	case C.OBXPropertyType_String:
+		if v := fbutils.GetStringPtrSlot(table, slot); v != nil {
+			return *v
+		}
+	case C.OBXPropertyType_Byte:
+		if v := fbutils.GetInt8PtrSlot(table, slot); v != nil {
+			if property.isUnsigned() {
+				return int64(uint8(*v))
+			}
+			return int64(*v)
+		}
+	case C.OBXPropertyType_Short, C.OBXPropertyType_Char:
+		if v := fbutils.GetInt16PtrSlot(table, slot); v != nil {
+			if property.isUnsigned() {
+				return int64(uint16(*v))
+			}
+			return int64(*v)
+		}
+	case C.OBXPropertyType_Int:
+		if v := fbutils.GetInt32PtrSlot(table, slot); v != nil {
+			if property.isUnsigned() {
+				return int64(uint32(*v))
+			}
+			return int64(*v)
+		}
+	case C.OBXPropertyType_Long, C.OBXPropertyType_Date, C.OBXPropertyType_DateNano:
+		if v := fbutils.GetInt64PtrSlot(table, slot); v != nil {
+			return *v
+		}
+	}
+	return nil
+}
@@ -37,6 +37,10 @@ they are discarded here to keep the example concise)
 	box.Remove(person)
 
 
+Bulk operations (e.g. PutMany, PutAllDedup, GetMany, GetBytesMany, RemoveIds, RemoveManyReport) accept empty as well
+as nil inputs: they return an empty (non-nil) result and no error, without accessing the database. ContainsIds()
+without IDs returns true.
+
 To learn more, see https://golang.objectbox.io/
 */
 package objectbox
@@ -26,9 +26,93 @@ type Entity struct {
 type entity struct {
 	objectBox *ObjectBox
 	id        TypeId
+	uid       uint64
 	name      string
 	binding   ObjectBinding
+	flags     int
 
 	// whether this entity has any relations (standalone or property-rels) - configured during model creation
 	hasRelations bool
+
+	// ID of the property flagged as the primary key (ID) - configured during model creation
+	idPropertyId TypeId
+
+	// properties in the order they were added to the model - configured during model creation
+	properties []*propertyInfo
+
+	// the remaining model information, only used by ObjectBox.ModelJSON()
+	lastPropertyId  TypeId
+	lastPropertyUid uint64
+	relations       []relationInfo
+}
+
+// propertyInfo holds model information about a single property of an entity
+type propertyInfo struct {
+	id           TypeId
+	uid          uint64
+	name         string
+	propertyType int
+	flags        int
+
+	// set for indexed properties and to-one relations
+	indexId        TypeId
+	indexUid       uint64
+	relationTarget string
+}
+
+// relationInfo holds model information about a standalone (many-to-many) relation
+type relationInfo struct {
+	id        TypeId
+	uid       uint64
+	targetId  TypeId
+	targetUid uint64
+}
+
+// lastProperty returns the property most recently added to the model
+func (entity *entity) lastProperty() *propertyInfo {
+	return entity.properties[len(entity.properties)-1]
+}
+
+// propertyByName finds a property by its name as defined in the model; returns nil if there's no such property
+func (entity *entity) propertyByName(name string) *propertyInfo {
+	for _, property := range entity.properties {
+		if property.name == name {
+			return property
+		}
+	}
+	return nil
+}
+
+// propertyById finds a property by its ID; returns nil if there's no such property
+func (entity *entity) propertyById(id TypeId) *propertyInfo {
+	for _, property := range entity.properties {
+		if property.id == id {
+			return property
+		}
+	}
+	return nil
+}
+
+// idProperty returns the property flagged as the primary key (ID), usable in query conditions
+func (entity *entity) idProperty() *BaseProperty {
+	return &BaseProperty{
+		Id:     entity.idPropertyId,
+		Entity: &Entity{Id: entity.id},
+	}
+}
+
+// sameDataLayout reports whether objects of this entity can be stored as-is (FlatBuffers bytes) as objects of the other
+// one, i.e. both are the same entity with the same properties, e.g. when defined by the same model in two stores
+func (entity *entity) sameDataLayout(other *entity) bool {
+	if entity.uid != other.uid || entity.name != other.name || len(entity.properties) != len(other.properties) {
+		return false
+	}
+
+	for _, property := range entity.properties {
+		var otherProperty = other.propertyById(property.id)
+		if otherProperty == nil || otherProperty.uid != property.uid || otherProperty.propertyType != property.propertyType {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,176 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include "objectbox.h"
+*/
+import "C"
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/flatbuffers/go"
+	"github.com/objectbox/objectbox-go/objectbox/fbutils"
+)
+
+// boxExpiry holds the time-to-live configuration of a box, see Box.SetTTL()
+type boxExpiry struct {
+	mutex        sync.RWMutex
+	property     *propertyInfo // nil if objects don't expire
+	ttl          time.Duration
+	removeOnRead bool
+
+	purging int32 // atomic boolean, set while a background purge triggered by a read is running
+}
+
+// SetTTL configures objects of this box to expire once the given time-to-live has passed since the value of the given
+// date property (PropertyType Date or DateNano), e.g. the time the object was created or last refreshed. Objects
+// without a value (zero) in the property never expire. Use a zero ttl with a property holding the expiration time
+// itself. Pass an empty property name to disable expiry.
+//
+// If the entity has a property flagged as the expiration time in the model (OBXPropertyFlags_EXPIRATION_TIME, written
+// by the generator for `objectbox:"expiration-time"`), it's used with a zero ttl by default.
+//
+// Expiry is evaluated lazily at read time: Get() and its variants, GetMany(), GetAll(), query results and the other
+// functions returning objects skip expired ones as if they were not stored (GetMany() returns nil at their position).
+// The data stays in the database though, so Count(), Query.FindIds(), GetBytes() and similar functions still include
+// expired objects until they're removed by PurgeExpired(). With removeOnRead, a read encountering an expired object
+// triggers PurgeExpired() in a background goroutine. Like RequireIds(), the setting is kept for the lifetime of the
+// ObjectBox instance.
+func (box *Box) SetTTL(property string, ttl time.Duration, removeOnRead bool) error {
+	if ttl < 0 {
+		return fmt.Errorf("invalid TTL %v, must not be negative", ttl)
+	}
+
+	var info *propertyInfo
+	if property != "" {
+		if info = box.entity.propertyByName(property); info == nil {
+			return fmt.Errorf("unknown property %s on entity %s", property, box.entity.name)
+		} else if !info.isDate() {
+			return fmt.Errorf("property %s on entity %s is not a date property", property, box.entity.name)
+		}
+	}
+
+	box.expiry.mutex.Lock()
+	defer box.expiry.mutex.Unlock()
+	box.expiry.property = info
+	box.expiry.ttl = ttl
+	box.expiry.removeOnRead = removeOnRead
+	return nil
+}
+
+// PurgeExpired removes all objects that have expired according to the configuration set by SetTTL(), returning the
+// number of removed objects. It runs a single write transaction and may be called periodically, e.g. from a
+// background goroutine, to physically reclaim the space taken by expired objects.
+func (box *Box) PurgeExpired() (count uint64, err error) {
+	box.expiry.mutex.RLock()
+	var property = box.expiry.property
+	var cutoff int64
+	if property != nil {
+		cutoff = box.expiryCutoff()
+	}
+	box.expiry.mutex.RUnlock()
+
+	if property == nil {
+		return 0, fmt.Errorf("no TTL configured for entity %s", box.entity.name)
+	}
+
+	// the same predicate as expired(): a zero (unset) date never expires, any other date (even a negative one) does
+	// once it's not after the cutoff
+	var baseProperty = &BaseProperty{Id: property.id, Entity: &Entity{Id: box.entity.id}}
+	query, err := box.QueryOrError(&conditionClosure{
+		apply: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntLess(baseProperty, cutoff, true)
+		},
+	}, &conditionClosure{
+		apply: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotEqual(baseProperty, 0)
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer query.Close()
+
+	return query.Remove()
+}
+
+// initExpiry applies the default configuration given by the model, i.e. a property flagged as the expiration time
+func (box *Box) initExpiry() {
+	for _, property := range box.entity.properties {
+		if property.flags&C.OBXPropertyFlags_EXPIRATION_TIME != 0 && property.isDate() {
+			box.expiry.property = property
+			return
+		}
+	}
+}
+
+// expired reports whether the object given as (FlatBuffers) bytes has expired; triggers a purge if configured to
+func (box *Box) expired(bytes []byte) bool {
+	box.expiry.mutex.RLock()
+	var property, removeOnRead = box.expiry.property, box.expiry.removeOnRead
+	var cutoff int64
+	if property != nil {
+		cutoff = box.expiryCutoff()
+	}
+	box.expiry.mutex.RUnlock()
+
+	if property == nil {
+		return false
+	}
+
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+	var value = fbutils.GetInt64PtrSlot(table, property.slot())
+	if value == nil || *value == 0 || *value > cutoff {
+		return false
+	}
+
+	if removeOnRead && atomic.CompareAndSwapInt32(&box.expiry.purging, aFalse, aTrue) {
+		// the read transaction is still open (possibly on a write transaction of the caller), so remove separately;
+		// registered as a background goroutine so that Close() doesn't free the store while it runs
+		if !box.ObjectBox.startBackground() {
+			atomic.StoreInt32(&box.expiry.purging, aFalse)
+			return true
+		}
+		go func() {
+			defer box.ObjectBox.background.Done()
+			defer atomic.StoreInt32(&box.expiry.purging, aFalse)
+			_, _ = box.PurgeExpired()
+		}()
+	}
+	return true
+}
+
+// expiryCutoff returns the latest property value of expired objects, in the unit of the property; must be called
+// with the expiry mutex held
+func (box *Box) expiryCutoff() int64 {
+	var cutoff = time.Now().Add(-box.expiry.ttl)
+	if box.expiry.property.propertyType == C.OBXPropertyType_DateNano {
+		return cutoff.UnixNano()
+	}
+	return cutoff.UnixNano() / int64(time.Millisecond)
+}
+
+func (property *propertyInfo) isDate() bool {
+	return property.propertyType == C.OBXPropertyType_Date || property.propertyType == C.OBXPropertyType_DateNano
+}
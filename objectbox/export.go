@@ -0,0 +1,183 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include <stdlib.h>
+#include "objectbox.h"
+*/
+import "C"
+import (
+	"encoding/base64"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"github.com/google/flatbuffers/go"
+	"github.com/objectbox/objectbox-go/objectbox/fbutils"
+)
+
+// ExportCSV writes all objects stored in the box as CSV (RFC 4180) to the given writer.
+// The first row is a header with the given property names, followed by one row per object.
+// Properties are referenced by their names as defined in the model (see objectbox-model.json).
+//
+// Values are written as they are stored in the database, i.e. before applying any converters; e.g. a time.Time
+// field with the default converter is written as a Unix timestamp in milliseconds. Byte vectors are Base64 encoded
+// and string vectors are joined using a new line. Nil (missing) values are written as empty cells.
+//
+// Data is streamed to the writer while objects are read so even large boxes don't need to fit in memory.
+// An unknown property name or an unsupported property type causes an error before any output is written.
+func (box *Box) ExportCSV(w io.Writer, properties []string) error {
+	var columns = make([]*propertyInfo, len(properties))
+	for i, name := range properties {
+		if columns[i] = box.entity.propertyByName(name); columns[i] == nil {
+			return fmt.Errorf("unknown property '%s' on entity %s", name, box.entity.name)
+		} else if !columns[i].supportsCSV() {
+			return fmt.Errorf("property '%s' on entity %s has a type %d that can't be exported to CSV",
+				name, box.entity.name, columns[i].propertyType)
+		}
+	}
+
+	var writer = csv.NewWriter(w)
+	if err := writer.Write(properties); err != nil {
+		return err
+	}
+
+	var err error
+	var row = make([]string, len(columns))
+	var visitor uint32
+	visitor, err = dataVisitorRegister(func(bytes []byte) bool {
+		var table = &flatbuffers.Table{
+			Bytes: bytes,
+			Pos:   flatbuffers.GetUOffsetT(bytes),
+		}
+		for i, column := range columns {
+			row[i] = column.csvValue(table)
+		}
+		if err = writer.Write(row); err != nil {
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	defer dataVisitorUnregister(visitor)
+
+	// use another `error` variable as `err` may be set by the visitor callback above
+	var err2 = box.ObjectBox.RunInReadTx(func() error {
+		return cCall(func() C.obx_err {
+			return C.obx_box_visit_all(box.cBox, dataVisitor, unsafe.Pointer(&visitor))
+		})
+	})
+
+	if err2 != nil {
+		return err2
+	} else if err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func (property *propertyInfo) slot() flatbuffers.VOffsetT {
+	return flatbuffers.VOffsetT(4 + 2*(property.id-1))
+}
+
+func (property *propertyInfo) isUnsigned() bool {
+	return property.flags&C.OBXPropertyFlags_UNSIGNED != 0
+}
+
+func (property *propertyInfo) supportsCSV() bool {
+	switch property.propertyType {
+	case C.OBXPropertyType_Bool, C.OBXPropertyType_Byte, C.OBXPropertyType_Short, C.OBXPropertyType_Char,
+		C.OBXPropertyType_Int, C.OBXPropertyType_Long, C.OBXPropertyType_Float, C.OBXPropertyType_Double,
+		C.OBXPropertyType_String, C.OBXPropertyType_Date, C.OBXPropertyType_Relation, C.OBXPropertyType_DateNano,
+		C.OBXPropertyType_ByteVector, C.OBXPropertyType_StringVector:
+		return true
+	}
+	return false
+}
+
+// csvValue reads the raw (stored) value of the property from the given FlatBuffers table and formats it as a string.
+func (property *propertyInfo) csvValue(table *flatbuffers.Table) string {
+	var slot = property.slot()
+	switch property.propertyType {
+	case C.OBXPropertyType_Bool:
+		if v := fbutils.GetBoolPtrSlot(table, slot); v != nil {
+			return strconv.FormatBool(*v)
+		}
+	case C.OBXPropertyType_Byte:
+		if property.isUnsigned() {
+			if v := fbutils.GetUint8PtrSlot(table, slot); v != nil {
+				return strconv.FormatUint(uint64(*v), 10)
+			}
+		} else if v := fbutils.GetInt8PtrSlot(table, slot); v != nil {
+			return strconv.FormatInt(int64(*v), 10)
+		}
+	case C.OBXPropertyType_Short, C.OBXPropertyType_Char:
+		if property.isUnsigned() {
+			if v := fbutils.GetUint16PtrSlot(table, slot); v != nil {
+				return strconv.FormatUint(uint64(*v), 10)
+			}
+		} else if v := fbutils.GetInt16PtrSlot(table, slot); v != nil {
+			return strconv.FormatInt(int64(*v), 10)
+		}
+	case C.OBXPropertyType_Int:
+		if property.isUnsigned() {
+			if v := fbutils.GetUint32PtrSlot(table, slot); v != nil {
+				return strconv.FormatUint(uint64(*v), 10)
+			}
+		} else if v := fbutils.GetInt32PtrSlot(table, slot); v != nil {
+			return strconv.FormatInt(int64(*v), 10)
+		}
+	case C.OBXPropertyType_Long, C.OBXPropertyType_Date, C.OBXPropertyType_DateNano, C.OBXPropertyType_Relation:
+		if property.isUnsigned() || property.propertyType == C.OBXPropertyType_Relation ||
+			property.flags&C.OBXPropertyFlags_ID != 0 {
+			if v := fbutils.GetUint64PtrSlot(table, slot); v != nil {
+				return strconv.FormatUint(*v, 10)
+			}
+		} else if v := fbutils.GetInt64PtrSlot(table, slot); v != nil {
+			return strconv.FormatInt(*v, 10)
+		}
+	case C.OBXPropertyType_Float:
+		if v := fbutils.GetFloat32PtrSlot(table, slot); v != nil {
+			return strconv.FormatFloat(float64(*v), 'g', -1, 32)
+		}
+	case C.OBXPropertyType_Double:
+		if v := fbutils.GetFloat64PtrSlot(table, slot); v != nil {
+			return strconv.FormatFloat(*v, 'g', -1, 64)
+		}
+	case C.OBXPropertyType_String:
+		if v := fbutils.GetStringPtrSlot(table, slot); v != nil {
+			return *v
+		}
+	case C.OBXPropertyType_ByteVector:
+		if v := fbutils.GetByteVectorPtrSlot(table, slot); v != nil {
+			return base64.StdEncoding.EncodeToString(*v)
+		}
+	case C.OBXPropertyType_StringVector:
+		if v := fbutils.GetStringVectorPtrSlot(table, slot); v != nil {
+			return strings.Join(*v, "\n")
+		}
+	}
+	return ""
+}
@@ -26,3 +26,40 @@ var fbbPool = sync.Pool{
 		return flatbuffers.NewBuilder(256)
 	},
 }
+
+// fbbPoolPut puts the fbb back to the pool for the others to use if it's reasonably small
+func fbbPoolPut(fbb *flatbuffers.Builder) {
+	if cap(fbb.Bytes) < 1024*1024 {
+		fbb.Reset()
+		fbbPool.Put(fbb)
+	}
+}
+
+// putManyArenaChunkMax limits the size of a single chunk allocated by putManyArena
+const putManyArenaChunkMax = 4 * 1024 * 1024
+
+// putManyArena holds copies of flattened objects in a few large chunks instead of an allocation per object
+type putManyArena struct {
+	chunk []byte
+}
+
+// copy returns a copy of the given bytes. If the current chunk is full, a new one is allocated, sized for the given
+// number of remaining objects of (about) the same size.
+func (arena *putManyArena) copy(bytes []byte, remaining int) []byte {
+	if cap(arena.chunk)-len(arena.chunk) < len(bytes) {
+		var size = len(bytes) * remaining
+		if size > putManyArenaChunkMax {
+			size = putManyArenaChunkMax
+		}
+		if size < len(bytes) {
+			size = len(bytes)
+		}
+		arena.chunk = make([]byte, 0, size)
+	}
+
+	var start = len(arena.chunk)
+	arena.chunk = append(arena.chunk, bytes...)
+
+	// limit the capacity so that the returned slice can't be appended to over the next object's bytes
+	return arena.chunk[start:len(arena.chunk):len(arena.chunk)]
+}
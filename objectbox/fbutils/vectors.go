@@ -0,0 +1,338 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fbutils
+
+import "github.com/google/flatbuffers/go"
+
+// Numeric vectors (slices of scalars), stored as FlatBuffers vectors.
+// Like for []byte, a nil slice isn't stored at all (offset 0) while an empty slice is stored as an empty vector,
+// so both are read back as they were written.
+
+// CreateInt8VectorOffset creates an offset in the FlatBuffers table
+func CreateInt8VectorOffset(fbb *flatbuffers.Builder, values []int8) flatbuffers.UOffsetT {
+	if values == nil {
+		return 0
+	}
+
+	fbb.StartVector(1, len(values), 1)
+	for i := len(values) - 1; i >= 0; i-- {
+		fbb.PrependInt8(values[i])
+	}
+	return fbb.EndVector(len(values))
+}
+
+// GetInt8VectorSlot provides access to the FlatBuffers table
+func GetInt8VectorSlot(table *flatbuffers.Table, slot flatbuffers.VOffsetT) []int8 {
+	if vector := GetInt8VectorPtrSlot(table, slot); vector != nil {
+		return *vector
+	}
+	return nil
+}
+
+// GetInt8VectorPtrSlot provides access to the FlatBuffers table
+func GetInt8VectorPtrSlot(table *flatbuffers.Table, slot flatbuffers.VOffsetT) *[]int8 {
+	if o := flatbuffers.UOffsetT(table.Offset(slot)); o != 0 {
+		var ln = table.VectorLen(o)
+		var start = table.Vector(o)
+		var values = make([]int8, ln)
+		for i := range values {
+			values[i] = table.GetInt8(start + flatbuffers.UOffsetT(i*1))
+		}
+		return &values
+	}
+	return nil
+}
+
+// CreateInt16VectorOffset creates an offset in the FlatBuffers table
+func CreateInt16VectorOffset(fbb *flatbuffers.Builder, values []int16) flatbuffers.UOffsetT {
+	if values == nil {
+		return 0
+	}
+
+	fbb.StartVector(2, len(values), 2)
+	for i := len(values) - 1; i >= 0; i-- {
+		fbb.PrependInt16(values[i])
+	}
+	return fbb.EndVector(len(values))
+}
+
+// GetInt16VectorSlot provides access to the FlatBuffers table
+func GetInt16VectorSlot(table *flatbuffers.Table, slot flatbuffers.VOffsetT) []int16 {
+	if vector := GetInt16VectorPtrSlot(table, slot); vector != nil {
+		return *vector
+	}
+	return nil
+}
+
+// GetInt16VectorPtrSlot provides access to the FlatBuffers table
+func GetInt16VectorPtrSlot(table *flatbuffers.Table, slot flatbuffers.VOffsetT) *[]int16 {
+	if o := flatbuffers.UOffsetT(table.Offset(slot)); o != 0 {
+		var ln = table.VectorLen(o)
+		var start = table.Vector(o)
+		var values = make([]int16, ln)
+		for i := range values {
+			values[i] = table.GetInt16(start + flatbuffers.UOffsetT(i*2))
+		}
+		return &values
+	}
+	return nil
+}
+
+// CreateUint16VectorOffset creates an offset in the FlatBuffers table
+func CreateUint16VectorOffset(fbb *flatbuffers.Builder, values []uint16) flatbuffers.UOffsetT {
+	if values == nil {
+		return 0
+	}
+
+	fbb.StartVector(2, len(values), 2)
+	for i := len(values) - 1; i >= 0; i-- {
+		fbb.PrependUint16(values[i])
+	}
+	return fbb.EndVector(len(values))
+}
+
+// GetUint16VectorSlot provides access to the FlatBuffers table
+func GetUint16VectorSlot(table *flatbuffers.Table, slot flatbuffers.VOffsetT) []uint16 {
+	if vector := GetUint16VectorPtrSlot(table, slot); vector != nil {
+		return *vector
+	}
+	return nil
+}
+
+// GetUint16VectorPtrSlot provides access to the FlatBuffers table
+func GetUint16VectorPtrSlot(table *flatbuffers.Table, slot flatbuffers.VOffsetT) *[]uint16 {
+	if o := flatbuffers.UOffsetT(table.Offset(slot)); o != 0 {
+		var ln = table.VectorLen(o)
+		var start = table.Vector(o)
+		var values = make([]uint16, ln)
+		for i := range values {
+			values[i] = table.GetUint16(start + flatbuffers.UOffsetT(i*2))
+		}
+		return &values
+	}
+	return nil
+}
+
+// CreateInt32VectorOffset creates an offset in the FlatBuffers table
+func CreateInt32VectorOffset(fbb *flatbuffers.Builder, values []int32) flatbuffers.UOffsetT {
+	if values == nil {
+		return 0
+	}
+
+	fbb.StartVector(4, len(values), 4)
+	for i := len(values) - 1; i >= 0; i-- {
+		fbb.PrependInt32(values[i])
+	}
+	return fbb.EndVector(len(values))
+}
+
+// GetInt32VectorSlot provides access to the FlatBuffers table
+func GetInt32VectorSlot(table *flatbuffers.Table, slot flatbuffers.VOffsetT) []int32 {
+	if vector := GetInt32VectorPtrSlot(table, slot); vector != nil {
+		return *vector
+	}
+	return nil
+}
+
+// GetInt32VectorPtrSlot provides access to the FlatBuffers table
+func GetInt32VectorPtrSlot(table *flatbuffers.Table, slot flatbuffers.VOffsetT) *[]int32 {
+	if o := flatbuffers.UOffsetT(table.Offset(slot)); o != 0 {
+		var ln = table.VectorLen(o)
+		var start = table.Vector(o)
+		var values = make([]int32, ln)
+		for i := range values {
+			values[i] = table.GetInt32(start + flatbuffers.UOffsetT(i*4))
+		}
+		return &values
+	}
+	return nil
+}
+
+// CreateUint32VectorOffset creates an offset in the FlatBuffers table
+func CreateUint32VectorOffset(fbb *flatbuffers.Builder, values []uint32) flatbuffers.UOffsetT {
+	if values == nil {
+		return 0
+	}
+
+	fbb.StartVector(4, len(values), 4)
+	for i := len(values) - 1; i >= 0; i-- {
+		fbb.PrependUint32(values[i])
+	}
+	return fbb.EndVector(len(values))
+}
+
+// GetUint32VectorSlot provides access to the FlatBuffers table
+func GetUint32VectorSlot(table *flatbuffers.Table, slot flatbuffers.VOffsetT) []uint32 {
+	if vector := GetUint32VectorPtrSlot(table, slot); vector != nil {
+		return *vector
+	}
+	return nil
+}
+
+// GetUint32VectorPtrSlot provides access to the FlatBuffers table
+func GetUint32VectorPtrSlot(table *flatbuffers.Table, slot flatbuffers.VOffsetT) *[]uint32 {
+	if o := flatbuffers.UOffsetT(table.Offset(slot)); o != 0 {
+		var ln = table.VectorLen(o)
+		var start = table.Vector(o)
+		var values = make([]uint32, ln)
+		for i := range values {
+			values[i] = table.GetUint32(start + flatbuffers.UOffsetT(i*4))
+		}
+		return &values
+	}
+	return nil
+}
+
+// CreateInt64VectorOffset creates an offset in the FlatBuffers table
+func CreateInt64VectorOffset(fbb *flatbuffers.Builder, values []int64) flatbuffers.UOffsetT {
+	if values == nil {
+		return 0
+	}
+
+	fbb.StartVector(8, len(values), 8)
+	for i := len(values) - 1; i >= 0; i-- {
+		fbb.PrependInt64(values[i])
+	}
+	return fbb.EndVector(len(values))
+}
+
+// GetInt64VectorSlot provides access to the FlatBuffers table
+func GetInt64VectorSlot(table *flatbuffers.Table, slot flatbuffers.VOffsetT) []int64 {
+	if vector := GetInt64VectorPtrSlot(table, slot); vector != nil {
+		return *vector
+	}
+	return nil
+}
+
+// GetInt64VectorPtrSlot provides access to the FlatBuffers table
+func GetInt64VectorPtrSlot(table *flatbuffers.Table, slot flatbuffers.VOffsetT) *[]int64 {
+	if o := flatbuffers.UOffsetT(table.Offset(slot)); o != 0 {
+		var ln = table.VectorLen(o)
+		var start = table.Vector(o)
+		var values = make([]int64, ln)
+		for i := range values {
+			values[i] = table.GetInt64(start + flatbuffers.UOffsetT(i*8))
+		}
+		return &values
+	}
+	return nil
+}
+
+// CreateUint64VectorOffset creates an offset in the FlatBuffers table
+func CreateUint64VectorOffset(fbb *flatbuffers.Builder, values []uint64) flatbuffers.UOffsetT {
+	if values == nil {
+		return 0
+	}
+
+	fbb.StartVector(8, len(values), 8)
+	for i := len(values) - 1; i >= 0; i-- {
+		fbb.PrependUint64(values[i])
+	}
+	return fbb.EndVector(len(values))
+}
+
+// GetUint64VectorSlot provides access to the FlatBuffers table
+func GetUint64VectorSlot(table *flatbuffers.Table, slot flatbuffers.VOffsetT) []uint64 {
+	if vector := GetUint64VectorPtrSlot(table, slot); vector != nil {
+		return *vector
+	}
+	return nil
+}
+
+// GetUint64VectorPtrSlot provides access to the FlatBuffers table
+func GetUint64VectorPtrSlot(table *flatbuffers.Table, slot flatbuffers.VOffsetT) *[]uint64 {
+	if o := flatbuffers.UOffsetT(table.Offset(slot)); o != 0 {
+		var ln = table.VectorLen(o)
+		var start = table.Vector(o)
+		var values = make([]uint64, ln)
+		for i := range values {
+			values[i] = table.GetUint64(start + flatbuffers.UOffsetT(i*8))
+		}
+		return &values
+	}
+	return nil
+}
+
+// CreateFloat32VectorOffset creates an offset in the FlatBuffers table
+func CreateFloat32VectorOffset(fbb *flatbuffers.Builder, values []float32) flatbuffers.UOffsetT {
+	if values == nil {
+		return 0
+	}
+
+	fbb.StartVector(4, len(values), 4)
+	for i := len(values) - 1; i >= 0; i-- {
+		fbb.PrependFloat32(values[i])
+	}
+	return fbb.EndVector(len(values))
+}
+
+// GetFloat32VectorSlot provides access to the FlatBuffers table
+func GetFloat32VectorSlot(table *flatbuffers.Table, slot flatbuffers.VOffsetT) []float32 {
+	if vector := GetFloat32VectorPtrSlot(table, slot); vector != nil {
+		return *vector
+	}
+	return nil
+}
+
+// GetFloat32VectorPtrSlot provides access to the FlatBuffers table
+func GetFloat32VectorPtrSlot(table *flatbuffers.Table, slot flatbuffers.VOffsetT) *[]float32 {
+	if o := flatbuffers.UOffsetT(table.Offset(slot)); o != 0 {
+		var ln = table.VectorLen(o)
+		var start = table.Vector(o)
+		var values = make([]float32, ln)
+		for i := range values {
+			values[i] = table.GetFloat32(start + flatbuffers.UOffsetT(i*4))
+		}
+		return &values
+	}
+	return nil
+}
+
+// CreateFloat64VectorOffset creates an offset in the FlatBuffers table
+func CreateFloat64VectorOffset(fbb *flatbuffers.Builder, values []float64) flatbuffers.UOffsetT {
+	if values == nil {
+		return 0
+	}
+
+	fbb.StartVector(8, len(values), 8)
+	for i := len(values) - 1; i >= 0; i-- {
+		fbb.PrependFloat64(values[i])
+	}
+	return fbb.EndVector(len(values))
+}
+
+// GetFloat64VectorSlot provides access to the FlatBuffers table
+func GetFloat64VectorSlot(table *flatbuffers.Table, slot flatbuffers.VOffsetT) []float64 {
+	if vector := GetFloat64VectorPtrSlot(table, slot); vector != nil {
+		return *vector
+	}
+	return nil
+}
+
+// GetFloat64VectorPtrSlot provides access to the FlatBuffers table
+func GetFloat64VectorPtrSlot(table *flatbuffers.Table, slot flatbuffers.VOffsetT) *[]float64 {
+	if o := flatbuffers.UOffsetT(table.Offset(slot)); o != 0 {
+		var ln = table.VectorLen(o)
+		var start = table.Vector(o)
+		var values = make([]float64, ln)
+		for i := range values {
+			values[i] = table.GetFloat64(start + flatbuffers.UOffsetT(i*8))
+		}
+		return &values
+	}
+	return nil
+}
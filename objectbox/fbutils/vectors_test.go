@@ -0,0 +1,145 @@
+package fbutils
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/flatbuffers/go"
+	"github.com/objectbox/objectbox-go/test/assert"
+)
+
+// vectorTestTable builds an object with three vectors created by the given function: nil, empty and with values.
+// Returns a table reading from an unsafe copy of the bytes and a function clearing the source memory.
+func vectorTestTable(create func(fbb *flatbuffers.Builder, which int) flatbuffers.UOffsetT) (*flatbuffers.Table, func()) {
+	var fbb = flatbuffers.NewBuilder(256)
+	var offsets = []flatbuffers.UOffsetT{create(fbb, 0), create(fbb, 1), create(fbb, 2)}
+	fbb.StartObject(3)
+	for i, offset := range offsets {
+		SetUOffsetTSlot(fbb, i, offset)
+	}
+	fbb.Finish(fbb.EndObject())
+
+	var managed = fbb.FinishedBytes()
+	var bytes = getUnsafeBytes(managed)
+	return &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}, func() { clearBytes(&managed) }
+}
+
+func TestInt8Vector(t *testing.T) {
+	var values = [][]int8{nil, {}, {math.MinInt8, -1, 0, 1, math.MaxInt8}}
+	table, clear := vectorTestTable(func(fbb *flatbuffers.Builder, which int) flatbuffers.UOffsetT {
+		return CreateInt8VectorOffset(fbb, values[which])
+	})
+
+	var read = [][]int8{GetInt8VectorSlot(table, 4), GetInt8VectorSlot(table, 6), GetInt8VectorSlot(table, 8)}
+	clear()
+
+	assert.Eq(t, values, read)
+	assert.True(t, read[0] == nil && read[1] != nil)
+}
+
+func TestInt16Vector(t *testing.T) {
+	var values = [][]int16{nil, {}, {math.MinInt16, -1, 0, 1, math.MaxInt16}}
+	table, clear := vectorTestTable(func(fbb *flatbuffers.Builder, which int) flatbuffers.UOffsetT {
+		return CreateInt16VectorOffset(fbb, values[which])
+	})
+
+	var read = [][]int16{GetInt16VectorSlot(table, 4), GetInt16VectorSlot(table, 6), GetInt16VectorSlot(table, 8)}
+	clear()
+
+	assert.Eq(t, values, read)
+	assert.True(t, read[0] == nil && read[1] != nil)
+}
+
+func TestUint16Vector(t *testing.T) {
+	var values = [][]uint16{nil, {}, {0, 1, math.MaxUint16}}
+	table, clear := vectorTestTable(func(fbb *flatbuffers.Builder, which int) flatbuffers.UOffsetT {
+		return CreateUint16VectorOffset(fbb, values[which])
+	})
+
+	var read = [][]uint16{GetUint16VectorSlot(table, 4), GetUint16VectorSlot(table, 6), GetUint16VectorSlot(table, 8)}
+	clear()
+
+	assert.Eq(t, values, read)
+	assert.True(t, read[0] == nil && read[1] != nil)
+}
+
+func TestInt32Vector(t *testing.T) {
+	var values = [][]int32{nil, {}, {math.MinInt32, -1, 0, 1, math.MaxInt32}}
+	table, clear := vectorTestTable(func(fbb *flatbuffers.Builder, which int) flatbuffers.UOffsetT {
+		return CreateInt32VectorOffset(fbb, values[which])
+	})
+
+	var read = [][]int32{GetInt32VectorSlot(table, 4), GetInt32VectorSlot(table, 6), GetInt32VectorSlot(table, 8)}
+	clear()
+
+	assert.Eq(t, values, read)
+	assert.True(t, read[0] == nil && read[1] != nil)
+}
+
+func TestUint32Vector(t *testing.T) {
+	var values = [][]uint32{nil, {}, {0, 1, math.MaxUint32}}
+	table, clear := vectorTestTable(func(fbb *flatbuffers.Builder, which int) flatbuffers.UOffsetT {
+		return CreateUint32VectorOffset(fbb, values[which])
+	})
+
+	var read = [][]uint32{GetUint32VectorSlot(table, 4), GetUint32VectorSlot(table, 6), GetUint32VectorSlot(table, 8)}
+	clear()
+
+	assert.Eq(t, values, read)
+	assert.True(t, read[0] == nil && read[1] != nil)
+}
+
+func TestInt64Vector(t *testing.T) {
+	var values = [][]int64{nil, {}, {math.MinInt64, -1, 0, 1, math.MaxInt64}}
+	table, clear := vectorTestTable(func(fbb *flatbuffers.Builder, which int) flatbuffers.UOffsetT {
+		return CreateInt64VectorOffset(fbb, values[which])
+	})
+
+	var read = [][]int64{GetInt64VectorSlot(table, 4), GetInt64VectorSlot(table, 6), GetInt64VectorSlot(table, 8)}
+	clear()
+
+	assert.Eq(t, values, read)
+	assert.True(t, read[0] == nil && read[1] != nil)
+}
+
+func TestUint64Vector(t *testing.T) {
+	var values = [][]uint64{nil, {}, {0, 1, math.MaxUint64}}
+	table, clear := vectorTestTable(func(fbb *flatbuffers.Builder, which int) flatbuffers.UOffsetT {
+		return CreateUint64VectorOffset(fbb, values[which])
+	})
+
+	var read = [][]uint64{GetUint64VectorSlot(table, 4), GetUint64VectorSlot(table, 6), GetUint64VectorSlot(table, 8)}
+	clear()
+
+	assert.Eq(t, values, read)
+	assert.True(t, read[0] == nil && read[1] != nil)
+}
+
+func TestFloat32Vector(t *testing.T) {
+	var values = [][]float32{nil, {}, {-math.MaxFloat32, -1.5, 0, math.SmallestNonzeroFloat32, 47.74, math.MaxFloat32}}
+	table, clear := vectorTestTable(func(fbb *flatbuffers.Builder, which int) flatbuffers.UOffsetT {
+		return CreateFloat32VectorOffset(fbb, values[which])
+	})
+
+	var read = [][]float32{GetFloat32VectorSlot(table, 4), GetFloat32VectorSlot(table, 6), GetFloat32VectorSlot(table, 8)}
+	clear()
+
+	assert.Eq(t, values, read)
+	assert.True(t, read[0] == nil && read[1] != nil)
+}
+
+func TestFloat64Vector(t *testing.T) {
+	var values = [][]float64{nil, {}, {-math.MaxFloat64, -1.5, 0, math.SmallestNonzeroFloat64, 47.74, math.MaxFloat64}}
+	table, clear := vectorTestTable(func(fbb *flatbuffers.Builder, which int) flatbuffers.UOffsetT {
+		return CreateFloat64VectorOffset(fbb, values[which])
+	})
+
+	var read = [][]float64{GetFloat64VectorSlot(table, 4), GetFloat64VectorSlot(table, 6), GetFloat64VectorSlot(table, 8)}
+	clear()
+
+	assert.Eq(t, values, read)
+	assert.True(t, read[0] == nil && read[1] != nil)
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+import "sync"
+
+// boxHooks holds the functions applied to the objects of a box when they're read or written, see Box.SetPostLoad()
+// and Box.SetPreSave()
+type boxHooks struct {
+	mutex    sync.RWMutex
+	postLoad func(object interface{}) error
+	preSave  func(object interface{}) error
+}
+
+// SetPostLoad sets a function called for each object read from this box, after it has been created from the stored
+// data, e.g. to decrypt a field or to populate fields derived from the stored ones. It applies to all read paths
+// returning objects: Get() and its variants, GetMany(), GetAll(), query results (Find() and others) and iterating
+// functions like GetAllWhere() and ForEachReverse(). Pass nil to remove the hook.
+//
+// An error returned by the hook aborts the read and is returned by the read operation. The hook is called inside the
+// read transaction and must not modify the store. Like RequireIds(), the setting is kept for the lifetime of the
+// ObjectBox instance; it can be changed at any time and affects the reads started afterwards.
+func (box *Box) SetPostLoad(fn func(object interface{}) error) {
+	box.hooks.mutex.Lock()
+	defer box.hooks.mutex.Unlock()
+	box.hooks.postLoad = fn
+}
+
+// load creates an object from the stored data, applying the post-load hook; returns nil if the object has expired
+func (box *Box) load(bytes []byte) (interface{}, error) {
+	if box.expired(bytes) {
+		return nil, nil
+	}
+
+	object, err := box.entity.binding.Load(box.ObjectBox, bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	box.hooks.mutex.RLock()
+	var postLoad = box.hooks.postLoad
+	box.hooks.mutex.RUnlock()
+
+	if postLoad != nil {
+		if err := postLoad(object); err != nil {
+			return nil, err
+		}
+	}
+	return object, nil
+}
+
+// SetPreSave sets a function called for each object written to this box, just before it's converted to the stored
+// format, e.g. to encrypt a field or to set a timestamp; together with SetPostLoad() this allows for transparent
+// field encryption. It applies to all put variants: Put(), Insert(), Update(), PutMany() and the other bulk
+// functions, async puts (Box.Async()) and the updates done by PutMerge() and Query.SetProperty(); CopyTo() copies the
+// stored data as-is, without calling the hook. Pass nil to remove the hook.
+//
+// The hook is called exactly once per object and write operation, also in bulk operations and coalesced puts. Note
+// that it modifies the given object (changes remain visible to the caller). An error returned by the hook aborts the
+// write: nothing is stored for a single put and the whole transaction is rolled back for a bulk put (the error is
+// wrapped in a PutManyError). The setting is kept for the lifetime of the ObjectBox instance and can be changed at
+// any time.
+func (box *Box) SetPreSave(fn func(object interface{}) error) {
+	box.hooks.mutex.Lock()
+	defer box.hooks.mutex.Unlock()
+	box.hooks.preSave = fn
+}
+
+// preSave applies the pre-save hook, if any, to an object about to be written
+func (box *Box) preSave(object interface{}) error {
+	box.hooks.mutex.RLock()
+	var preSave = box.hooks.preSave
+	box.hooks.mutex.RUnlock()
+
+	if preSave == nil {
+		return nil
+	}
+	return preSave(object)
+}
@@ -18,3 +18,20 @@ func TestLargeArraySupport(t *testing.T) {
 			"in the ObjectBox core library", runtime.GOARCH)
 	}
 }
+
+func TestIsCorruptOnOpen(t *testing.T) {
+	const storageGeneral = 10199 // OBX_ERROR_STORAGE_GENERAL
+
+	// recognized by the codes only, regardless of the message
+	for _, secondary := range []int{mdbInvalid, mdbPageNotFound, mdbCorrupted} {
+		if !isCorruptOnOpen(&StorageError{Code: storageGeneral, secondaryCode: secondary}) {
+			t.Errorf("secondary code %d not recognized as corruption", secondary)
+		}
+	}
+	if isCorruptOnOpen(&StorageError{Code: storageGeneral, secondaryCode: 13, Message: "corrupt"}) {
+		t.Errorf("secondary code 13 (EACCES) recognized as corruption")
+	}
+	if isCorruptOnOpen(&StorageError{Code: 10198, secondaryCode: mdbInvalid}) {
+		t.Errorf("a different primary code recognized as corruption")
+	}
+}
@@ -30,36 +30,76 @@ import (
 	"github.com/objectbox/objectbox-generator/cmd/objectbox-gogen"
 )
 
-// ObjectBinding provides an interface for various object types to be included in the model
+// ObjectBinding provides an interface for various object types to be included in the model.
+//
+// Bindings are usually generated (see objectbox-gogen) but can also be written by hand, e.g. for entities registered
+// at runtime using Builder.RegisterEntity(). All functions are called with objects of the single Go type the binding
+// represents (usually a pointer to a struct) and must be safe for concurrent use. Objects are stored in the FlatBuffers
+// format: each property is a field of a table, its slot given by the property ID (the slot of the property with ID 1
+// is 0, its vtable offset used for reading is 4; the following ones increment by 1 and 2, respectively).
 type ObjectBinding interface {
 	// AddToModel adds the entity information, including properties, indexes, etc., to the model during construction.
+	// It must call model.Entity() first, followed by model.Property() (and model.PropertyFlags(), etc.) for each
+	// property, including the ID property flagged with OBXPropertyFlags_ID (1), and model.EntityLastPropertyId().
+	// The IDs and UIDs must stay the same across program runs, otherwise the stored data isn't recognized.
 	AddToModel(model *Model)
 
-	// GetId reads the ID field of the given object.
+	// GetId reads the ID field of the given object; 0 for new objects (an ID is assigned on put).
 	GetId(object interface{}) (id uint64, err error)
 
-	// SetId sets the ID field on the given object.
+	// SetId sets the ID field on the given object, e.g. after a put assigned a new ID to it.
 	SetId(object interface{}, id uint64) error
 
 	// PutRelated updates/inserts objects related to the given object, based on the available object data.
+	// Called inside the write transaction of the put, before Flatten(); return nil if the entity has no relations.
 	PutRelated(ob *ObjectBox, object interface{}, id uint64) error
 
 	// Flatten serializes the object to FlatBuffers. The given ID must be used instead of the object field.
+	// It must create any offsets (strings, vectors) first, then call fbb.StartObject() with the number of slots and
+	// set the fields; the table is finished by the caller (fbb.EndObject(), Finish()).
 	Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error
 
 	// Load constructs the object from serialized byte buffer. Also reads data for eagerly loaded related entities.
+	// The bytes are only valid during the call, so all values (including strings and byte vectors) must be copied.
 	Load(ob *ObjectBox, bytes []byte) (interface{}, error)
 
 	// MakeSlice creates a slice of objects with the given capacity (0 length).
 	MakeSlice(capacity int) interface{}
 
 	// AppendToSlice adds the object at the end of the slice created by MakeSlice(). Returns the new slice.
+	// The object may be nil, e.g. for GetMany() with an ID that doesn't exist.
 	AppendToSlice(slice interface{}, object interface{}) (sliceNew interface{})
 
 	// GeneratorVersion returns the version used to generate this binding - used to verify the compatibility.
+	// Hand-written bindings should return BindingVersion.
 	GeneratorVersion() int
 }
 
+// BindingVersion is the binding version expected by this version of ObjectBox: the value returned by
+// ObjectBinding.GeneratorVersion() and passed to Model.GeneratorVersion() by the generated code.
+const BindingVersion = gogen.VersionId
+
+// ObjectSliceBinding can optionally be implemented by an ObjectBinding to give access to the objects in a slice given
+// to PutMany() and similar functions without using reflection, which is considerably faster for large slices.
+// Bindings not implementing it are handled using reflection.
+type ObjectSliceBinding interface {
+	// SliceLen returns the number of objects in the given slice (of the same type as created by MakeSlice()).
+	SliceLen(slice interface{}) int
+
+	// SliceObject returns the object at the given index of the slice.
+	SliceObject(slice interface{}, index int) interface{}
+}
+
+// SliceAppenderBinding can optionally be implemented by an ObjectBinding to let GetAll(), query results and the other
+// functions reading multiple objects build the resulting slice directly as the typed slice (e.g. []*Order). With
+// AppendToSlice(), the slice passes through interface{} for each object, which costs an allocation per object.
+// Bindings not implementing it are handled using MakeSlice() and AppendToSlice().
+type SliceAppenderBinding interface {
+	// NewSliceAppender creates a slice (of the same type as created by MakeSlice()) with the given capacity and returns
+	// a function appending an object (as returned by Load(), or nil) to it and a function returning the slice.
+	NewSliceAppender(capacity int) (add func(object interface{}), result func() interface{})
+}
+
 // Model is used by the generated code to represent information about the ObjectBox database schema
 type Model struct {
 	cModel *C.OBX_model
@@ -68,6 +108,7 @@ type Model struct {
 	currentEntity  *entity
 	entitiesById   map[TypeId]*entity
 	entitiesByName map[string]*entity
+	entities       []*entity // in the order the bindings were registered, see ObjectBox.ModelJSON()
 
 	lastEntityId  TypeId
 	lastEntityUid uint64
@@ -156,6 +197,7 @@ func (model *Model) Entity(name string, id TypeId, uid uint64) {
 	model.currentEntity = &entity{
 		name: name,
 		id:   id,
+		uid:  uid,
 	}
 }
 
@@ -167,6 +209,10 @@ func (model *Model) EntityFlags(entityFlags int) {
 	model.Error = cCall(func() C.obx_err {
 		return C.obx_model_entity_flags(model.cModel, C.uint32_t(entityFlags))
 	})
+
+	if model.Error == nil {
+		model.currentEntity.flags = entityFlags
+	}
 }
 
 // TODO each Entity-related method (e.g. Property, Relation,...) should check whether currentEntity is not nil
@@ -182,6 +228,15 @@ func (model *Model) Relation(relationId TypeId, relationUid uint64, targetEntity
 			C.obx_schema_id(targetEntityId), C.obx_uid(targetEntityUid))
 	})
 
+	if model.Error == nil {
+		model.currentEntity.relations = append(model.currentEntity.relations, relationInfo{
+			id:        relationId,
+			uid:       relationUid,
+			targetId:  targetEntityId,
+			targetUid: targetEntityUid,
+		})
+	}
+
 	model.currentEntity.hasRelations = true
 }
 
@@ -195,6 +250,11 @@ func (model *Model) EntityLastPropertyId(id TypeId, uid uint64) {
 	model.Error = cCall(func() C.obx_err {
 		return C.obx_model_entity_last_property_id(model.cModel, C.obx_schema_id(id), C.obx_uid(uid))
 	})
+
+	if model.Error == nil {
+		model.currentEntity.lastPropertyId = id
+		model.currentEntity.lastPropertyUid = uid
+	}
 }
 
 // Property creates a property in an Entity
@@ -202,12 +262,32 @@ func (model *Model) Property(name string, propertyType int, id TypeId, uid uint6
 	if model.Error != nil {
 		return
 	}
+
+	// UIDs identify properties across renames so a duplicate would silently merge two properties' data
+	for _, property := range model.currentEntity.properties {
+		if property.uid == uid {
+			model.Error = fmt.Errorf("duplicate property UID %d on entity %s: used by both %s and %s - "+
+				"when renaming a field, keep its `objectbox:\"uid:%d\"` annotation only on the renamed field",
+				uid, model.currentEntity.name, property.name, name, uid)
+			return
+		}
+	}
+
 	cname := C.CString(name)
 	defer C.free(unsafe.Pointer(cname))
 
 	model.Error = cCall(func() C.obx_err {
 		return C.obx_model_property(model.cModel, cname, C.OBXPropertyType(propertyType), C.obx_schema_id(id), C.obx_uid(uid))
 	})
+
+	if model.Error == nil {
+		model.currentEntity.properties = append(model.currentEntity.properties, &propertyInfo{
+			id:           id,
+			uid:          uid,
+			name:         name,
+			propertyType: propertyType,
+		})
+	}
 }
 
 // PropertyFlags configures type and other information about the property
@@ -218,6 +298,14 @@ func (model *Model) PropertyFlags(propertyFlags int) {
 	model.Error = cCall(func() C.obx_err {
 		return C.obx_model_property_flags(model.cModel, C.uint32_t(propertyFlags))
 	})
+
+	if model.Error == nil {
+		var property = model.currentEntity.lastProperty()
+		property.flags = propertyFlags
+		if propertyFlags&C.OBXPropertyFlags_ID != 0 {
+			model.currentEntity.idPropertyId = property.id
+		}
+	}
 }
 
 // PropertyIndex creates a new index on the property
@@ -228,6 +316,12 @@ func (model *Model) PropertyIndex(id TypeId, uid uint64) {
 	model.Error = cCall(func() C.obx_err {
 		return C.obx_model_property_index_id(model.cModel, C.obx_schema_id(id), C.obx_uid(uid))
 	})
+
+	if model.Error == nil {
+		var property = model.currentEntity.lastProperty()
+		property.indexId = id
+		property.indexUid = uid
+	}
 }
 
 // PropertyRelation adds a property-based (i.e. to-one) relation
@@ -243,6 +337,13 @@ func (model *Model) PropertyRelation(targetEntityName string, indexId TypeId, in
 		return C.obx_model_property_relation(model.cModel, cname, C.obx_schema_id(indexId), C.obx_uid(indexUid))
 	})
 
+	if model.Error == nil {
+		var property = model.currentEntity.lastProperty()
+		property.indexId = indexId
+		property.indexUid = indexUid
+		property.relationTarget = targetEntityName
+	}
+
 	model.currentEntity.hasRelations = true
 }
 
@@ -295,6 +396,7 @@ func (model *Model) RegisterBinding(binding ObjectBinding) {
 	model.currentEntity.binding = binding
 	model.entitiesById[id] = model.currentEntity
 	model.entitiesByName[name] = model.currentEntity
+	model.entities = append(model.entities, model.currentEntity)
 
 	model.currentEntity = nil
 }
@@ -313,5 +415,77 @@ func (model *Model) validate() error {
 		return fmt.Errorf("last entity ID/UID is missing")
 	}
 
+	return model.validateIds()
+}
+
+// validateIds checks the IDs and UIDs of all the registered entities as a whole, i.e. also across entities coming from
+// separately generated bindings, so that an inconsistency is reported before opening the store
+func (model *Model) validateIds() error {
+	// owners (for the error message) of all UIDs and of the IDs that must be unique within the model
+	var uids = make(map[uint64]string)
+	var indexIds = make(map[TypeId]string)
+	var relationIds = make(map[TypeId]string)
+
+	var claimUid = func(uid uint64, owner string) error {
+		if uid == 0 {
+			return fmt.Errorf("%s has no UID", owner)
+		} else if existing, found := uids[uid]; found {
+			return fmt.Errorf("duplicate UID %d: used by both %s and %s", uid, existing, owner)
+		}
+		uids[uid] = owner
+		return nil
+	}
+
+	var claimId = func(ids map[TypeId]string, kind string, id TypeId, last TypeId, owner string) error {
+		if existing, found := ids[id]; found {
+			return fmt.Errorf("duplicate %s ID %d: used by both %s and %s", kind, id, existing, owner)
+		} else if id > last {
+			return fmt.Errorf("%s ID %d of %s is higher than the last %s ID %d", kind, id, owner, kind, last)
+		}
+		ids[id] = owner
+		return nil
+	}
+
+	var entityIds = make(map[TypeId]string)
+	for _, entity := range model.entities {
+		var owner = "entity " + entity.name
+		if err := claimUid(entity.uid, owner); err != nil {
+			return err
+		} else if err := claimId(entityIds, "entity", entity.id, model.lastEntityId, owner); err != nil {
+			return err
+		} else if entity.id == model.lastEntityId && entity.uid != model.lastEntityUid {
+			return fmt.Errorf("UID %d of %s doesn't match the last entity UID %d", entity.uid, owner,
+				model.lastEntityUid)
+		}
+
+		var propertyIds = make(map[TypeId]string)
+		for _, property := range entity.properties {
+			var owner = fmt.Sprintf("property %s.%s", entity.name, property.name)
+			if err := claimUid(property.uid, owner); err != nil {
+				return err
+			} else if err := claimId(propertyIds, "property", property.id, entity.lastPropertyId, owner); err != nil {
+				return err
+			}
+
+			if property.indexId != 0 {
+				var owner = fmt.Sprintf("index on %s.%s", entity.name, property.name)
+				if err := claimUid(property.indexUid, owner); err != nil {
+					return err
+				} else if err := claimId(indexIds, "index", property.indexId, model.lastIndexId, owner); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, relation := range entity.relations {
+			var owner = fmt.Sprintf("relation %d on entity %s", relation.id, entity.name)
+			if err := claimUid(relation.uid, owner); err != nil {
+				return err
+			} else if err := claimId(relationIds, "relation", relation.id, model.lastRelationId, owner); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,112 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// the structure of objectbox-model.json; IDs are formatted as "id:uid"
+type modelJSON struct {
+	Entities       []entityJSON `json:"entities"`
+	LastEntityId   string       `json:"lastEntityId,omitempty"`
+	LastIndexId    string       `json:"lastIndexId,omitempty"`
+	LastRelationId string       `json:"lastRelationId,omitempty"`
+}
+
+type entityJSON struct {
+	Id             string         `json:"id"`
+	LastPropertyId string         `json:"lastPropertyId,omitempty"`
+	Name           string         `json:"name"`
+	Flags          int            `json:"flags,omitempty"`
+	Properties     []propertyJSON `json:"properties"`
+	Relations      []relationJSON `json:"relations,omitempty"`
+}
+
+type propertyJSON struct {
+	Id             string `json:"id"`
+	Name           string `json:"name"`
+	IndexId        string `json:"indexId,omitempty"`
+	Type           int    `json:"type"`
+	Flags          int    `json:"flags,omitempty"`
+	RelationTarget string `json:"relationTarget,omitempty"`
+}
+
+type relationJSON struct {
+	Id       string `json:"id"`
+	TargetId string `json:"targetId"`
+}
+
+func modelJSONId(id TypeId, uid uint64) string {
+	if id == 0 && uid == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d:%d", id, uid)
+}
+
+// ModelJSON returns the model registered by the generated code (see Builder.Model()) in the format of the
+// objectbox-model.json file: entities, their properties with types, flags and index IDs, and relations.
+// This helps to find discrepancies between the model file and the code actually compiled into the program, e.g. when
+// opening the database fails because of a schema mismatch.
+//
+// Note: only the information passed to the builder is included, i.e. not the retired UIDs, the model version or
+// names of standalone relations (not known at runtime). Entities are listed in the order they were registered.
+func (ob *ObjectBox) ModelJSON() ([]byte, error) {
+	if ob.model == nil {
+		return nil, fmt.Errorf("the model is not available")
+	}
+
+	var model = modelJSON{
+		Entities:       make([]entityJSON, 0, len(ob.model.entities)),
+		LastEntityId:   modelJSONId(ob.model.lastEntityId, ob.model.lastEntityUid),
+		LastIndexId:    modelJSONId(ob.model.lastIndexId, ob.model.lastIndexUid),
+		LastRelationId: modelJSONId(ob.model.lastRelationId, ob.model.lastRelationUid),
+	}
+
+	for _, entity := range ob.model.entities {
+		var entityJson = entityJSON{
+			Id:             modelJSONId(entity.id, entity.uid),
+			LastPropertyId: modelJSONId(entity.lastPropertyId, entity.lastPropertyUid),
+			Name:           entity.name,
+			Flags:          entity.flags,
+			Properties:     make([]propertyJSON, 0, len(entity.properties)),
+		}
+
+		for _, property := range entity.properties {
+			entityJson.Properties = append(entityJson.Properties, propertyJSON{
+				Id:             modelJSONId(property.id, property.uid),
+				Name:           property.name,
+				IndexId:        modelJSONId(property.indexId, property.indexUid),
+				Type:           property.propertyType,
+				Flags:          property.flags,
+				RelationTarget: property.relationTarget,
+			})
+		}
+
+		for _, relation := range entity.relations {
+			entityJson.Relations = append(entityJson.Relations, relationJSON{
+				Id:       modelJSONId(relation.id, relation.uid),
+				TargetId: modelJSONId(relation.targetId, relation.targetUid),
+			})
+		}
+
+		model.Entities = append(model.Entities, entityJson)
+	}
+
+	return json.MarshalIndent(model, "", "  ")
+}
@@ -26,9 +26,13 @@ import "C"
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"runtime"
+	"sort"
 	"strconv"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 const (
@@ -64,6 +68,9 @@ const (
 	cPutModePutIdGuaranteedToBeNew = 4
 )
 
+// ErrStoreClosed is returned by operations on an ObjectBox, its boxes and queries after ObjectBox.Close() was called
+var ErrStoreClosed = errors.New("the store has been closed")
+
 // atomic boolean true & false
 const aTrue = 1
 const aFalse = 0
@@ -76,10 +83,24 @@ type ObjectBox struct {
 	store          *C.OBX_store
 	entitiesById   map[TypeId]*entity
 	entitiesByName map[string]*entity
+	model          *Model
 	boxes          map[TypeId]*Box
 	boxesMutex     sync.Mutex
+	observers      map[*Observer]struct{}
+	observersMutex sync.Mutex
+	coalescer      *writeCoalescer
+	coalescerMutex sync.RWMutex
+	writeTxDepth   int32 // atomic; the number of (nested) write transactions started by RunInWriteTx currently open
+	audit          auditLog
+	readers        readerStats
+	maxReaders     int
+	readSlots      chan struct{}  // limits the concurrent reads of GetContext() to maxReaders
+	closed         int32          // atomic boolean; set by Close()
+	background     sync.WaitGroup // goroutines using the store in the background, see startBackground()
+	backgroundLock sync.Mutex     // makes startBackground() and setting `closed` mutually exclusive
 	options        options
 	syncClient     *SyncClient
+	directory      string // normalized, as registered in openDirectories
 }
 
 type options struct {
@@ -89,16 +110,100 @@ type options struct {
 // constant during runtime so no need to call this each time it's necessary
 var supportsResultArray = bool(C.obx_has_feature(C.OBXFeature_ResultArray))
 
-// Close fully closes the database and frees resources
+// Close fully closes the database and frees resources.
+// Pending asynchronous operations may be dropped, see CloseGraceful() to wait for them first.
+// The store is only freed once the goroutines the library runs in the background have finished (e.g. the one still
+// waiting for the asynchronous operations after a CloseGraceful() timeout), so this may block until then.
 func (ob *ObjectBox) Close() {
+	// let the pending coalesced puts finish before refusing further operations
+	ob.DisableWriteCoalescing()
+
+	ob.backgroundLock.Lock()
+	atomic.StoreInt32(&ob.closed, aTrue)
+	ob.backgroundLock.Unlock()
+	ob.background.Wait()
+
 	storeToClose := ob.store
 	ob.store = nil
 	if ob.syncClient != nil {
 		_ = ob.syncClient.Close()
 	}
 	if storeToClose != nil {
+		_ = ob.UnsubscribeAll()
 		C.obx_store_close(storeToClose)
+		unregisterDirectory(ob.directory)
+	}
+}
+
+// CloseGraceful closes the database like Close(), after waiting for the pending asynchronous operations (e.g. PutAsync)
+// to be processed so that none of the enqueued writes are lost. If they aren't finished within the given timeout, the
+// store is left open and an error is returned; it's then up to the caller to retry or to call Close(). Note that the
+// waiting continues in the background after a timeout and Close() only frees the store once it's finished, i.e. it
+// blocks until the queue has been processed.
+// Note: no new asynchronous operations should be submitted concurrently, these could still be lost.
+func (ob *ObjectBox) CloseGraceful(timeout time.Duration) error {
+	if err := ob.check(); err != nil {
+		return err
+	}
+
+	// let the pending coalesced puts finish first, these are executed synchronously
+	ob.DisableWriteCoalescing()
+
+	// the native call can't be interrupted, Close() must wait for it to return before freeing the store
+	if !ob.startBackground() {
+		return ErrStoreClosed
+	}
+	var awaited = make(chan error, 1)
+	go func() {
+		defer ob.background.Done()
+		awaited <- ob.AwaitAsyncCompletion()
+	}()
+
+	var timer = time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-awaited:
+		if err != nil {
+			return fmt.Errorf("can't wait for async operations to complete, the store is left open: %s", err)
+		}
+	case <-timer.C:
+		return fmt.Errorf("async operations didn't complete in %v, the store is left open", timeout)
+	}
+
+	ob.Close()
+	return nil
+}
+
+// Ping checks that the store is open and responsive by starting and immediately closing a read transaction.
+// It's cheap and safe to call concurrently, e.g. from a readiness/health-check probe.
+func (ob *ObjectBox) Ping() error {
+	if err := ob.check(); err != nil {
+		return err
 	}
+	return ob.RunInReadTx(func() error { return nil })
+}
+
+// startBackground registers a goroutine about to use the store in the background, which must call background.Done()
+// once finished: Close() waits for it before freeing the store. Returns false if the store has been closed already.
+func (ob *ObjectBox) startBackground() bool {
+	ob.backgroundLock.Lock()
+	defer ob.backgroundLock.Unlock()
+	if atomic.LoadInt32(&ob.closed) == aTrue {
+		return false
+	}
+	ob.background.Add(1)
+	return true
+}
+
+// check returns ErrStoreClosed if Close() has been called.
+// Note: this guards against using the store after it was closed, it doesn't make Close() safe to call concurrently
+// with other operations.
+func (ob *ObjectBox) check() error {
+	if atomic.LoadInt32(&ob.closed) == aTrue {
+		return ErrStoreClosed
+	}
+	return nil
 }
 
 // RunInReadTx executes the given function inside a read transaction.
@@ -106,6 +211,11 @@ func (ob *ObjectBox) Close() {
 // If you launch goroutines inside `fn`, they will be executed on separate threads and not part of the same transaction.
 // Multiple read transaction may be executed concurrently.
 // The error returned by your callback is passed-through as the output error
+//
+// A read transaction is snapshot-isolated: all reads inside it see the state of the last transaction committed before
+// it started, changes committed by concurrent write transactions aren't visible (and don't block it). Keeping a read
+// transaction open for long prevents the database from reusing the space of data changed meanwhile though, so prefer
+// shorter transactions for long-running scans, see ReadConsistency and Box.ForEachBatch().
 func (ob *ObjectBox) RunInReadTx(fn func() error) error {
 	return ob.runInTxn(true, fn)
 }
@@ -121,6 +231,10 @@ func (ob *ObjectBox) RunInWriteTx(fn func() error) error {
 }
 
 func (ob *ObjectBox) runInTxn(readOnly bool, fn func() error) (err error) {
+	if err := ob.check(); err != nil {
+		return err
+	}
+
 	// NOTE if runtime.LockOSThread() is about to be removed, evaluate use of createError() inside transactions
 	runtime.LockOSThread()
 
@@ -136,10 +250,28 @@ func (ob *ObjectBox) runInTxn(readOnly bool, fn func() error) (err error) {
 		runtime.UnlockOSThread()
 		return err
 	}
+	var countedReader = ob.txnOpened(readOnly)
+
+	// there can only be a single write transaction at a time (including the ones nested in it on the same thread) so
+	// while it's open, the depth belongs to it; it's back at zero once the outermost transaction is finished
+	var depthReleased bool
+	if !readOnly {
+		atomic.AddInt32(&ob.writeTxDepth, 1)
+		ob.auditTxStarted()
+	}
+	var releaseDepth = func() {
+		if !readOnly && !depthReleased {
+			depthReleased = true
+			atomic.AddInt32(&ob.writeTxDepth, -1)
+		}
+	}
+
+	var committed bool
 
 	// Defer to ensure a TX is ALWAYS closed, even in a panic
 	defer func() {
 		if cTxn != nil {
+			releaseDepth()
 			if rc := C.obx_txn_close(cTxn); rc != 0 {
 				if err == nil {
 					err = createError()
@@ -148,17 +280,26 @@ func (ob *ObjectBox) runInTxn(readOnly bool, fn func() error) (err error) {
 				}
 			}
 		}
+		ob.txnClosed(countedReader)
+		if !readOnly {
+			ob.auditTxFinished(committed)
+		}
 
 		runtime.UnlockOSThread()
 	}()
 
 	err = fn()
 
+	// release before the commit lets another write transaction start
+	releaseDepth()
+
 	if !readOnly && err == nil {
 		var ptr = cTxn
 		cTxn = nil
 		if rc := C.obx_txn_success(ptr); rc != 0 {
 			err = createError()
+		} else {
+			committed = true
 		}
 	}
 
@@ -186,6 +327,10 @@ func (ob *ObjectBox) getEntityByName(name string) *entity {
 // SetDebugFlags configures debug logging of the ObjectBox core.
 // See DebugFlags* constants
 func (ob *ObjectBox) SetDebugFlags(flags uint) error {
+	if err := ob.check(); err != nil {
+		return err
+	}
+
 	return cCall(func() C.obx_err {
 		return C.obx_store_debug_flags(ob.store, C.uint32_t(flags))
 	})
@@ -200,6 +345,104 @@ func (ob *ObjectBox) InternalBox(entityId TypeId) *Box {
 	return box
 }
 
+// AllBoxes returns boxes for all entity types registered in the model, ordered by the entity ID.
+// The returned boxes are the same instances as the ones returned by the generated BoxFor*() functions.
+// Use Box.EntityName() or Box.EntityId() to find out which entity a box represents.
+// Panics on error, like InternalBox().
+func (ob *ObjectBox) AllBoxes() []*Box {
+	var ids = make([]int, 0, len(ob.entitiesById))
+	for id := range ob.entitiesById {
+		ids = append(ids, int(id))
+	}
+	sort.Ints(ids)
+
+	var boxes = make([]*Box, len(ids))
+	for i, id := range ids {
+		boxes[i] = ob.InternalBox(TypeId(id))
+	}
+	return boxes
+}
+
+// PutWithOutbox puts an object and an outbox event (an object of another entity type) in a single write transaction,
+// i.e. either both are stored or none of them. This implements the "transactional outbox" pattern: publishing the
+// event to other systems is done by a separate consumer, which polls the outbox box (or observes it, see
+// Box.Subscribe()) and removes events once they have been delivered.
+//
+// Both arguments must be pointers to objects of entity types registered in the model; the ID properties of new
+// objects are assigned as with Box.Put().
+func (ob *ObjectBox) PutWithOutbox(object interface{}, event interface{}) error {
+	objectBox, err := ob.boxForObject(object)
+	if err != nil {
+		return err
+	}
+
+	eventBox, err := ob.boxForObject(event)
+	if err != nil {
+		return err
+	}
+
+	return ob.RunInWriteTx(func() error {
+		if _, err := objectBox.put(object, true, cPutModePut); err != nil {
+			return err
+		}
+		_, err := eventBox.put(event, true, cPutModePut)
+		return err
+	})
+}
+
+// MoveObject moves the object with the given ID from the src box to the dst box (of another entity type) in a single
+// write transaction, e.g. when a Draft becomes a Published entity: the object is read, mapped to the destination type
+// by transform, put to dst and removed from src. Returns the ID of the object in dst.
+//
+// The transform function receives the source object and must return a pointer to an object of the destination type.
+// To preserve the ID, set it on the returned object (or map it to another one); leave it 0 to assign a new ID.
+// If any of the steps fails, e.g. if transform returns nil or dst rejects the object, the transaction is rolled back:
+// the object stays in src and nothing is stored in dst. ErrNotFound is returned if there's no object with the ID.
+// Both boxes must belong to the same ObjectBox.
+func MoveObject(src *Box, dst *Box, id uint64, transform func(interface{}) interface{}) (newId uint64, err error) {
+	if src.ObjectBox != dst.ObjectBox {
+		return 0, errors.New("can't move objects between boxes of different stores in a single transaction")
+	} else if src == dst {
+		return 0, fmt.Errorf("can't move an object of entity %s to the same box", src.entity.name)
+	}
+
+	err = src.ObjectBox.RunInWriteTx(func() error {
+		object, err := src.Get(id)
+		if err != nil {
+			return err
+		} else if object == nil {
+			return ErrNotFound
+		}
+
+		var moved = transform(object)
+		if moved == nil {
+			return fmt.Errorf("the transform of %s %d returned nil", src.entity.name, id)
+		}
+
+		if newId, err = dst.put(moved, true, cPutModePut); err != nil {
+			return err
+		}
+		return src.RemoveId(id)
+	})
+
+	if err != nil {
+		return 0, err
+	}
+	return newId, nil
+}
+
+// boxForObject finds the Box for the given object based on its type, using the slice type created by the bindings.
+func (ob *ObjectBox) boxForObject(object interface{}) (*Box, error) {
+	var objectType = reflect.TypeOf(object)
+	for id, entity := range ob.entitiesById {
+		var elemType = reflect.TypeOf(entity.binding.MakeSlice(0)).Elem()
+		if elemType == objectType || reflect.PtrTo(elemType) == objectType {
+			return ob.box(id)
+		}
+	}
+	return nil, fmt.Errorf("no entity registered for objects of type %v", objectType)
+}
+
 // Gets an Entity Box which provides CRUD access to objects of the given type
 func (ob *ObjectBox) box(entityId TypeId) (*Box, error) {
 	ob.boxesMutex.Lock()
@@ -209,6 +452,10 @@ func (ob *ObjectBox) box(entityId TypeId) (*Box, error) {
 		return box, nil
 	}
 
+	if err := ob.check(); err != nil {
+		return nil, err
+	}
+
 	box, err := newBox(ob, entityId)
 	if err != nil {
 		return nil, err
@@ -220,6 +467,10 @@ func (ob *ObjectBox) box(entityId TypeId) (*Box, error) {
 
 // AwaitAsyncCompletion blocks until all PutAsync insert have been processed
 func (ob *ObjectBox) AwaitAsyncCompletion() error {
+	if err := ob.check(); err != nil {
+		return err
+	}
+
 	return cCallBool(func() bool {
 		return bool(C.obx_store_await_async_completion(ob.store))
 	})
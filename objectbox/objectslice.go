@@ -0,0 +1,86 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+import (
+	"reflect"
+)
+
+// objectSlice provides access to the objects of a slice given to PutMany() & co.
+// It uses the binding if it implements ObjectSliceBinding and falls back to reflection otherwise.
+type objectSlice struct {
+	slice   interface{}
+	binding ObjectSliceBinding // nil if reflection is used
+	value   reflect.Value
+}
+
+func (box *Box) objectSlice(slice interface{}) objectSlice {
+	if binding, ok := box.entity.binding.(ObjectSliceBinding); ok {
+		return objectSlice{slice: slice, binding: binding}
+	}
+	return objectSlice{slice: slice, value: reflect.ValueOf(slice)}
+}
+
+func (objects objectSlice) len() int {
+	if objects.slice == nil { // an untyped nil, e.g. PutMany(nil)
+		return 0
+	} else if objects.binding != nil {
+		return objects.binding.SliceLen(objects.slice)
+	}
+	return objects.value.Len()
+}
+
+func (objects objectSlice) index(i int) interface{} {
+	if objects.binding != nil {
+		return objects.binding.SliceObject(objects.slice, i)
+	}
+	return objects.value.Index(i).Interface()
+}
+
+// sliceBuilder collects the objects read by GetAll() & co. into the resulting slice.
+// It uses the binding if it implements SliceAppenderBinding and falls back to AppendToSlice() otherwise.
+type sliceBuilder struct {
+	binding ObjectBinding
+	slice   interface{}             // only used with AppendToSlice()
+	add     func(object interface{}) // nil if AppendToSlice() is used
+	result  func() interface{}
+}
+
+func (box *Box) newSliceBuilder(capacity int) *sliceBuilder {
+	var builder = &sliceBuilder{binding: box.entity.binding}
+	if appender, ok := builder.binding.(SliceAppenderBinding); ok {
+		builder.add, builder.result = appender.NewSliceAppender(capacity)
+	} else {
+		builder.slice = builder.binding.MakeSlice(capacity)
+	}
+	return builder
+}
+
+func (builder *sliceBuilder) append(object interface{}) {
+	if builder.add != nil {
+		builder.add(object)
+	} else {
+		builder.slice = builder.binding.AppendToSlice(builder.slice, object)
+	}
+}
+
+func (builder *sliceBuilder) get() interface{} {
+	if builder.result != nil {
+		return builder.result()
+	}
+	return builder.slice
+}
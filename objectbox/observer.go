@@ -0,0 +1,268 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include <stdlib.h>
+#include "objectbox.h"
+*/
+import "C"
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Observer is notified about committed changes of objects of a single entity type, see Box.Subscribe().
+type Observer struct {
+	objectBox  *ObjectBox
+	cObserver  *C.OBX_observer
+	callbackId cCallbackId
+	closeMutex sync.Mutex
+	onClose    func() // optional, called once the observer has been closed
+}
+
+// Subscribe registers a callback that is called after each successful commit of a transaction that changed
+// (put or removed) objects of the entity type this Box represents.
+//
+// The callback is called from an internal thread, one notification at a time. It should return quickly and
+// must not create or close observers. Close() the observer when it's no longer needed.
+func (box *Box) Subscribe(callback func()) (*Observer, error) {
+	if err := box.check(); err != nil {
+		return nil, err
+	}
+
+	var observer = &Observer{objectBox: box.ObjectBox}
+
+	var err error
+	if observer.callbackId, err = cCallbackRegister(cVoidCallback(callback)); err != nil {
+		return nil, err
+	}
+
+	if err = cCallBool(func() bool {
+		observer.cObserver = C.obx_observe_single_type(box.ObjectBox.store, C.obx_schema_id(box.entity.id),
+			(*C.obx_observer_single_type)(cVoidCallbackDispatchPtr), observer.callbackId.cPtr())
+		return observer.cObserver != nil
+	}); err != nil {
+		cCallbackUnregister(observer.callbackId)
+		return nil, err
+	}
+
+	box.ObjectBox.observersMutex.Lock()
+	box.ObjectBox.observers[observer] = struct{}{}
+	box.ObjectBox.observersMutex.Unlock()
+
+	return observer, nil
+}
+
+// TypeChange describes the changes of a single entity type in a committed transaction, see ObjectBox.SubscribeTx().
+//
+// Note: the native library only reports which types were changed, not the number of objects put or removed, so
+// there are no per-type counts; load the data (e.g. using a query) in case you need the details.
+type TypeChange struct {
+	TypeId TypeId
+}
+
+// SubscribeTx registers a callback that is called once after each successful commit of a transaction that changed
+// (put or removed) objects of any entity type, with an entry for each of the changed types. Contrary to subscribing
+// to each Box individually, a transaction changing multiple types is delivered in a single notification.
+//
+// The callback is called from an internal thread, one notification at a time. It should return quickly and
+// must not create or close observers. Close() the observer when it's no longer needed.
+func (ob *ObjectBox) SubscribeTx(callback func(changes []TypeChange)) (*Observer, error) {
+	if err := ob.check(); err != nil {
+		return nil, err
+	}
+
+	var observer = &Observer{objectBox: ob}
+
+	var err error
+	if observer.callbackId, err = cCallbackRegister(cVoidTypeIdsCallback(func(typeIds []TypeId) {
+		var changes = make([]TypeChange, len(typeIds))
+		for i, typeId := range typeIds {
+			changes[i].TypeId = typeId
+		}
+		callback(changes)
+	})); err != nil {
+		return nil, err
+	}
+
+	if err = cCallBool(func() bool {
+		observer.cObserver = C.obx_observe(ob.store, (*C.obx_observer)(cVoidTypeIdsCallbackDispatchPtr),
+			observer.callbackId.cPtr())
+		return observer.cObserver != nil
+	}); err != nil {
+		cCallbackUnregister(observer.callbackId)
+		return nil, err
+	}
+
+	ob.observersMutex.Lock()
+	ob.observers[observer] = struct{}{}
+	ob.observersMutex.Unlock()
+
+	return observer, nil
+}
+
+// SubscribeDebounced registers a callback like Box.Subscribe() for the entity type with the given ID but coalesces
+// rapid-fire changes: the callback is called once there were no further changes for the given window, e.g. 100ms,
+// so that a UI isn't refreshed for each of many small transactions during a bulk import. A callback is always
+// called after the last change (once the window has passed).
+//
+// The callback is called from a timer goroutine, one notification at a time. Close() the observer when it's no longer
+// needed; it cancels a pending notification and waits for a running callback to finish, i.e. the callback isn't
+// called anymore after Close() returns.
+func (ob *ObjectBox) SubscribeDebounced(typeId TypeId, window time.Duration, callback func()) (*Observer, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("invalid debounce window %v, must be positive", window)
+	}
+
+	if ob.entitiesById[typeId] == nil {
+		return nil, fmt.Errorf("unknown entity type ID %d", typeId)
+	}
+
+	box, err := ob.box(typeId)
+	if err != nil {
+		return nil, err
+	}
+
+	var debouncer = &debouncer{window: window, callback: callback}
+	observer, err := box.Subscribe(debouncer.trigger)
+	if err != nil {
+		return nil, err
+	}
+	observer.onClose = debouncer.stop
+	return observer, nil
+}
+
+// debouncer postpones the callback until no trigger() was issued for the whole window
+type debouncer struct {
+	window        time.Duration
+	callback      func()
+	mutex         sync.Mutex // guards timer & stopped
+	timer         *time.Timer
+	stopped       bool
+	callbackMutex sync.Mutex // held while the callback runs
+}
+
+func (debouncer *debouncer) trigger() {
+	debouncer.mutex.Lock()
+	defer debouncer.mutex.Unlock()
+
+	if debouncer.stopped {
+		return
+	}
+
+	if debouncer.timer == nil {
+		debouncer.timer = time.AfterFunc(debouncer.window, debouncer.fire)
+	} else {
+		// if the timer has just fired, this schedules another callback which is fine: there was another change
+		debouncer.timer.Reset(debouncer.window)
+	}
+}
+
+func (debouncer *debouncer) fire() {
+	debouncer.callbackMutex.Lock()
+	defer debouncer.callbackMutex.Unlock()
+
+	debouncer.mutex.Lock()
+	var stopped = debouncer.stopped
+	debouncer.mutex.Unlock()
+
+	if !stopped {
+		debouncer.callback()
+	}
+}
+
+// stop cancels the pending callback and waits for the running one to finish
+func (debouncer *debouncer) stop() {
+	debouncer.mutex.Lock()
+	debouncer.stopped = true
+	if debouncer.timer != nil {
+		debouncer.timer.Stop()
+	}
+	debouncer.mutex.Unlock()
+
+	debouncer.callbackMutex.Lock()
+	debouncer.callbackMutex.Unlock()
+}
+
+// Close unregisters the observer; the callback isn't called anymore after Close() returns.
+// Must not be called from inside an observer callback.
+func (observer *Observer) Close() error {
+	observer.closeMutex.Lock()
+	defer observer.closeMutex.Unlock()
+
+	if observer.cObserver == nil {
+		return nil
+	}
+
+	if err := cCall(func() C.obx_err {
+		return C.obx_observer_close(observer.cObserver)
+	}); err != nil {
+		return err
+	}
+
+	observer.cObserver = nil
+	cCallbackUnregister(observer.callbackId)
+
+	if observer.onClose != nil {
+		observer.onClose()
+	}
+
+	observer.objectBox.observersMutex.Lock()
+	delete(observer.objectBox.observers, observer)
+	observer.objectBox.observersMutex.Unlock()
+	return nil
+}
+
+// closed reports whether the observer has been closed, e.g. by ObjectBox.UnsubscribeAll()
+func (observer *Observer) closed() bool {
+	observer.closeMutex.Lock()
+	defer observer.closeMutex.Unlock()
+	return observer.cObserver == nil
+}
+
+// UnsubscribeAll closes all active observers created by Box.Subscribe(), including those used internally by
+// Query.Cached() and Box.CachedCount(): a CachedQuery then reads the data on each Find() and CachedCount()
+// subscribes again on its next call.
+// It's safe to call even if some of the observers have already been closed; it's also called by Close().
+// Must not be called from inside an observer callback.
+//
+// Returns the first error encountered; observers that failed to close stay registered.
+func (ob *ObjectBox) UnsubscribeAll() error {
+	ob.observersMutex.Lock()
+	var observers = make([]*Observer, 0, len(ob.observers))
+	for observer := range ob.observers {
+		observers = append(observers, observer)
+	}
+	ob.observersMutex.Unlock()
+
+	var err error
+	for _, observer := range observers {
+		if closeErr := observer.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// ObserverCount returns the number of active (not closed) observers, e.g. for diagnostics.
+func (ob *ObjectBox) ObserverCount() int {
+	ob.observersMutex.Lock()
+	defer ob.observersMutex.Unlock()
+	return len(ob.observers)
+}
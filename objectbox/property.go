@@ -41,6 +41,9 @@ func (property BaseProperty) IsNil() Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IsNil(&property)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IsNotNil(&property)
+		},
 	}
 }
 
@@ -50,6 +53,9 @@ func (property BaseProperty) IsNotNil() Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IsNotNil(&property)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IsNil(&property)
+		},
 	}
 }
 
@@ -103,6 +109,9 @@ func (property PropertyString) Equals(text string, caseSensitive bool) Condition
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.StringEquals(property.BaseProperty, text, caseSensitive)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.StringNotEquals(property.BaseProperty, text, caseSensitive)
+		},
 	}
 }
 
@@ -112,6 +121,9 @@ func (property PropertyString) NotEquals(text string, caseSensitive bool) Condit
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.StringNotEquals(property.BaseProperty, text, caseSensitive)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.StringEquals(property.BaseProperty, text, caseSensitive)
+		},
 	}
 }
 
@@ -133,6 +145,26 @@ func (property PropertyString) HasPrefix(text string, caseSensitive bool) Condit
 	}
 }
 
+// StartsWithAny finds entities with the stored property value starting with any of the given prefixes, e.g. to
+// categorize objects by a set of routing prefixes. An empty list of prefixes matches no objects.
+//
+// It's equivalent to Any() of HasPrefix() conditions, one for each prefix: the native library has no dedicated prefix
+// set condition so the prefixes are checked one by one. Evaluating the query therefore takes time proportional to
+// the number of prefixes times the number of (scanned) objects. For large prefix sets, consider storing the category
+// in a separate (indexed) property, or removing prefixes covered by shorter ones before calling this.
+func (property PropertyString) StartsWithAny(prefixes []string, caseSensitive bool) Condition {
+	if len(prefixes) == 0 {
+		// a contradiction; an empty Any() would match all objects
+		return All(property.BaseProperty.IsNil(), property.BaseProperty.IsNotNil())
+	}
+
+	var conditions = make([]Condition, len(prefixes))
+	for i, prefix := range prefixes {
+		conditions[i] = property.HasPrefix(prefix, caseSensitive)
+	}
+	return Any(conditions...)
+}
+
 // HasSuffix finds entities with the stored property value ends with the given text
 func (property PropertyString) HasSuffix(text string, caseSensitive bool) Condition {
 	return &conditionClosure{
@@ -148,6 +180,9 @@ func (property PropertyString) GreaterThan(text string, caseSensitive bool) Cond
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.StringGreater(property.BaseProperty, text, caseSensitive, false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.StringLess(property.BaseProperty, text, caseSensitive, true)
+		},
 	}
 }
 
@@ -157,6 +192,9 @@ func (property PropertyString) GreaterOrEqual(text string, caseSensitive bool) C
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.StringGreater(property.BaseProperty, text, caseSensitive, true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.StringLess(property.BaseProperty, text, caseSensitive, false)
+		},
 	}
 }
 
@@ -166,6 +204,9 @@ func (property PropertyString) LessThan(text string, caseSensitive bool) Conditi
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.StringLess(property.BaseProperty, text, caseSensitive, false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.StringGreater(property.BaseProperty, text, caseSensitive, true)
+		},
 	}
 }
 
@@ -175,6 +216,9 @@ func (property PropertyString) LessOrEqual(text string, caseSensitive bool) Cond
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.StringLess(property.BaseProperty, text, caseSensitive, true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.StringGreater(property.BaseProperty, text, caseSensitive, false)
+		},
 	}
 }
 
@@ -242,6 +286,9 @@ func (property PropertyInt64) Equals(value int64) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntEqual(property.BaseProperty, value)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotEqual(property.BaseProperty, value)
+		},
 	}
 }
 
@@ -251,6 +298,9 @@ func (property PropertyInt64) NotEquals(value int64) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntNotEqual(property.BaseProperty, value)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntEqual(property.BaseProperty, value)
+		},
 	}
 }
 
@@ -260,6 +310,9 @@ func (property PropertyInt64) GreaterThan(value int64) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntGreater(property.BaseProperty, value, false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntLess(property.BaseProperty, value, true)
+		},
 	}
 }
 
@@ -269,6 +322,9 @@ func (property PropertyInt64) GreaterOrEqual(value int64) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntGreater(property.BaseProperty, value, true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntLess(property.BaseProperty, value, false)
+		},
 	}
 }
 
@@ -278,6 +334,9 @@ func (property PropertyInt64) LessThan(value int64) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntLess(property.BaseProperty, value, false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntGreater(property.BaseProperty, value, true)
+		},
 	}
 }
 
@@ -287,6 +346,9 @@ func (property PropertyInt64) LessOrEqual(value int64) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntLess(property.BaseProperty, value, true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntGreater(property.BaseProperty, value, false)
+		},
 	}
 }
 
@@ -296,6 +358,9 @@ func (property PropertyInt64) Between(a, b int64) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntBetween(property.BaseProperty, a, b)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotBetween(property.BaseProperty, a, b)
+		},
 	}
 }
 
@@ -305,6 +370,9 @@ func (property PropertyInt64) In(values ...int64) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.Int64In(property.BaseProperty, values)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.Int64NotIn(property.BaseProperty, values)
+		},
 	}
 }
 
@@ -314,6 +382,9 @@ func (property PropertyInt64) NotIn(values ...int64) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.Int64NotIn(property.BaseProperty, values)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.Int64In(property.BaseProperty, values)
+		},
 	}
 }
 
@@ -348,6 +419,9 @@ func (property PropertyInt) Equals(value int) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntEqual(property.BaseProperty, int64(value))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotEqual(property.BaseProperty, int64(value))
+		},
 	}
 }
 
@@ -357,6 +431,9 @@ func (property PropertyInt) NotEquals(value int) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntNotEqual(property.BaseProperty, int64(value))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntEqual(property.BaseProperty, int64(value))
+		},
 	}
 }
 
@@ -366,6 +443,9 @@ func (property PropertyInt) GreaterThan(value int) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntGreater(property.BaseProperty, int64(value), false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntLess(property.BaseProperty, int64(value), true)
+		},
 	}
 }
 
@@ -375,6 +455,9 @@ func (property PropertyInt) GreaterOrEqual(value int) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntGreater(property.BaseProperty, int64(value), true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntLess(property.BaseProperty, int64(value), false)
+		},
 	}
 }
 
@@ -384,6 +467,9 @@ func (property PropertyInt) LessThan(value int) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntLess(property.BaseProperty, int64(value), false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntGreater(property.BaseProperty, int64(value), true)
+		},
 	}
 }
 
@@ -393,6 +479,9 @@ func (property PropertyInt) LessOrEqual(value int) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntLess(property.BaseProperty, int64(value), true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntGreater(property.BaseProperty, int64(value), false)
+		},
 	}
 }
 
@@ -402,6 +491,9 @@ func (property PropertyInt) Between(a, b int) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntBetween(property.BaseProperty, int64(a), int64(b))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotBetween(property.BaseProperty, int64(a), int64(b))
+		},
 	}
 }
 
@@ -421,6 +513,9 @@ func (property PropertyInt) In(values ...int) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.Int64In(property.BaseProperty, property.int64Slice(values))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.Int64NotIn(property.BaseProperty, property.int64Slice(values))
+		},
 	}
 }
 
@@ -430,6 +525,9 @@ func (property PropertyInt) NotIn(values ...int) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.Int64NotIn(property.BaseProperty, property.int64Slice(values))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.Int64In(property.BaseProperty, property.int64Slice(values))
+		},
 	}
 }
 
@@ -464,6 +562,9 @@ func (property PropertyUint64) Equals(value uint64) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntEqual(property.BaseProperty, int64(value))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotEqual(property.BaseProperty, int64(value))
+		},
 	}
 }
 
@@ -473,6 +574,9 @@ func (property PropertyUint64) NotEquals(value uint64) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntNotEqual(property.BaseProperty, int64(value))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntEqual(property.BaseProperty, int64(value))
+		},
 	}
 }
 
@@ -482,6 +586,9 @@ func (property PropertyUint64) GreaterThan(value uint64) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntGreater(property.BaseProperty, int64(value), false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntLess(property.BaseProperty, int64(value), true)
+		},
 	}
 }
 
@@ -491,6 +598,9 @@ func (property PropertyUint64) GreaterOrEqual(value uint64) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntGreater(property.BaseProperty, int64(value), true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntLess(property.BaseProperty, int64(value), false)
+		},
 	}
 }
 
@@ -500,6 +610,9 @@ func (property PropertyUint64) LessThan(value uint64) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntLess(property.BaseProperty, int64(value), false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntGreater(property.BaseProperty, int64(value), true)
+		},
 	}
 }
 
@@ -509,6 +622,9 @@ func (property PropertyUint64) LessOrEqual(value uint64) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntLess(property.BaseProperty, int64(value), true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntGreater(property.BaseProperty, int64(value), false)
+		},
 	}
 }
 
@@ -518,6 +634,9 @@ func (property PropertyUint64) Between(a, b uint64) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntBetween(property.BaseProperty, int64(a), int64(b))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotBetween(property.BaseProperty, int64(a), int64(b))
+		},
 	}
 }
 
@@ -537,6 +656,9 @@ func (property PropertyUint64) In(values ...uint64) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.Int64In(property.BaseProperty, property.int64Slice(values))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.Int64NotIn(property.BaseProperty, property.int64Slice(values))
+		},
 	}
 }
 
@@ -546,6 +668,9 @@ func (property PropertyUint64) NotIn(values ...uint64) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.Int64NotIn(property.BaseProperty, property.int64Slice(values))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.Int64In(property.BaseProperty, property.int64Slice(values))
+		},
 	}
 }
 
@@ -580,6 +705,9 @@ func (property PropertyUint) Equals(value uint) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntEqual(property.BaseProperty, int64(value))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotEqual(property.BaseProperty, int64(value))
+		},
 	}
 }
 
@@ -589,6 +717,9 @@ func (property PropertyUint) NotEquals(value uint) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntNotEqual(property.BaseProperty, int64(value))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntEqual(property.BaseProperty, int64(value))
+		},
 	}
 }
 
@@ -598,6 +729,9 @@ func (property PropertyUint) GreaterThan(value uint) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntGreater(property.BaseProperty, int64(value), false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntLess(property.BaseProperty, int64(value), true)
+		},
 	}
 }
 
@@ -607,6 +741,9 @@ func (property PropertyUint) GreaterOrEqual(value uint) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntGreater(property.BaseProperty, int64(value), true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntLess(property.BaseProperty, int64(value), false)
+		},
 	}
 }
 
@@ -616,6 +753,9 @@ func (property PropertyUint) LessThan(value uint) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntLess(property.BaseProperty, int64(value), false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntGreater(property.BaseProperty, int64(value), true)
+		},
 	}
 }
 
@@ -625,6 +765,9 @@ func (property PropertyUint) LessOrEqual(value uint) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntLess(property.BaseProperty, int64(value), true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntGreater(property.BaseProperty, int64(value), false)
+		},
 	}
 }
 
@@ -634,6 +777,9 @@ func (property PropertyUint) Between(a, b uint) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntBetween(property.BaseProperty, int64(a), int64(b))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotBetween(property.BaseProperty, int64(a), int64(b))
+		},
 	}
 }
 
@@ -653,6 +799,9 @@ func (property PropertyUint) In(values ...uint) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.Int64In(property.BaseProperty, property.int64Slice(values))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.Int64NotIn(property.BaseProperty, property.int64Slice(values))
+		},
 	}
 }
 
@@ -662,6 +811,9 @@ func (property PropertyUint) NotIn(values ...uint) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.Int64NotIn(property.BaseProperty, property.int64Slice(values))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.Int64In(property.BaseProperty, property.int64Slice(values))
+		},
 	}
 }
 
@@ -696,6 +848,9 @@ func (property PropertyRune) Equals(value rune) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntEqual(property.BaseProperty, int64(value))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotEqual(property.BaseProperty, int64(value))
+		},
 	}
 }
 
@@ -705,6 +860,9 @@ func (property PropertyRune) NotEquals(value rune) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntNotEqual(property.BaseProperty, int64(value))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntEqual(property.BaseProperty, int64(value))
+		},
 	}
 }
 
@@ -714,6 +872,9 @@ func (property PropertyRune) GreaterThan(value rune) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntGreater(property.BaseProperty, int64(value), false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntLess(property.BaseProperty, int64(value), true)
+		},
 	}
 }
 
@@ -723,6 +884,9 @@ func (property PropertyRune) GreaterOrEqual(value rune) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntGreater(property.BaseProperty, int64(value), true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntLess(property.BaseProperty, int64(value), false)
+		},
 	}
 }
 
@@ -732,6 +896,9 @@ func (property PropertyRune) LessThan(value rune) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntLess(property.BaseProperty, int64(value), false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntGreater(property.BaseProperty, int64(value), true)
+		},
 	}
 }
 
@@ -741,6 +908,9 @@ func (property PropertyRune) LessOrEqual(value rune) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntLess(property.BaseProperty, int64(value), true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntGreater(property.BaseProperty, int64(value), false)
+		},
 	}
 }
 
@@ -750,6 +920,9 @@ func (property PropertyRune) Between(a, b rune) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntBetween(property.BaseProperty, int64(a), int64(b))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotBetween(property.BaseProperty, int64(a), int64(b))
+		},
 	}
 }
 
@@ -769,6 +942,9 @@ func (property PropertyRune) In(values ...rune) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.Int32In(property.BaseProperty, property.int32Slice(values))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.Int32NotIn(property.BaseProperty, property.int32Slice(values))
+		},
 	}
 }
 
@@ -778,6 +954,9 @@ func (property PropertyRune) NotIn(values ...rune) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.Int32NotIn(property.BaseProperty, property.int32Slice(values))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.Int32In(property.BaseProperty, property.int32Slice(values))
+		},
 	}
 }
 
@@ -812,6 +991,9 @@ func (property PropertyInt32) Equals(value int32) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntEqual(property.BaseProperty, int64(value))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotEqual(property.BaseProperty, int64(value))
+		},
 	}
 }
 
@@ -821,6 +1003,9 @@ func (property PropertyInt32) NotEquals(value int32) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntNotEqual(property.BaseProperty, int64(value))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntEqual(property.BaseProperty, int64(value))
+		},
 	}
 }
 
@@ -830,6 +1015,9 @@ func (property PropertyInt32) GreaterThan(value int32) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntGreater(property.BaseProperty, int64(value), false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntLess(property.BaseProperty, int64(value), true)
+		},
 	}
 }
 
@@ -839,6 +1027,9 @@ func (property PropertyInt32) GreaterOrEqual(value int32) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntGreater(property.BaseProperty, int64(value), true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntLess(property.BaseProperty, int64(value), false)
+		},
 	}
 }
 
@@ -848,6 +1039,9 @@ func (property PropertyInt32) LessThan(value int32) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntLess(property.BaseProperty, int64(value), false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntGreater(property.BaseProperty, int64(value), true)
+		},
 	}
 }
 
@@ -857,6 +1051,9 @@ func (property PropertyInt32) LessOrEqual(value int32) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntLess(property.BaseProperty, int64(value), true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntGreater(property.BaseProperty, int64(value), false)
+		},
 	}
 }
 
@@ -866,6 +1063,9 @@ func (property PropertyInt32) Between(a, b int32) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntBetween(property.BaseProperty, int64(a), int64(b))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotBetween(property.BaseProperty, int64(a), int64(b))
+		},
 	}
 }
 
@@ -875,6 +1075,9 @@ func (property PropertyInt32) In(values ...int32) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.Int32In(property.BaseProperty, values)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.Int32NotIn(property.BaseProperty, values)
+		},
 	}
 }
 
@@ -884,6 +1087,9 @@ func (property PropertyInt32) NotIn(values ...int32) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.Int32NotIn(property.BaseProperty, values)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.Int32In(property.BaseProperty, values)
+		},
 	}
 }
 
@@ -918,6 +1124,9 @@ func (property PropertyUint32) Equals(value uint32) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntEqual(property.BaseProperty, int64(value))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotEqual(property.BaseProperty, int64(value))
+		},
 	}
 }
 
@@ -927,6 +1136,9 @@ func (property PropertyUint32) NotEquals(value uint32) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntNotEqual(property.BaseProperty, int64(value))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntEqual(property.BaseProperty, int64(value))
+		},
 	}
 }
 
@@ -936,6 +1148,9 @@ func (property PropertyUint32) GreaterThan(value uint32) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntGreater(property.BaseProperty, int64(value), false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntLess(property.BaseProperty, int64(value), true)
+		},
 	}
 }
 
@@ -945,6 +1160,9 @@ func (property PropertyUint32) GreaterOrEqual(value uint32) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntGreater(property.BaseProperty, int64(value), true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntLess(property.BaseProperty, int64(value), false)
+		},
 	}
 }
 
@@ -954,6 +1172,9 @@ func (property PropertyUint32) LessThan(value uint32) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntLess(property.BaseProperty, int64(value), false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntGreater(property.BaseProperty, int64(value), true)
+		},
 	}
 }
 
@@ -963,6 +1184,9 @@ func (property PropertyUint32) LessOrEqual(value uint32) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntLess(property.BaseProperty, int64(value), true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntGreater(property.BaseProperty, int64(value), false)
+		},
 	}
 }
 
@@ -972,6 +1196,9 @@ func (property PropertyUint32) Between(a, b uint32) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntBetween(property.BaseProperty, int64(a), int64(b))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotBetween(property.BaseProperty, int64(a), int64(b))
+		},
 	}
 }
 
@@ -991,6 +1218,9 @@ func (property PropertyUint32) In(values ...uint32) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.Int32In(property.BaseProperty, property.int32Slice(values))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.Int32NotIn(property.BaseProperty, property.int32Slice(values))
+		},
 	}
 }
 
@@ -1000,6 +1230,9 @@ func (property PropertyUint32) NotIn(values ...uint32) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.Int32NotIn(property.BaseProperty, property.int32Slice(values))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.Int32In(property.BaseProperty, property.int32Slice(values))
+		},
 	}
 }
 
@@ -1034,6 +1267,9 @@ func (property PropertyInt16) Equals(value int16) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntEqual(property.BaseProperty, int64(value))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotEqual(property.BaseProperty, int64(value))
+		},
 	}
 }
 
@@ -1043,6 +1279,9 @@ func (property PropertyInt16) NotEquals(value int16) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntNotEqual(property.BaseProperty, int64(value))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntEqual(property.BaseProperty, int64(value))
+		},
 	}
 }
 
@@ -1052,6 +1291,9 @@ func (property PropertyInt16) GreaterThan(value int16) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntGreater(property.BaseProperty, int64(value), false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntLess(property.BaseProperty, int64(value), true)
+		},
 	}
 }
 
@@ -1061,6 +1303,9 @@ func (property PropertyInt16) GreaterOrEqual(value int16) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntGreater(property.BaseProperty, int64(value), true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntLess(property.BaseProperty, int64(value), false)
+		},
 	}
 }
 
@@ -1070,6 +1315,9 @@ func (property PropertyInt16) LessThan(value int16) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntLess(property.BaseProperty, int64(value), false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntGreater(property.BaseProperty, int64(value), true)
+		},
 	}
 }
 
@@ -1079,6 +1327,9 @@ func (property PropertyInt16) LessOrEqual(value int16) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntLess(property.BaseProperty, int64(value), true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntGreater(property.BaseProperty, int64(value), false)
+		},
 	}
 }
 
@@ -1088,6 +1339,9 @@ func (property PropertyInt16) Between(a, b int16) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntBetween(property.BaseProperty, int64(a), int64(b))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotBetween(property.BaseProperty, int64(a), int64(b))
+		},
 	}
 }
 
@@ -1122,6 +1376,9 @@ func (property PropertyUint16) Equals(value uint16) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntEqual(property.BaseProperty, int64(value))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotEqual(property.BaseProperty, int64(value))
+		},
 	}
 }
 
@@ -1131,6 +1388,9 @@ func (property PropertyUint16) NotEquals(value uint16) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntNotEqual(property.BaseProperty, int64(value))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntEqual(property.BaseProperty, int64(value))
+		},
 	}
 }
 
@@ -1140,6 +1400,9 @@ func (property PropertyUint16) GreaterThan(value uint16) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntGreater(property.BaseProperty, int64(value), false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntLess(property.BaseProperty, int64(value), true)
+		},
 	}
 }
 
@@ -1149,6 +1412,9 @@ func (property PropertyUint16) GreaterOrEqual(value uint16) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntGreater(property.BaseProperty, int64(value), true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntLess(property.BaseProperty, int64(value), false)
+		},
 	}
 }
 
@@ -1158,6 +1424,9 @@ func (property PropertyUint16) LessThan(value uint16) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntLess(property.BaseProperty, int64(value), false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntGreater(property.BaseProperty, int64(value), true)
+		},
 	}
 }
 
@@ -1167,6 +1436,9 @@ func (property PropertyUint16) LessOrEqual(value uint16) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntLess(property.BaseProperty, int64(value), true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntGreater(property.BaseProperty, int64(value), false)
+		},
 	}
 }
 
@@ -1176,6 +1448,9 @@ func (property PropertyUint16) Between(a, b uint16) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntBetween(property.BaseProperty, int64(a), int64(b))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotBetween(property.BaseProperty, int64(a), int64(b))
+		},
 	}
 }
 
@@ -1210,6 +1485,9 @@ func (property PropertyInt8) Equals(value int8) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntEqual(property.BaseProperty, int64(value))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotEqual(property.BaseProperty, int64(value))
+		},
 	}
 }
 
@@ -1219,6 +1497,9 @@ func (property PropertyInt8) NotEquals(value int8) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntNotEqual(property.BaseProperty, int64(value))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntEqual(property.BaseProperty, int64(value))
+		},
 	}
 }
 
@@ -1228,6 +1509,9 @@ func (property PropertyInt8) GreaterThan(value int8) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntGreater(property.BaseProperty, int64(value), false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntLess(property.BaseProperty, int64(value), true)
+		},
 	}
 }
 
@@ -1237,6 +1521,9 @@ func (property PropertyInt8) GreaterOrEqual(value int8) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntGreater(property.BaseProperty, int64(value), true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntLess(property.BaseProperty, int64(value), false)
+		},
 	}
 }
 
@@ -1246,6 +1533,9 @@ func (property PropertyInt8) LessThan(value int8) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntLess(property.BaseProperty, int64(value), false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntGreater(property.BaseProperty, int64(value), true)
+		},
 	}
 }
 
@@ -1255,6 +1545,9 @@ func (property PropertyInt8) LessOrEqual(value int8) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntLess(property.BaseProperty, int64(value), true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntGreater(property.BaseProperty, int64(value), false)
+		},
 	}
 }
 
@@ -1264,6 +1557,9 @@ func (property PropertyInt8) Between(a, b int8) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntBetween(property.BaseProperty, int64(a), int64(b))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotBetween(property.BaseProperty, int64(a), int64(b))
+		},
 	}
 }
 
@@ -1298,6 +1594,9 @@ func (property PropertyUint8) Equals(value uint8) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntEqual(property.BaseProperty, int64(value))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotEqual(property.BaseProperty, int64(value))
+		},
 	}
 }
 
@@ -1307,6 +1606,9 @@ func (property PropertyUint8) NotEquals(value uint8) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntNotEqual(property.BaseProperty, int64(value))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntEqual(property.BaseProperty, int64(value))
+		},
 	}
 }
 
@@ -1316,6 +1618,9 @@ func (property PropertyUint8) GreaterThan(value uint8) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntGreater(property.BaseProperty, int64(value), false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntLess(property.BaseProperty, int64(value), true)
+		},
 	}
 }
 
@@ -1325,6 +1630,9 @@ func (property PropertyUint8) GreaterOrEqual(value uint8) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntGreater(property.BaseProperty, int64(value), true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntLess(property.BaseProperty, int64(value), false)
+		},
 	}
 }
 
@@ -1334,6 +1642,9 @@ func (property PropertyUint8) LessThan(value uint8) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntLess(property.BaseProperty, int64(value), false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntGreater(property.BaseProperty, int64(value), true)
+		},
 	}
 }
 
@@ -1343,6 +1654,9 @@ func (property PropertyUint8) LessOrEqual(value uint8) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntLess(property.BaseProperty, int64(value), true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntGreater(property.BaseProperty, int64(value), false)
+		},
 	}
 }
 
@@ -1352,6 +1666,9 @@ func (property PropertyUint8) Between(a, b uint8) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntBetween(property.BaseProperty, int64(a), int64(b))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotBetween(property.BaseProperty, int64(a), int64(b))
+		},
 	}
 }
 
@@ -1386,6 +1703,9 @@ func (property PropertyByte) Equals(value byte) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntEqual(property.BaseProperty, int64(value))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotEqual(property.BaseProperty, int64(value))
+		},
 	}
 }
 
@@ -1395,6 +1715,9 @@ func (property PropertyByte) NotEquals(value byte) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntNotEqual(property.BaseProperty, int64(value))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntEqual(property.BaseProperty, int64(value))
+		},
 	}
 }
 
@@ -1404,6 +1727,9 @@ func (property PropertyByte) GreaterThan(value byte) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntGreater(property.BaseProperty, int64(value), false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntLess(property.BaseProperty, int64(value), true)
+		},
 	}
 }
 
@@ -1413,6 +1739,9 @@ func (property PropertyByte) GreaterOrEqual(value byte) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntGreater(property.BaseProperty, int64(value), true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntLess(property.BaseProperty, int64(value), false)
+		},
 	}
 }
 
@@ -1422,6 +1751,9 @@ func (property PropertyByte) LessThan(value byte) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntLess(property.BaseProperty, int64(value), false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntGreater(property.BaseProperty, int64(value), true)
+		},
 	}
 }
 
@@ -1431,6 +1763,9 @@ func (property PropertyByte) LessOrEqual(value byte) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntLess(property.BaseProperty, int64(value), true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntGreater(property.BaseProperty, int64(value), false)
+		},
 	}
 }
 
@@ -1440,6 +1775,9 @@ func (property PropertyByte) Between(a, b byte) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntBetween(property.BaseProperty, int64(a), int64(b))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotBetween(property.BaseProperty, int64(a), int64(b))
+		},
 	}
 }
 
@@ -1474,6 +1812,9 @@ func (property PropertyFloat64) GreaterThan(value float64) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.DoubleGreater(property.BaseProperty, value, false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.DoubleLess(property.BaseProperty, value, true)
+		},
 	}
 }
 
@@ -1483,6 +1824,9 @@ func (property PropertyFloat64) GreaterOrEqual(value float64) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.DoubleGreater(property.BaseProperty, value, true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.DoubleLess(property.BaseProperty, value, false)
+		},
 	}
 }
 
@@ -1492,6 +1836,9 @@ func (property PropertyFloat64) LessThan(value float64) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.DoubleLess(property.BaseProperty, value, false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.DoubleGreater(property.BaseProperty, value, true)
+		},
 	}
 }
 
@@ -1501,6 +1848,9 @@ func (property PropertyFloat64) LessOrEqual(value float64) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.DoubleLess(property.BaseProperty, value, true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.DoubleGreater(property.BaseProperty, value, false)
+		},
 	}
 }
 
@@ -1510,6 +1860,9 @@ func (property PropertyFloat64) Between(a, b float64) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.DoubleBetween(property.BaseProperty, a, b)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.DoubleNotBetween(property.BaseProperty, a, b)
+		},
 	}
 }
 
@@ -1544,6 +1897,9 @@ func (property PropertyFloat32) GreaterThan(value float32) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.DoubleGreater(property.BaseProperty, float64(value), false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.DoubleLess(property.BaseProperty, float64(value), true)
+		},
 	}
 }
 
@@ -1553,6 +1909,9 @@ func (property PropertyFloat32) GreaterOrEqual(value float32) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.DoubleGreater(property.BaseProperty, float64(value), true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.DoubleLess(property.BaseProperty, float64(value), false)
+		},
 	}
 }
 
@@ -1562,6 +1921,9 @@ func (property PropertyFloat32) LessThan(value float32) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.DoubleLess(property.BaseProperty, float64(value), false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.DoubleGreater(property.BaseProperty, float64(value), true)
+		},
 	}
 }
 
@@ -1571,6 +1933,9 @@ func (property PropertyFloat32) LessOrEqual(value float32) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.DoubleLess(property.BaseProperty, float64(value), true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.DoubleGreater(property.BaseProperty, float64(value), false)
+		},
 	}
 }
 
@@ -1580,6 +1945,9 @@ func (property PropertyFloat32) Between(a, b float32) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.DoubleBetween(property.BaseProperty, float64(a), float64(b))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.DoubleNotBetween(property.BaseProperty, float64(a), float64(b))
+		},
 	}
 }
 
@@ -1623,6 +1991,9 @@ func (property PropertyByteVector) GreaterThan(value []byte) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.BytesGreater(property.BaseProperty, value, false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.BytesLess(property.BaseProperty, value, true)
+		},
 	}
 }
 
@@ -1632,6 +2003,9 @@ func (property PropertyByteVector) GreaterOrEqual(value []byte) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.BytesGreater(property.BaseProperty, value, true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.BytesLess(property.BaseProperty, value, false)
+		},
 	}
 }
 
@@ -1641,6 +2015,9 @@ func (property PropertyByteVector) LessThan(value []byte) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.BytesLess(property.BaseProperty, value, false)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.BytesGreater(property.BaseProperty, value, true)
+		},
 	}
 }
 
@@ -1650,6 +2027,9 @@ func (property PropertyByteVector) LessOrEqual(value []byte) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.BytesLess(property.BaseProperty, value, true)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.BytesGreater(property.BaseProperty, value, false)
+		},
 	}
 }
 
@@ -1667,9 +2047,20 @@ func (property PropertyBool) Equals(value bool) Condition {
 			}
 			return qb.IntEqual(property.BaseProperty, 0)
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			if value {
+				return qb.IntNotEqual(property.BaseProperty, 1)
+			}
+			return qb.IntNotEqual(property.BaseProperty, 0)
+		},
 	}
 }
 
+// NotEquals finds entities with the stored property value different than the given value
+func (property PropertyBool) NotEquals(value bool) Condition {
+	return property.Equals(!value)
+}
+
 // OrderAsc sets ascending order based on this property
 func (property PropertyBool) OrderAsc() Condition {
 	return property.orderAsc()
@@ -35,6 +35,11 @@ type PropertyQuery struct {
 	query      *Query
 }
 
+// check returns an error if the store has been closed
+func (pq *PropertyQuery) check() error {
+	return pq.query.objectBox.check()
+}
+
 func newPropertyQuery(query *Query, propertyId TypeId) (*PropertyQuery, error) {
 	var pq = &PropertyQuery{query: query}
 
@@ -78,6 +83,10 @@ func propQueryFinalizer(pq *PropertyQuery) {
 // Distinct configures the property query to work only on distinct values.
 // Note: not all methods support distinct, those that don't will return an error.
 func (pq *PropertyQuery) Distinct(value bool) error {
+	if err := pq.check(); err != nil {
+		return err
+	}
+
 	return cCall(func() C.obx_err {
 		return C.obx_query_prop_distinct(pq.cPropQuery, C.bool(value))
 	})
@@ -86,6 +95,10 @@ func (pq *PropertyQuery) Distinct(value bool) error {
 // DistinctString configures the property query to work only on distinct values.
 // Note: not all methods support distinct, those that don't will return an error.
 func (pq *PropertyQuery) DistinctString(value, caseSensitive bool) error {
+	if err := pq.check(); err != nil {
+		return err
+	}
+
 	return cCall(func() C.obx_err {
 		return C.obx_query_prop_distinct_case(pq.cPropQuery, C.bool(value), C.bool(caseSensitive))
 	})
@@ -93,6 +106,10 @@ func (pq *PropertyQuery) DistinctString(value, caseSensitive bool) error {
 
 // Count returns a number of non-NULL values of the given property across all objects matching the query.
 func (pq *PropertyQuery) Count() (uint64, error) {
+	if err := pq.check(); err != nil {
+		return 0, err
+	}
+
 	var cResult C.uint64_t
 	if err := cCall(func() C.obx_err { return C.obx_query_prop_count(pq.cPropQuery, &cResult) }); err != nil {
 		return 0, err
@@ -102,6 +119,10 @@ func (pq *PropertyQuery) Count() (uint64, error) {
 
 // Average returns an average value for the given numeric property across all objects matching the query.
 func (pq *PropertyQuery) Average() (float64, error) {
+	if err := pq.check(); err != nil {
+		return 0, err
+	}
+
 	var cResult C.double
 	var cCount C.int64_t
 	if err := cCall(func() C.obx_err { return C.obx_query_prop_avg(pq.cPropQuery, &cResult, &cCount) }); err != nil {
@@ -112,6 +133,10 @@ func (pq *PropertyQuery) Average() (float64, error) {
 
 // MinFloat64 finds the minimum value of the given floating-point property across all objects matching the query.
 func (pq *PropertyQuery) MinFloat64() (float64, error) {
+	if err := pq.check(); err != nil {
+		return 0, err
+	}
+
 	var cResult C.double
 	if err := cCall(func() C.obx_err { return C.obx_query_prop_min(pq.cPropQuery, &cResult, nil) }); err != nil {
 		return 0, err
@@ -121,6 +146,10 @@ func (pq *PropertyQuery) MinFloat64() (float64, error) {
 
 // MaxFloat64 finds the maximum value of the given floating-point property across all objects matching the query.
 func (pq *PropertyQuery) MaxFloat64() (float64, error) {
+	if err := pq.check(); err != nil {
+		return 0, err
+	}
+
 	var cResult C.double
 	if err := cCall(func() C.obx_err { return C.obx_query_prop_max(pq.cPropQuery, &cResult, nil) }); err != nil {
 		return 0, err
@@ -130,6 +159,10 @@ func (pq *PropertyQuery) MaxFloat64() (float64, error) {
 
 // SumFloat64 calculates the sum of the given floating-point property across all objects matching the query.
 func (pq *PropertyQuery) SumFloat64() (float64, error) {
+	if err := pq.check(); err != nil {
+		return 0, err
+	}
+
 	var cResult C.double
 	if err := cCall(func() C.obx_err { return C.obx_query_prop_sum(pq.cPropQuery, &cResult, nil) }); err != nil {
 		return 0, err
@@ -139,6 +172,10 @@ func (pq *PropertyQuery) SumFloat64() (float64, error) {
 
 // Min finds the minimum value of the given property across all objects matching the query.
 func (pq *PropertyQuery) Min() (int64, error) {
+	if err := pq.check(); err != nil {
+		return 0, err
+	}
+
 	var cResult C.int64_t
 	if err := cCall(func() C.obx_err { return C.obx_query_prop_min_int(pq.cPropQuery, &cResult, nil) }); err != nil {
 		return 0, err
@@ -148,6 +185,10 @@ func (pq *PropertyQuery) Min() (int64, error) {
 
 // Max finds the maximum value of the given property across all objects matching the query.
 func (pq *PropertyQuery) Max() (int64, error) {
+	if err := pq.check(); err != nil {
+		return 0, err
+	}
+
 	var cResult C.int64_t
 	if err := cCall(func() C.obx_err { return C.obx_query_prop_max_int(pq.cPropQuery, &cResult, nil) }); err != nil {
 		return 0, err
@@ -157,6 +198,10 @@ func (pq *PropertyQuery) Max() (int64, error) {
 
 // Sum calculates the sum of the given property across all objects matching the query.
 func (pq *PropertyQuery) Sum() (int64, error) {
+	if err := pq.check(); err != nil {
+		return 0, err
+	}
+
 	var cResult C.int64_t
 	if err := cCall(func() C.obx_err { return C.obx_query_prop_sum_int(pq.cPropQuery, &cResult, nil) }); err != nil {
 		return 0, err
@@ -168,6 +213,10 @@ func (pq *PropertyQuery) Sum() (int64, error) {
 // Parameter valueIfNil - value that should be returned instead of NULL values on object fields.
 // If `valueIfNil = nil` is given, objects with NULL values of the specified field are skipped.
 func (pq *PropertyQuery) FindInts(valueIfNil *int) ([]int, error) {
+	if err := pq.check(); err != nil {
+		return nil, err
+	}
+
 	return cGetInts(func() *C.OBX_int64_array {
 		if valueIfNil == nil {
 			return C.obx_query_prop_find_int64s(pq.cPropQuery, nil)
@@ -182,6 +231,10 @@ func (pq *PropertyQuery) FindInts(valueIfNil *int) ([]int, error) {
 // Parameter valueIfNil - value that should be returned instead of NULL values on object fields.
 // If `valueIfNil = nil` is given, objects with NULL values of the specified field are skipped.
 func (pq *PropertyQuery) FindUints(valueIfNil *uint) ([]uint, error) {
+	if err := pq.check(); err != nil {
+		return nil, err
+	}
+
 	return cGetUints(func() *C.OBX_int64_array {
 		if valueIfNil == nil {
 			return C.obx_query_prop_find_int64s(pq.cPropQuery, nil)
@@ -196,6 +249,10 @@ func (pq *PropertyQuery) FindUints(valueIfNil *uint) ([]uint, error) {
 // Parameter valueIfNil - value that should be returned instead of NULL values on object fields.
 // If `valueIfNil = nil` is given, objects with NULL values of the specified field are skipped.
 func (pq *PropertyQuery) FindInt64s(valueIfNil *int64) ([]int64, error) {
+	if err := pq.check(); err != nil {
+		return nil, err
+	}
+
 	return cGetInt64s(func() *C.OBX_int64_array {
 		return C.obx_query_prop_find_int64s(pq.cPropQuery, (*C.int64_t)(valueIfNil))
 	})
@@ -205,6 +262,10 @@ func (pq *PropertyQuery) FindInt64s(valueIfNil *int64) ([]int64, error) {
 // Parameter valueIfNil - value that should be returned instead of NULL values on object fields.
 // If `valueIfNil = nil` is given, objects with NULL values of the specified field are skipped.
 func (pq *PropertyQuery) FindUint64s(valueIfNil *uint64) ([]uint64, error) {
+	if err := pq.check(); err != nil {
+		return nil, err
+	}
+
 	return cGetUint64s(func() *C.OBX_int64_array {
 		if valueIfNil == nil {
 			return C.obx_query_prop_find_int64s(pq.cPropQuery, nil)
@@ -219,6 +280,10 @@ func (pq *PropertyQuery) FindUint64s(valueIfNil *uint64) ([]uint64, error) {
 // Parameter valueIfNil - value that should be returned instead of NULL values on object fields.
 // If `valueIfNil = nil` is given, objects with NULL values of the specified field are skipped.
 func (pq *PropertyQuery) FindInt32s(valueIfNil *int32) ([]int32, error) {
+	if err := pq.check(); err != nil {
+		return nil, err
+	}
+
 	return cGetInt32s(func() *C.OBX_int32_array {
 		return C.obx_query_prop_find_int32s(pq.cPropQuery, (*C.int32_t)(valueIfNil))
 	})
@@ -228,6 +293,10 @@ func (pq *PropertyQuery) FindInt32s(valueIfNil *int32) ([]int32, error) {
 // Parameter valueIfNil - value that should be returned instead of NULL values on object fields.
 // If `valueIfNil = nil` is given, objects with NULL values of the specified field are skipped.
 func (pq *PropertyQuery) FindUint32s(valueIfNil *uint32) ([]uint32, error) {
+	if err := pq.check(); err != nil {
+		return nil, err
+	}
+
 	return cGetUint32s(func() *C.OBX_int32_array {
 		if valueIfNil == nil {
 			return C.obx_query_prop_find_int32s(pq.cPropQuery, nil)
@@ -242,6 +311,10 @@ func (pq *PropertyQuery) FindUint32s(valueIfNil *uint32) ([]uint32, error) {
 // Parameter valueIfNil - value that should be returned instead of NULL values on object fields.
 // If `valueIfNil = nil` is given, objects with NULL values of the specified field are skipped.
 func (pq *PropertyQuery) FindInt16s(valueIfNil *int16) ([]int16, error) {
+	if err := pq.check(); err != nil {
+		return nil, err
+	}
+
 	return cGetInt16s(func() *C.OBX_int16_array {
 		return C.obx_query_prop_find_int16s(pq.cPropQuery, (*C.int16_t)(valueIfNil))
 	})
@@ -251,6 +324,10 @@ func (pq *PropertyQuery) FindInt16s(valueIfNil *int16) ([]int16, error) {
 // Parameter valueIfNil - value that should be returned instead of NULL values on object fields.
 // If `valueIfNil = nil` is given, objects with NULL values of the specified field are skipped.
 func (pq *PropertyQuery) FindUint16s(valueIfNil *uint16) ([]uint16, error) {
+	if err := pq.check(); err != nil {
+		return nil, err
+	}
+
 	return cGetUint16s(func() *C.OBX_int16_array {
 		if valueIfNil == nil {
 			return C.obx_query_prop_find_int16s(pq.cPropQuery, nil)
@@ -265,6 +342,10 @@ func (pq *PropertyQuery) FindUint16s(valueIfNil *uint16) ([]uint16, error) {
 // Parameter valueIfNil - value that should be returned instead of NULL values on object fields.
 // If `valueIfNil = nil` is given, objects with NULL values of the specified field are skipped.
 func (pq *PropertyQuery) FindInt8s(valueIfNil *int8) ([]int8, error) {
+	if err := pq.check(); err != nil {
+		return nil, err
+	}
+
 	return cGetInt8s(func() *C.OBX_int8_array {
 		return C.obx_query_prop_find_int8s(pq.cPropQuery, (*C.int8_t)(valueIfNil))
 	})
@@ -274,6 +355,10 @@ func (pq *PropertyQuery) FindInt8s(valueIfNil *int8) ([]int8, error) {
 // Parameter valueIfNil - value that should be returned instead of NULL values on object fields.
 // If `valueIfNil = nil` is given, objects with NULL values of the specified field are skipped.
 func (pq *PropertyQuery) FindUint8s(valueIfNil *uint8) ([]uint8, error) {
+	if err := pq.check(); err != nil {
+		return nil, err
+	}
+
 	return cGetUint8s(func() *C.OBX_int8_array {
 		if valueIfNil == nil {
 			return C.obx_query_prop_find_int8s(pq.cPropQuery, nil)
@@ -288,6 +373,10 @@ func (pq *PropertyQuery) FindUint8s(valueIfNil *uint8) ([]uint8, error) {
 // Parameter valueIfNil - value that should be returned instead of NULL values on object fields.
 // If `valueIfNil = nil` is given, objects with NULL values of the specified field are skipped.
 func (pq *PropertyQuery) FindFloat64s(valueIfNil *float64) ([]float64, error) {
+	if err := pq.check(); err != nil {
+		return nil, err
+	}
+
 	return cGetFloat64s(func() *C.OBX_double_array {
 		return C.obx_query_prop_find_doubles(pq.cPropQuery, (*C.double)(valueIfNil))
 	})
@@ -297,6 +386,10 @@ func (pq *PropertyQuery) FindFloat64s(valueIfNil *float64) ([]float64, error) {
 // Parameter valueIfNil - value that should be returned instead of NULL values on object fields.
 // If `valueIfNil = nil` is given, objects with NULL values of the specified field are skipped.
 func (pq *PropertyQuery) FindFloat32s(valueIfNil *float32) ([]float32, error) {
+	if err := pq.check(); err != nil {
+		return nil, err
+	}
+
 	return cGetFloat32s(func() *C.OBX_float_array {
 		return C.obx_query_prop_find_floats(pq.cPropQuery, (*C.float)(valueIfNil))
 	})
@@ -306,6 +399,10 @@ func (pq *PropertyQuery) FindFloat32s(valueIfNil *float32) ([]float32, error) {
 // Parameter valueIfNil - value that should be returned instead of NULL values on object fields.
 // If `valueIfNil = nil` is given, objects with NULL values of the specified field are skipped.
 func (pq *PropertyQuery) FindBools(valueIfNil *bool) ([]bool, error) {
+	if err := pq.check(); err != nil {
+		return nil, err
+	}
+
 	return cGetBools(func() *C.OBX_int8_array {
 		if valueIfNil == nil {
 			return C.obx_query_prop_find_int8s(pq.cPropQuery, nil)
@@ -323,6 +420,10 @@ func (pq *PropertyQuery) FindBools(valueIfNil *bool) ([]bool, error) {
 // Parameter valueIfNil - value that should be returned instead of NULL values on object fields.
 // If `valueIfNil = nil` is given, objects with NULL values of the specified field are skipped.
 func (pq *PropertyQuery) FindStrings(valueIfNil *string) ([]string, error) {
+	if err := pq.check(); err != nil {
+		return nil, err
+	}
+
 	return cGetStrings(func() *C.OBX_string_array {
 		if valueIfNil == nil {
 			return C.obx_query_prop_find_strings(pq.cPropQuery, nil)
@@ -43,6 +43,12 @@ type Query struct {
 	offsetErr       error
 	limitErr        error
 	linkedEntityIds []TypeId
+
+	// state kept to be able to derive a restricted query, see FindWithBudget()
+	conditions    []Condition // as given to Box.QueryOrError(), nil for other queries
+	offset        uint64
+	limit         uint64
+	paramsChanged bool
 }
 
 // Close frees (native) resources held by this Query.
@@ -62,6 +68,40 @@ func (query *Query) Close() error {
 	return nil
 }
 
+// Clone creates an independent copy of the query with the same conditions, order and currently set parameter values.
+// The clone can be modified (e.g. its parameters, Offset() or Limit()) and executed without affecting the original,
+// which makes it a safe way to derive variations of a base query or to use the same query in multiple goroutines
+// (a single Query must not be used concurrently).
+//
+// The native query resources are duplicated; Close() the clone once it's no longer needed (or leave it to the GC).
+func (query *Query) Clone() (*Query, error) {
+	if err := query.check(); err != nil {
+		return nil, err
+	}
+
+	var clone = &Query{
+		entity:          query.entity,
+		objectBox:       query.objectBox,
+		box:             query.box,
+		linkedEntityIds: append([]TypeId(nil), query.linkedEntityIds...),
+		conditions:      query.conditions,
+		offset:          query.offset,
+		limit:           query.limit,
+		paramsChanged:   query.paramsChanged,
+	}
+
+	if err := cCallBool(func() bool {
+		clone.cQuery = C.obx_query_clone(query.cQuery)
+		return clone.cQuery != nil
+	}); err != nil {
+		return nil, err
+	}
+	runtime.KeepAlive(query)
+
+	clone.installFinalizer()
+	return clone, nil
+}
+
 func queryFinalizer(query *Query) {
 	err := query.Close()
 	if err != nil {
@@ -77,7 +117,9 @@ func (query *Query) installFinalizer() {
 }
 
 func (query *Query) check() error {
-	if query.cQuery == nil {
+	if err := query.objectBox.check(); err != nil {
+		return err
+	} else if query.cQuery == nil {
 		return errors.New("illegal state; query was closed")
 	} else if query.limitErr != nil {
 		return query.limitErr
@@ -133,12 +175,14 @@ func (query *Query) Find() (objects interface{}, err error) {
 // Offset defines the index of the first object to process (how many objects to skip)
 func (query *Query) Offset(offset uint64) *Query {
 	query.offsetErr = cCall(func() C.obx_err { return C.obx_query_offset(query.cQuery, C.size_t(offset)) })
+	query.offset = offset
 	return query
 }
 
 // Limit sets the number of elements to process by the query
 func (query *Query) Limit(limit uint64) *Query {
 	query.limitErr = cCall(func() C.obx_err { return C.obx_query_limit(query.cQuery, C.size_t(limit)) })
+	query.limit = limit
 	return query
 }
 
@@ -170,6 +214,33 @@ func (query *Query) Count() (uint64, error) {
 	return uint64(cResult), nil
 }
 
+// CountEstimate returns the number of objects matching the query, counting at most up to the given limit: the result is
+// exact if it's smaller than limit, otherwise it's limit, meaning "limit or more". This is cheaper than Count() for
+// queries matching many objects because the search stops after finding limit objects (unless the query has an order, in
+// which case all matching objects need to be found anyway), e.g. to show "more than 1000 results" and offer pagination.
+// There's no approximate (statistics-based) count in the database; a limit of 0 returns the exact Count().
+//
+// The query Limit() is used internally and restored afterwards; a smaller limit set before is used instead of the
+// given one. Can't be used with Offset().
+func (query *Query) CountEstimate(limit uint64) (uint64, error) {
+	if limit == 0 {
+		return query.Count()
+	}
+
+	var previous = query.limit
+	defer query.Limit(previous)
+
+	if previous != 0 && previous < limit {
+		limit = previous
+	}
+
+	ids, err := query.Limit(limit).FindIds()
+	if err != nil {
+		return 0, err
+	}
+	return uint64(len(ids)), nil
+}
+
 // Remove permanently deletes all objects matching the query from the database.
 // Currently can't be used in combination with Offset() or Limit().
 func (query *Query) Remove() (count uint64, err error) {
@@ -186,6 +257,41 @@ func (query *Query) Remove() (count uint64, err error) {
 	return uint64(cResult), nil
 }
 
+// RemoveBatched permanently deletes all objects matching the query, like Remove(), but in multiple write transactions,
+// each removing up to batchSize objects. This keeps transactions small when deleting a large number of objects, e.g.
+// in a periodic clean-up of expired data, and lets other writers proceed in between the batches.
+//
+// Note: this is not a single atomic operation - if it fails, the batches removed so far stay removed and the returned
+// count reflects them. Objects matching the query that are put concurrently may be removed as well.
+// The query Limit() is used internally and the limit set before (if any) is restored afterwards; can't be used with
+// Offset().
+func (query *Query) RemoveBatched(batchSize int) (count uint64, err error) {
+	if batchSize <= 0 {
+		return 0, fmt.Errorf("invalid batch size %d, must be positive", batchSize)
+	}
+
+	defer query.Limit(query.limit)
+
+	for {
+		var removed uint64
+		if err = query.Limit(uint64(batchSize)).objectBox.RunInWriteTx(func() error {
+			ids, err := query.FindIds()
+			if err != nil || len(ids) == 0 {
+				return err
+			}
+			removed, err = query.box.RemoveIds(ids...)
+			return err
+		}); err != nil {
+			return count, err
+		}
+
+		if removed == 0 {
+			return count, nil
+		}
+		count += removed
+	}
+}
+
 // DescribeParams returns a string representation of the query conditions
 func (query *Query) DescribeParams() (string, error) {
 	if err := query.check(); err != nil {
@@ -247,6 +353,8 @@ func (query *Query) SetStringParams(identifier propertyOrAlias, values ...string
 		return err
 	}
 
+	query.paramsChanged = true
+
 	if len(values) == 0 {
 		return fmt.Errorf("no values given")
 	}
@@ -280,6 +388,8 @@ func (query *Query) SetStringParamsIn(identifier propertyOrAlias, values ...stri
 		return err
 	}
 
+	query.paramsChanged = true
+
 	if len(values) == 0 {
 		return fmt.Errorf("no values given")
 	}
@@ -309,6 +419,8 @@ func (query *Query) SetInt64Params(identifier propertyOrAlias, values ...int64)
 		return err
 	}
 
+	query.paramsChanged = true
+
 	if len(values) == 0 {
 		return fmt.Errorf("no values given")
 	}
@@ -347,6 +459,8 @@ func (query *Query) SetInt64ParamsIn(identifier propertyOrAlias, values ...int64
 		return err
 	}
 
+	query.paramsChanged = true
+
 	if len(values) == 0 {
 		return fmt.Errorf("no values given")
 	}
@@ -373,6 +487,8 @@ func (query *Query) SetInt32ParamsIn(identifier propertyOrAlias, values ...int32
 		return err
 	}
 
+	query.paramsChanged = true
+
 	if len(values) == 0 {
 		return fmt.Errorf("no values given")
 	}
@@ -399,6 +515,8 @@ func (query *Query) SetFloat64Params(identifier propertyOrAlias, values ...float
 		return err
 	}
 
+	query.paramsChanged = true
+
 	if len(values) == 0 {
 		return fmt.Errorf("no values given")
 	}
@@ -438,6 +556,8 @@ func (query *Query) SetBytesParams(identifier propertyOrAlias, values ...[]byte)
 		return err
 	}
 
+	query.paramsChanged = true
+
 	if len(values) == 0 {
 		return fmt.Errorf("no values given")
 
@@ -0,0 +1,88 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+import (
+	"errors"
+	"fmt"
+)
+
+// FindWithBudget is like Find() but restricted to the maxScan stored objects with the lowest IDs, e.g. to keep a
+// pathological query from blocking a request with a latency SLA. The budget is an ID-range cap, not a count of the
+// objects the query actually examines: the query is evaluated on the objects with the lowest maxScan IDs only (using
+// its indexes as usual), and Offset(), Limit() and the order of the query apply to the matches among those objects.
+//
+// truncated reports whether the cap was applied, i.e. the box contains more than maxScan objects: the results may
+// then be incomplete as objects matching the query beyond the cap are missing. It doesn't tell whether any such matches
+// exist; note that it's also true for queries that would touch only a few objects anyway (e.g. an equality condition
+// on an indexed property) if the box is larger than the cap. If truncated is false, the results are the same as
+// returned by Find().
+//
+// The native library can't interrupt a query after a number of candidates so the restricted query is built anew from
+// the conditions given to Box.Query() (with an additional condition on the ID), executed in a single read transaction
+// together with determining the ID range. Therefore, it's only supported on queries created by Box.Query() or
+// QueryOrError() (and their clones) whose parameters haven't been changed using Set*Params(); create a new query
+// with the changed values instead.
+func (query *Query) FindWithBudget(maxScan uint64) (slice interface{}, truncated bool, err error) {
+	if err := query.check(); err != nil {
+		return nil, false, err
+	}
+
+	if maxScan == 0 {
+		return nil, false, errors.New("invalid scan budget 0, must be positive")
+	} else if query.conditions == nil {
+		return nil, false, errors.New("scan budget is only supported on queries created by Box.Query()")
+	} else if query.paramsChanged {
+		return nil, false, errors.New("scan budget is not supported on queries with changed parameters")
+	}
+
+	err = query.objectBox.RunInReadTx(func() error {
+		// the ID of the last object within the cap and, if there's one, the first object beyond it
+		all, err := query.box.QueryOrError()
+		if err != nil {
+			return err
+		}
+		defer all.Close()
+
+		ids, err := all.Offset(maxScan - 1).Limit(2).FindIds()
+		if err != nil {
+			return err
+		}
+
+		if len(ids) < 2 {
+			// the cap covers all objects
+			slice, err = query.Find()
+			return err
+		}
+		truncated = true
+
+		var conditions = append(append([]Condition{}, query.conditions...), query.box.idBetween(0, ids[0]))
+		restricted, err := query.box.QueryOrError(conditions...)
+		if err != nil {
+			return fmt.Errorf("can't create the query restricted to the scan budget: %s", err)
+		}
+		defer restricted.Close()
+
+		slice, err = restricted.Offset(query.offset).Limit(query.limit).Find()
+		return err
+	})
+
+	if err != nil {
+		return nil, false, err
+	}
+	return slice, truncated, nil
+}
@@ -502,6 +502,22 @@ func (qb *QueryBuilder) IntBetween(property *BaseProperty, value1 int64, value2
 	return cid, qb.Err
 }
 
+// IntNotBetween is called internally
+func (qb *QueryBuilder) IntNotBetween(property *BaseProperty, value1 int64, value2 int64) (ConditionId, error) {
+	var ids = make([]ConditionId, 2)
+
+	if qb.Err == nil {
+		ids[0], _ = qb.IntLess(property, value1, false)
+		ids[1], _ = qb.IntGreater(property, value2, false)
+	}
+
+	if qb.Err != nil {
+		return 0, qb.Err
+	}
+
+	return qb.Any(ids)
+}
+
 // IntEqual is called internally
 func (qb *QueryBuilder) IntEqual(property *BaseProperty, value int64) (ConditionId, error) {
 	var cid ConditionId
@@ -639,6 +655,22 @@ func (qb *QueryBuilder) DoubleBetween(property *BaseProperty, valueA float64, va
 	return cid, qb.Err
 }
 
+// DoubleNotBetween is called internally
+func (qb *QueryBuilder) DoubleNotBetween(property *BaseProperty, valueA float64, valueB float64) (ConditionId, error) {
+	var ids = make([]ConditionId, 2)
+
+	if qb.Err == nil {
+		ids[0], _ = qb.DoubleLess(property, valueA, false)
+		ids[1], _ = qb.DoubleGreater(property, valueB, false)
+	}
+
+	if qb.Err != nil {
+		return 0, qb.Err
+	}
+
+	return qb.Any(ids)
+}
+
 // BytesEqual is called internally
 func (qb *QueryBuilder) BytesEqual(property *BaseProperty, value []byte) (ConditionId, error) {
 	var cid ConditionId
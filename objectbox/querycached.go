@@ -0,0 +1,132 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+import (
+	"sync"
+)
+
+// CachedQuery serves memoized results of a Query, refreshing them only after the data could have changed,
+// i.e. after a transaction changing objects of the queried entity (or entities linked in the query) was committed.
+// See Query.Cached().
+type CachedQuery struct {
+	query     *Query
+	observers []*Observer
+
+	mutex sync.Mutex
+
+	// incremented by observers on each change notification
+	generation uint64
+
+	// the generation the cached result was read at
+	cachedGeneration uint64
+	cached           interface{}
+	hasCached        bool
+}
+
+// Cached returns a handle serving memoized results of this query, which are invalidated automatically whenever
+// objects of the queried entity type (or any type linked in the query) change. This is useful for read-mostly data.
+//
+// Note: changing the query parameters (Set*Params) or offset/limit doesn't invalidate the cached result, use
+// CachedQuery.Invalidate() after doing so. Targets of eagerly loaded to-one relations aren't observed either.
+//
+// Close() the returned handle when it's no longer needed; this doesn't close the underlying query.
+func (query *Query) Cached() (*CachedQuery, error) {
+	if err := query.check(); err != nil {
+		return nil, err
+	}
+
+	var cached = &CachedQuery{query: query}
+
+	var entityIds = append([]TypeId{query.entity.id}, query.linkedEntityIds...)
+	for _, entityId := range entityIds {
+		box, err := query.objectBox.box(entityId)
+		if err == nil {
+			var observer *Observer
+			if observer, err = box.Subscribe(cached.Invalidate); err == nil {
+				cached.observers = append(cached.observers, observer)
+				continue
+			}
+		}
+
+		cached.Close()
+		return nil, err
+	}
+
+	return cached, nil
+}
+
+// Find returns the same result as Query.Find(), reading it from the database only if it has been invalidated since
+// the last call. The returned slice is shared by all callers until the next refresh and thus must not be modified;
+// apart from that, Find is safe to call from multiple goroutines concurrently.
+func (cached *CachedQuery) Find() (objects interface{}, err error) {
+	cached.mutex.Lock()
+	defer cached.mutex.Unlock()
+
+	if cached.hasCached && cached.cachedGeneration == cached.generation && !cached.unobserved() {
+		return cached.cached, nil
+	}
+
+	// a change notification may arrive while reading, in which case the result is replaced by the next Find()
+	var generation = cached.generation
+	cached.mutex.Unlock()
+	objects, err = cached.query.Find()
+	cached.mutex.Lock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	cached.cached = objects
+	cached.cachedGeneration = generation
+	cached.hasCached = true
+	return objects, nil
+}
+
+// unobserved reports whether any of the observers has been closed (e.g. by ObjectBox.UnsubscribeAll()), in which case
+// changes may go unnoticed and the result can't be cached anymore
+func (cached *CachedQuery) unobserved() bool {
+	for _, observer := range cached.observers {
+		if observer.closed() {
+			return true
+		}
+	}
+	return false
+}
+
+// Invalidate drops the cached result so the next Find() reads the data from the database.
+func (cached *CachedQuery) Invalidate() {
+	cached.mutex.Lock()
+	defer cached.mutex.Unlock()
+
+	cached.generation++
+	cached.cached = nil
+	cached.hasCached = false
+}
+
+// Close stops observing the changes and drops the cached result; the underlying Query stays open.
+func (cached *CachedQuery) Close() error {
+	var err error
+	for _, observer := range cached.observers {
+		if closeErr := observer.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	cached.observers = nil
+	cached.Invalidate()
+	return err
+}
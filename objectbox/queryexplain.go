@@ -0,0 +1,178 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include "objectbox.h"
+*/
+import "C"
+import "strings"
+
+// QueryPlan describes how a query is expected to be evaluated, see Query.Explain().
+type QueryPlan struct {
+	// Conditions lists the individual conditions of the query, in the order of the query description
+	Conditions []ConditionPlan
+
+	// UsesIndex is true if at least one condition is expected to be resolved using an index (or the ID) so that not
+	// all objects need to be examined; otherwise the query does a full scan
+	UsesIndex bool
+
+	// EstimatedScan is the estimated number of objects examined: the number of objects in the box for a full scan,
+	// otherwise the number of matching objects (a lower bound)
+	EstimatedScan uint64
+}
+
+// ConditionPlan describes a single condition of a query, see QueryPlan
+type ConditionPlan struct {
+	// Description of the condition, as in Query.DescribeParams(), e.g. `Name == "Joe"`
+	Description string
+
+	// Property is the name of the property the condition applies to; empty if it couldn't be determined
+	Property string
+
+	// UsesIndex is true if the condition is expected to be resolved using an index (or the ID)
+	UsesIndex bool
+}
+
+// Explain returns the expected execution plan of the query: whether the conditions can use an index or the query has
+// to fall back to a full scan, and the estimated number of examined objects. It's intended for tests asserting e.g.
+// that a query must use an index, to catch performance regressions early.
+//
+// The native library doesn't expose its query plan so it's derived from the query description (DescribeParams())
+// and the model: a condition is considered to use an index if it's a top-level condition (not inside Any()), its
+// property is the ID or has an index, and the index supports the operation: equality and "in" conditions with any
+// index, ranges and prefixes only with a value index (not a hash index, e.g. of a unique string property) and none of
+// the case-insensitive string conditions. Treat the result as an estimate.
+func (query *Query) Explain() (QueryPlan, error) {
+	var plan QueryPlan
+
+	description, err := query.DescribeParams()
+	if err != nil {
+		return plan, err
+	}
+
+	var parts, operator = splitQueryDescription(description)
+	for _, part := range parts {
+		var condition = ConditionPlan{Description: part}
+
+		// nested combinations are listed as a single condition
+		var nested = strings.HasPrefix(part, "(")
+		if !nested {
+			var name = part
+			if space := strings.IndexByte(part, ' '); space > 0 {
+				name = part[:space]
+			}
+			if property := query.entity.propertyByName(name); property != nil {
+				condition.Property = name
+				condition.UsesIndex = operator != "OR" &&
+					query.entity.canUseIndex(property, strings.TrimPrefix(part, name+" "))
+			}
+		}
+
+		plan.UsesIndex = plan.UsesIndex || condition.UsesIndex
+		plan.Conditions = append(plan.Conditions, condition)
+	}
+
+	if plan.UsesIndex {
+		plan.EstimatedScan, err = query.Count()
+	} else {
+		plan.EstimatedScan, err = query.box.Count()
+	}
+	if err != nil {
+		return QueryPlan{}, err
+	}
+	return plan, nil
+}
+
+// splitQueryDescription splits a query description into its top-level conditions, returning the operator combining
+// them ("AND" or "OR", empty for a single condition). A description "TRUE" (no conditions) results in no parts.
+func splitQueryDescription(description string) (parts []string, operator string) {
+	description = strings.TrimSpace(description)
+	if description == "" || description == "TRUE" {
+		return nil, ""
+	}
+
+	// strip the parentheses around the whole description, e.g. "(A AND B)"
+	if strings.HasPrefix(description, "(") && closingParenthesis(description, 0) == len(description)-1 {
+		description = description[1 : len(description)-1]
+	}
+
+	var depth = 0
+	var inString = false
+	var start = 0
+	for i := 0; i < len(description); i++ {
+		switch c := description[i]; {
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ' ' && depth == 0:
+			for _, op := range []string{"AND", "OR"} {
+				if strings.HasPrefix(description[i:], " "+op+" ") {
+					parts = append(parts, description[start:i])
+					operator = op
+					start = i + len(op) + 2
+					i = start - 1
+					break
+				}
+			}
+		}
+	}
+	return append(parts, description[start:]), operator
+}
+
+// closingParenthesis returns the index of the parenthesis closing the one at the given index, or -1
+func closingParenthesis(text string, open int) int {
+	var depth = 0
+	var inString = false
+	for i := open; i < len(text); i++ {
+		switch c := text[i]; {
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// canUseIndex reports whether a condition with the given operation (the description without the property name, e.g.
+// `== 47`) on the given property can be resolved using the ID or an index of the property
+func (entity *entity) canUseIndex(property *propertyInfo, operation string) bool {
+	var equality = strings.HasPrefix(operation, "== ") || strings.HasPrefix(operation, "in [")
+	var rangeOrPrefix = strings.HasPrefix(operation, "< ") || strings.HasPrefix(operation, "<= ") ||
+		strings.HasPrefix(operation, "> ") || strings.HasPrefix(operation, ">= ") ||
+		strings.HasPrefix(operation, "between ") || strings.HasPrefix(operation, "starts with ")
+
+	if property.id == entity.idPropertyId {
+		return equality || rangeOrPrefix
+	} else if property.indexId == 0 {
+		return false
+	}
+
+	var hashIndex = property.flags&(C.OBXPropertyFlags_INDEX_HASH|C.OBXPropertyFlags_INDEX_HASH64) != 0
+	return equality || (rangeOrPrefix && !hashIndex)
+}
@@ -0,0 +1,168 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include "objectbox.h"
+*/
+import "C"
+import "fmt"
+
+// CountGroupByInt counts the objects matching the query grouped by the value of the given integer property (including
+// date, bool and relation properties; booleans are counted as 0 and 1), e.g. the number of objects per status.
+// Objects with a NULL value of the property aren't counted. Values of unsigned 64-bit properties above the int64 range
+// are converted to int64 (wrap around).
+//
+// The counts are tallied in Go in a single scan reading the property value of each matching object (without loading
+// the objects), so the cost grows with the number of matching objects and the memory used by the resulting map with
+// the number of distinct values (cardinality); it's still much cheaper than issuing a count query per group.
+func (query *Query) CountGroupByInt(property Property) (map[int64]uint64, error) {
+	info, pq, err := query.groupByPropertyQuery(property)
+	if err != nil {
+		return nil, err
+	}
+	defer pq.Close()
+
+	var counts = make(map[int64]uint64)
+	var unsigned = info.isUnsigned()
+	switch info.propertyType {
+	case C.OBXPropertyType_Long, C.OBXPropertyType_Date, C.OBXPropertyType_DateNano, C.OBXPropertyType_Relation:
+		values, err := pq.FindInt64s(nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, value := range values {
+			counts[value]++
+		}
+	case C.OBXPropertyType_Int:
+		if unsigned {
+			values, err := pq.FindUint32s(nil)
+			if err != nil {
+				return nil, err
+			}
+			for _, value := range values {
+				counts[int64(value)]++
+			}
+		} else {
+			values, err := pq.FindInt32s(nil)
+			if err != nil {
+				return nil, err
+			}
+			for _, value := range values {
+				counts[int64(value)]++
+			}
+		}
+	case C.OBXPropertyType_Short, C.OBXPropertyType_Char:
+		if unsigned || info.propertyType == C.OBXPropertyType_Char {
+			values, err := pq.FindUint16s(nil)
+			if err != nil {
+				return nil, err
+			}
+			for _, value := range values {
+				counts[int64(value)]++
+			}
+		} else {
+			values, err := pq.FindInt16s(nil)
+			if err != nil {
+				return nil, err
+			}
+			for _, value := range values {
+				counts[int64(value)]++
+			}
+		}
+	case C.OBXPropertyType_Byte:
+		if unsigned {
+			values, err := pq.FindUint8s(nil)
+			if err != nil {
+				return nil, err
+			}
+			for _, value := range values {
+				counts[int64(value)]++
+			}
+		} else {
+			values, err := pq.FindInt8s(nil)
+			if err != nil {
+				return nil, err
+			}
+			for _, value := range values {
+				counts[int64(value)]++
+			}
+		}
+	case C.OBXPropertyType_Bool:
+		values, err := pq.FindBools(nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, value := range values {
+			if value {
+				counts[1]++
+			} else {
+				counts[0]++
+			}
+		}
+	default:
+		return nil, fmt.Errorf("property %s is not an integer property", info.name)
+	}
+	return counts, nil
+}
+
+// CountGroupByString counts the objects matching the query grouped by the value of the given string property, e.g.
+// the number of objects per category. The values are compared case-sensitively. Objects with a NULL value of the
+// property aren't counted. See CountGroupByInt() for the cost of the scan.
+func (query *Query) CountGroupByString(property Property) (map[string]uint64, error) {
+	info, pq, err := query.groupByPropertyQuery(property)
+	if err != nil {
+		return nil, err
+	}
+	defer pq.Close()
+
+	if info.propertyType != C.OBXPropertyType_String {
+		return nil, fmt.Errorf("property %s is not a string property", info.name)
+	}
+
+	values, err := pq.FindStrings(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var counts = make(map[string]uint64)
+	for _, value := range values {
+		counts[value]++
+	}
+	return counts, nil
+}
+
+// groupByPropertyQuery creates a property query for the given property, also returning its model information
+func (query *Query) groupByPropertyQuery(property Property) (*propertyInfo, *PropertyQuery, error) {
+	if err := query.check(); err != nil {
+		return nil, nil, err
+	} else if property == nil {
+		return nil, nil, fmt.Errorf("no property given")
+	}
+
+	pq, err := query.PropertyOrError(property)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var info = query.entity.propertyById(property.propertyId())
+	if info == nil {
+		pq.Close()
+		return nil, nil, fmt.Errorf("property %d not found on entity %s", property.propertyId(), query.entity.name)
+	}
+	return info, pq, nil
+}
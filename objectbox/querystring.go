@@ -0,0 +1,391 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include "objectbox.h"
+*/
+import "C"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// QueryString creates a query from a filter expression, e.g. for a search UI where conditions are entered as text:
+//
+//	box.QueryString(`Status == 2 && (Device == "thermo" || CreatedAt > 1600000000)`)
+//
+// Each comparison has a property name (as in the model) on the left side, one of the operators ==, !=, <, >, <=, >=
+// and a literal value on the right side. Comparisons can be combined using && and || (&& binds stronger) and grouped
+// using parentheses. The supported literals depend on the property type:
+//   - integer, date and relation properties: integer numbers, e.g. 42 or -1
+//   - bool properties: true, false, 1 or 0
+//   - float properties: numbers, e.g. 3.5 or 1e-3
+//   - string properties: string literals in double or single quotes, e.g. "thermo"; a backslash escapes the following
+//     character (\n and \t are a newline and a tab); comparisons are case-sensitive
+//
+// Other property types (e.g. byte and string vectors) aren't supported. An invalid expression is reported as a
+// *QueryStringError giving the position of the offending part of the expression.
+func (box *Box) QueryString(expr string) (*Query, error) {
+	if err := box.check(); err != nil {
+		return nil, err
+	}
+
+	tokens, err := tokenizeQueryString(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	var parser = &queryStringParser{entity: box.entity, tokens: tokens}
+	condition, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if token := parser.peek(); token.kind != queryTokenEnd {
+		return nil, parser.errorAt(token, "unexpected %s, expected && or ||", token)
+	}
+
+	return box.QueryOrError(condition)
+}
+
+// QueryStringError is returned by Box.QueryString() if the given expression is invalid
+type QueryStringError struct {
+	// Position is the (zero-based) byte offset in the expression where the error was detected
+	Position int
+
+	// Message describes the error
+	Message string
+}
+
+func (err *QueryStringError) Error() string {
+	return fmt.Sprintf("invalid query expression at position %d: %s", err.Position, err.Message)
+}
+
+type queryTokenKind int
+
+const (
+	queryTokenEnd queryTokenKind = iota
+	queryTokenIdent
+	queryTokenNumber
+	queryTokenString
+	queryTokenOperator
+	queryTokenLParen
+	queryTokenRParen
+)
+
+type queryToken struct {
+	kind  queryTokenKind
+	pos   int
+	text  string // as written in the expression
+	value string // unquoted value of string literals
+}
+
+// String describes the token for error messages
+func (token queryToken) String() string {
+	if token.kind == queryTokenEnd {
+		return "end of expression"
+	}
+	return "'" + token.text + "'"
+}
+
+// tokenizeQueryString splits the expression into tokens, always terminated by a queryTokenEnd token
+func tokenizeQueryString(expr string) ([]queryToken, error) {
+	var tokens []queryToken
+	var pos = 0
+	for {
+		for pos < len(expr) && strings.IndexByte(" \t\r\n", expr[pos]) >= 0 {
+			pos++
+		}
+		if pos == len(expr) {
+			return append(tokens, queryToken{kind: queryTokenEnd, pos: pos}), nil
+		}
+
+		var start = pos
+		var c = expr[pos]
+		var token = queryToken{pos: start}
+		switch {
+		case c == '(':
+			token.kind = queryTokenLParen
+			pos++
+		case c == ')':
+			token.kind = queryTokenRParen
+			pos++
+		case isQueryIdentStart(c):
+			token.kind = queryTokenIdent
+			for pos < len(expr) && (isQueryIdentStart(expr[pos]) || isQueryDigit(expr[pos])) {
+				pos++
+			}
+		case isQueryDigit(c) || ((c == '-' || c == '+' || c == '.') && pos+1 < len(expr) &&
+			(isQueryDigit(expr[pos+1]) || expr[pos+1] == '.')):
+			token.kind = queryTokenNumber
+			pos++
+			for pos < len(expr) && (isQueryDigit(expr[pos]) || expr[pos] == '.' || isQueryIdentStart(expr[pos]) ||
+				((expr[pos] == '-' || expr[pos] == '+') && (expr[pos-1] == 'e' || expr[pos-1] == 'E'))) {
+				pos++
+			}
+		case c == '"' || c == '\'':
+			token.kind = queryTokenString
+			var value strings.Builder
+			pos++
+			for ; pos < len(expr) && expr[pos] != c; pos++ {
+				if expr[pos] == '\\' && pos+1 < len(expr) {
+					pos++
+					switch expr[pos] {
+					case 'n':
+						value.WriteByte('\n')
+					case 't':
+						value.WriteByte('\t')
+					default:
+						value.WriteByte(expr[pos])
+					}
+				} else {
+					value.WriteByte(expr[pos])
+				}
+			}
+			if pos == len(expr) {
+				return nil, &QueryStringError{Position: start, Message: "unterminated string literal"}
+			}
+			pos++
+			token.value = value.String()
+		default:
+			token.kind = queryTokenOperator
+			for _, operator := range []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">"} {
+				if strings.HasPrefix(expr[pos:], operator) {
+					pos += len(operator)
+					break
+				}
+			}
+			if pos == start {
+				if c == '=' || c == '&' || c == '|' || c == '!' {
+					return nil, &QueryStringError{Position: start,
+						Message: fmt.Sprintf("invalid operator '%c', expected ==, !=, && or ||", c)}
+				}
+				return nil, &QueryStringError{Position: start, Message: fmt.Sprintf("unexpected character '%c'", c)}
+			}
+		}
+		token.text = expr[start:pos]
+		tokens = append(tokens, token)
+	}
+}
+
+func isQueryIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isQueryDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// queryStringParser is a recursive descent parser of the QueryString() grammar:
+//
+//	or         = and { "||" and }
+//	and        = primary { "&&" primary }
+//	primary    = "(" or ")" | comparison
+//	comparison = property ( "==" | "!=" | "<" | ">" | "<=" | ">=" ) literal
+type queryStringParser struct {
+	entity *entity
+	tokens []queryToken
+	index  int
+}
+
+func (parser *queryStringParser) peek() queryToken {
+	return parser.tokens[parser.index]
+}
+
+func (parser *queryStringParser) next() queryToken {
+	var token = parser.tokens[parser.index]
+	if token.kind != queryTokenEnd {
+		parser.index++
+	}
+	return token
+}
+
+func (parser *queryStringParser) errorAt(token queryToken, format string, args ...interface{}) error {
+	return &QueryStringError{Position: token.pos, Message: fmt.Sprintf(format, args...)}
+}
+
+func (parser *queryStringParser) parseOr() (Condition, error) {
+	return parser.parseCombination("||", parser.parseAnd, Any)
+}
+
+func (parser *queryStringParser) parseAnd() (Condition, error) {
+	return parser.parseCombination("&&", parser.parsePrimary, All)
+}
+
+// parseCombination parses a list of operands separated by the given operator
+func (parser *queryStringParser) parseCombination(operator string, operand func() (Condition, error),
+	combine func(...Condition) Condition) (Condition, error) {
+	condition, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	var conditions = []Condition{condition}
+	for token := parser.peek(); token.kind == queryTokenOperator && token.text == operator; token = parser.peek() {
+		parser.next()
+		if condition, err = operand(); err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0], nil
+	}
+	return combine(conditions...), nil
+}
+
+func (parser *queryStringParser) parsePrimary() (Condition, error) {
+	var token = parser.next()
+	switch token.kind {
+	case queryTokenLParen:
+		condition, err := parser.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := parser.next(); closing.kind != queryTokenRParen {
+			return nil, parser.errorAt(closing, "unexpected %s, expected ')' closing '(' at position %d", closing,
+				token.pos)
+		}
+		return condition, nil
+	case queryTokenIdent:
+		var property = parser.entity.propertyByName(token.text)
+		if property == nil {
+			return nil, parser.errorAt(token, "unknown property %s on entity %s", token.text, parser.entity.name)
+		}
+
+		var operator = parser.next()
+		if operator.kind != queryTokenOperator || operator.text == "&&" || operator.text == "||" {
+			return nil, parser.errorAt(operator, "unexpected %s, expected a comparison operator", operator)
+		}
+
+		var literal = parser.next()
+		if literal.kind != queryTokenNumber && literal.kind != queryTokenString && literal.kind != queryTokenIdent {
+			return nil, parser.errorAt(literal, "unexpected %s, expected a value", literal)
+		}
+		return parser.comparison(token, property, operator, literal)
+	default:
+		return nil, parser.errorAt(token, "unexpected %s, expected a property name or '('", token)
+	}
+}
+
+// comparison creates the condition comparing the given property with the literal value
+func (parser *queryStringParser) comparison(name queryToken, property *propertyInfo, operator, literal queryToken) (
+	Condition, error) {
+	var base = &BaseProperty{Id: property.id, Entity: &Entity{Id: parser.entity.id}}
+	var op = operator.text
+
+	switch property.propertyType {
+	case C.OBXPropertyType_Bool, C.OBXPropertyType_Byte, C.OBXPropertyType_Short, C.OBXPropertyType_Char,
+		C.OBXPropertyType_Int, C.OBXPropertyType_Long, C.OBXPropertyType_Date, C.OBXPropertyType_DateNano,
+		C.OBXPropertyType_Relation:
+		value, err := parser.intLiteral(property, literal)
+		if err != nil {
+			return nil, err
+		}
+		return &conditionClosure{
+			apply: func(qb *QueryBuilder) (ConditionId, error) {
+				switch op {
+				case "==":
+					return qb.IntEqual(base, value)
+				case "!=":
+					return qb.IntNotEqual(base, value)
+				case "<", "<=":
+					return qb.IntLess(base, value, op == "<=")
+				default:
+					return qb.IntGreater(base, value, op == ">=")
+				}
+			},
+		}, nil
+
+	case C.OBXPropertyType_Float, C.OBXPropertyType_Double:
+		if literal.kind != queryTokenNumber {
+			return nil, parser.errorAt(literal, "expected a number to compare float property %s with, got %s",
+				property.name, literal)
+		}
+		value, err := strconv.ParseFloat(literal.text, 64)
+		if err != nil {
+			return nil, parser.errorAt(literal, "invalid number %s", literal)
+		}
+		return &conditionClosure{
+			apply: func(qb *QueryBuilder) (ConditionId, error) {
+				switch op {
+				case "==":
+					return qb.DoubleBetween(base, value, value)
+				case "!=":
+					return qb.DoubleNotBetween(base, value, value)
+				case "<", "<=":
+					return qb.DoubleLess(base, value, op == "<=")
+				default:
+					return qb.DoubleGreater(base, value, op == ">=")
+				}
+			},
+		}, nil
+
+	case C.OBXPropertyType_String:
+		if literal.kind != queryTokenString {
+			return nil, parser.errorAt(literal, "expected a string literal to compare string property %s with, got %s",
+				property.name, literal)
+		}
+		var value = literal.value
+		return &conditionClosure{
+			apply: func(qb *QueryBuilder) (ConditionId, error) {
+				switch op {
+				case "==":
+					return qb.StringEquals(base, value, true)
+				case "!=":
+					return qb.StringNotEquals(base, value, true)
+				case "<", "<=":
+					return qb.StringLess(base, value, true, op == "<=")
+				default:
+					return qb.StringGreater(base, value, true, op == ">=")
+				}
+			},
+		}, nil
+
+	default:
+		return nil, parser.errorAt(name, "property %s has a type not supported in query expressions", property.name)
+	}
+}
+
+// intLiteral parses the literal compared with an integer property; large unsigned values are converted to int64
+// (wrap around) like the typed conditions of unsigned properties do
+func (parser *queryStringParser) intLiteral(property *propertyInfo, literal queryToken) (int64, error) {
+	if property.propertyType == C.OBXPropertyType_Bool && literal.kind == queryTokenIdent {
+		switch literal.text {
+		case "true":
+			return 1, nil
+		case "false":
+			return 0, nil
+		}
+	}
+
+	if literal.kind != queryTokenNumber {
+		return 0, parser.errorAt(literal, "expected an integer to compare property %s with, got %s", property.name,
+			literal)
+	}
+
+	if value, err := strconv.ParseInt(literal.text, 10, 64); err == nil {
+		return value, nil
+	} else if property.isUnsigned() {
+		if value, err := strconv.ParseUint(literal.text, 10, 64); err == nil {
+			return int64(value), nil
+		}
+	}
+	return 0, parser.errorAt(literal, "invalid integer %s for property %s", literal, property.name)
+}
@@ -0,0 +1,273 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// setPropertyBatchSize limits the number of objects loaded into memory at once by Query.SetProperty()
+const setPropertyBatchSize = 1000
+
+// SetProperty sets the given property to the same value on all objects matching the query, e.g. to archive old orders
+// using `query.SetProperty(Order_.Status, "archived")`. Returns the number of updated objects.
+//
+// The native library doesn't support updating properties in place so the objects are read, changed and put again,
+// in batches of up to 1000 objects to keep the memory usage bounded. All the batches are executed in a single write
+// transaction, i.e. either all of the matching objects are updated or, if an error occurs, none of them.
+// The set of updated objects is determined before the first change, so it's safe to change a property the query
+// conditions refer to.
+//
+// The value must be assignable to the struct field of the property, e.g. a string for a string field, a time.Time for
+// a field with a converter. For pointer fields, a value of the pointed-to type is accepted as well and nil sets the
+// field to nil (the zero value for other fields). Numeric values are converted if the value fits the field type, e.g.
+// a negative value doesn't fit an unsigned field; float values may lose precision when set to a float32 field.
+// The field is found by the `objectbox:"name:..."` tag if the property was renamed, by the property name otherwise.
+// The ID property can't be set.
+func (query *Query) SetProperty(property Property, value interface{}) (updated uint64, err error) {
+	if err := query.check(); err != nil {
+		return 0, err
+	}
+
+	if property.entityId() != query.entity.id {
+		return 0, fmt.Errorf("property %d doesn't belong to the queried entity %s", property.propertyId(), query.entity.name)
+	}
+
+	var info = query.entity.propertyById(property.propertyId())
+	if info == nil {
+		return 0, fmt.Errorf("unknown property %d on entity %s", property.propertyId(), query.entity.name)
+	} else if info.id == query.entity.idPropertyId {
+		return 0, fmt.Errorf("the ID property of entity %s can't be set", query.entity.name)
+	}
+
+	err = query.objectBox.RunInWriteTx(func() error {
+		ids, err := query.FindIds()
+		if err != nil {
+			return err
+		}
+
+		for start := 0; start < len(ids); start += setPropertyBatchSize {
+			var end = start + setPropertyBatchSize
+			if end > len(ids) {
+				end = len(ids)
+			}
+
+			slice, err := query.box.GetManyExisting(ids[start:end]...)
+			if err != nil {
+				return err
+			}
+
+			var objects = query.box.objectSlice(slice)
+			var count = objects.len()
+			for i := 0; i < count; i++ {
+				if err := setFieldValue(objects.index(i), info.name, value); err != nil {
+					return fmt.Errorf("can't set property %s on entity %s: %s", info.name, query.entity.name, err)
+				}
+			}
+
+			if supportsResultArray {
+				if err := query.box.putManyObjects(objects, make([]uint64, count), 0, count); err != nil {
+					return err
+				}
+			} else {
+				for i := 0; i < count; i++ {
+					if _, err := query.box.put(objects.index(i), true, cPutModePut); err != nil {
+						return err
+					}
+				}
+			}
+			updated += uint64(count)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+	return updated, nil
+}
+
+// setFieldValue assigns the value to the named field of the given struct pointer, see Query.SetProperty() and
+// Box.PutMerge()
+func setFieldValue(object interface{}, name string, value interface{}) error {
+	var objectValue = reflect.ValueOf(object)
+	if objectValue.Kind() != reflect.Ptr || objectValue.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("object of type %T is not a pointer to a struct", object)
+	}
+
+	var field = propertyField(objectValue.Elem(), name)
+	if !field.IsValid() || !field.CanSet() {
+		return fmt.Errorf("struct %T doesn't have a settable field for property %s", object, name)
+	}
+
+	if value == nil {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+
+	var v = reflect.ValueOf(value)
+	if v.Type().AssignableTo(field.Type()) {
+		field.Set(v)
+		return nil
+	}
+
+	var targetType = field.Type()
+	if targetType.Kind() == reflect.Ptr {
+		targetType = targetType.Elem()
+	}
+
+	if !v.Type().AssignableTo(targetType) {
+		if !isNumericKind(v.Kind()) || !isNumericKind(targetType.Kind()) {
+			return fmt.Errorf("value of type %T can't be assigned to the field of type %s", value, field.Type())
+		}
+
+		if !numberFits(v, targetType) {
+			return fmt.Errorf("value %v doesn't fit the field type %s", value, field.Type())
+		}
+		v = v.Convert(targetType)
+	}
+
+	if targetType != field.Type() {
+		// a new pointer for each object so that they don't share the memory
+		var ptr = reflect.New(targetType)
+		ptr.Elem().Set(v)
+		v = ptr
+	}
+	field.Set(v)
+	return nil
+}
+
+// intFieldValue returns the value of the named integer field of the given struct pointer, see Box.IncrementProperty()
+func intFieldValue(object interface{}, name string) (int64, error) {
+	var objectValue = reflect.ValueOf(object)
+	if objectValue.Kind() != reflect.Ptr || objectValue.Elem().Kind() != reflect.Struct {
+		return 0, fmt.Errorf("object of type %T is not a pointer to a struct", object)
+	}
+
+	var field = propertyField(objectValue.Elem(), name)
+	if !field.IsValid() {
+		return 0, fmt.Errorf("struct %T doesn't have a field for property %s", object, name)
+	}
+
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return 0, nil
+		}
+		field = field.Elem()
+	}
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return field.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if field.Uint() > math.MaxInt64 {
+			return 0, fmt.Errorf("value %d exceeds the int64 range", field.Uint())
+		}
+		return int64(field.Uint()), nil
+	}
+	return 0, fmt.Errorf("field %s of type %s is not an integer", name, field.Type())
+}
+
+// propertyField finds the struct field of the property with the given name (as defined in the model): a field renamed
+// using the `objectbox:"name:..."` tag or, if there's none, the field of the same name. Fields of embedded structs
+// are included. Returns an invalid reflect.Value if there's no such field.
+func propertyField(structValue reflect.Value, name string) reflect.Value {
+	var structType = structValue.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		var field = structType.Field(i)
+		if tagName, ok := fieldTagName(field); ok && tagName == name {
+			return structValue.Field(i)
+		} else if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if embedded := propertyField(structValue.Field(i), name); embedded.IsValid() {
+				return embedded
+			}
+		}
+	}
+
+	// a field renamed in the model doesn't represent a property of its Go name
+	if field, ok := structType.FieldByName(name); ok {
+		if _, renamed := fieldTagName(field); !renamed {
+			return structValue.FieldByIndex(field.Index)
+		}
+	}
+	return reflect.Value{}
+}
+
+// fieldTagName returns the property name given by the `objectbox:"name:..."` tag of the field, if there's one
+func fieldTagName(field reflect.StructField) (string, bool) {
+	for _, part := range strings.Fields(field.Tag.Get("objectbox")) {
+		if strings.HasPrefix(part, "name:") {
+			return strings.TrimPrefix(part, "name:"), true
+		}
+	}
+	return "", false
+}
+
+// numberFits reports whether the numeric value can be converted to the given numeric type without changing it, e.g.
+// a negative value doesn't fit an unsigned type and 3.5 doesn't fit an integer type
+func numberFits(v reflect.Value, targetType reflect.Type) bool {
+	switch targetType.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var max = uint64(math.MaxUint64) >> (64 - uint(targetType.Bits()))
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return v.Int() >= 0 && uint64(v.Int()) <= max
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return v.Uint() <= max
+		default:
+			var f = v.Float()
+			return f >= 0 && f == math.Trunc(f) && f < math.Ldexp(1, targetType.Bits())
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var max = int64(math.MaxInt64) >> (64 - uint(targetType.Bits()))
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return v.Int() >= -max-1 && v.Int() <= max
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return v.Uint() <= uint64(max)
+		default:
+			var f = v.Float()
+			var limit = math.Ldexp(1, targetType.Bits()-1)
+			return f == math.Trunc(f) && f >= -limit && f < limit
+		}
+	}
+
+	// floats: integers must be represented exactly, floats may lose precision (e.g. float64(0.1) as a float32) but
+	// not overflow (NaN and infinity are kept as they are)
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		var f = v.Float()
+		return targetType.Kind() == reflect.Float64 || math.IsNaN(f) || math.IsInf(f, 0) ||
+			math.Abs(f) <= math.MaxFloat32
+	default:
+		return v.Convert(targetType).Convert(v.Type()).Interface() == v.Interface()
+	}
+}
+
+func isNumericKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+import (
+	"errors"
+)
+
+// ReadCache is an identity map of objects read in a single read transaction, see ObjectBox.RunInReadTxCached().
+type ReadCache struct {
+	objects map[readCacheKey]interface{}
+}
+
+type readCacheKey struct {
+	entityId TypeId
+	id       uint64
+}
+
+// RunInReadTxCached executes the given function inside a read transaction, like RunInReadTx, passing it a ReadCache.
+// Objects read through the cache are loaded only once during the transaction: repeated ReadCache.Get() calls with the
+// same box and ID return the very same instance (pointer), i.e. they're identity-equal. This avoids repeated
+// deserialization, e.g. when traversing an object graph visiting some objects multiple times.
+//
+// The cache is bound to the transaction: it's emptied after fn returns and must not be used afterwards.
+// Changes made to the returned objects are visible to all subsequent Get() calls of the same cache.
+func (ob *ObjectBox) RunInReadTxCached(fn func(cache *ReadCache) error) error {
+	var cache = &ReadCache{objects: make(map[readCacheKey]interface{})}
+	defer func() {
+		cache.objects = nil
+	}()
+
+	return ob.RunInReadTx(func() error {
+		return fn(cache)
+	})
+}
+
+// Get reads a single object, like Box.Get(), returning the instance read earlier in this transaction if available.
+// Returns nil if the object doesn't exist; the absence is cached as well.
+func (cache *ReadCache) Get(box *Box, id uint64) (interface{}, error) {
+	if cache.objects == nil {
+		return nil, errors.New("the read cache can only be used inside its transaction")
+	}
+
+	var key = readCacheKey{box.entity.id, id}
+	if object, found := cache.objects[key]; found {
+		return object, nil
+	}
+
+	object, err := box.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.objects[key] = object
+	return object, nil
+}
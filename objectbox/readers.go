@@ -0,0 +1,134 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include <stdint.h>
+
+// the address of a thread-local variable identifies the OS thread (among the currently running ones)
+static inline uintptr_t obx_go_thread_id() {
+	static __thread char marker;
+	return (uintptr_t) &marker;
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"sync"
+)
+
+// defaultMaxReaders is the native default of Builder.MaxReaders()
+const defaultMaxReaders = 126
+
+// readerStats tracks the transactions open on each OS thread: only the outermost transaction of a thread takes a
+// reader slot, the ones nested in it reuse it.
+type readerStats struct {
+	mutex     sync.Mutex
+	threads   map[uintptr]int // the number of (nested) transactions currently open on the OS thread
+	inUse     int             // the number of threads with an outermost read transaction open
+	threshold int
+	callback  func(inUse, max int)
+}
+
+// ReadersInUse returns the number of currently open read transactions and the maximum number of readers configured
+// (see Builder.MaxReaders()), e.g. to alert or to back off before reads start failing with "max readers exceeded".
+// See SetReadersThreshold() to get notified instead of polling.
+//
+// Only the outermost read transaction of each OS thread is counted (including snapshots): the ones nested in it (e.g.
+// a Box.Get() inside RunInReadTx()) and reads inside a write transaction don't take another reader slot.
+// Note: the native library doesn't expose its reader slot usage so this counts the read transactions opened by this
+// ObjectBox instance; use it as an indicator. The actual limit may be reached earlier: each OS thread that has executed
+// a read transaction keeps a reader slot until the thread ends.
+func (ob *ObjectBox) ReadersInUse() (inUse int, max int, err error) {
+	if err := ob.check(); err != nil {
+		return 0, 0, err
+	}
+	ob.readers.mutex.Lock()
+	defer ob.readers.mutex.Unlock()
+	return ob.readers.inUse, ob.maxReaders, nil
+}
+
+// SetReadersThreshold sets a function called each time the number of readers in use (see ReadersInUse()) rises to the
+// given threshold, e.g. to emit a warning before the configured maximum is reached; pass a nil callback to remove it.
+//
+// The callback is called synchronously by the goroutine opening the read transaction that reached the threshold,
+// before the transaction is used. Keep it fast and don't block in it; a panic inside the callback is recovered and
+// ignored. Returns an error if the threshold is not positive.
+func (ob *ObjectBox) SetReadersThreshold(threshold int, callback func(inUse, max int)) error {
+	if callback != nil && threshold <= 0 {
+		return errors.New("readers threshold must be positive")
+	}
+	ob.readers.mutex.Lock()
+	defer ob.readers.mutex.Unlock()
+	ob.readers.threshold = threshold
+	ob.readers.callback = callback
+	return nil
+}
+
+// txnOpened must be called after a transaction has been opened, on its (locked) OS thread; reports whether the
+// transaction is counted as a reader in use, which must be passed to txnClosed().
+func (ob *ObjectBox) txnOpened(readOnly bool) (counted bool) {
+	var thread = currentThread()
+	var callback func(inUse, max int)
+	var inUse int
+
+	ob.readers.mutex.Lock()
+	if ob.readers.threads == nil {
+		ob.readers.threads = make(map[uintptr]int)
+	}
+	ob.readers.threads[thread]++
+	if readOnly && ob.readers.threads[thread] == 1 {
+		counted = true
+		ob.readers.inUse++
+		inUse = ob.readers.inUse
+		if inUse == ob.readers.threshold {
+			callback = ob.readers.callback
+		}
+	}
+	ob.readers.mutex.Unlock()
+
+	if callback != nil {
+		callReadersCallback(callback, inUse, ob.maxReaders)
+	}
+	return counted
+}
+
+// txnClosed must be called after a transaction has been closed, on the same OS thread it was opened on
+func (ob *ObjectBox) txnClosed(counted bool) {
+	var thread = currentThread()
+
+	ob.readers.mutex.Lock()
+	defer ob.readers.mutex.Unlock()
+	if ob.readers.threads[thread]--; ob.readers.threads[thread] <= 0 {
+		delete(ob.readers.threads, thread)
+	}
+	if counted {
+		ob.readers.inUse--
+	}
+}
+
+// currentThread identifies the OS thread the caller runs on; only stable while the goroutine is locked to the thread
+func currentThread() uintptr {
+	return uintptr(C.obx_go_thread_id())
+}
+
+func callReadersCallback(callback func(inUse, max int), inUse, max int) {
+	// the transaction is already open and must be closed by the caller, don't let a panic skip that
+	defer func() { _ = recover() }()
+	callback(inUse, max)
+}
@@ -17,6 +17,7 @@
 package objectbox
 
 import (
+	"errors"
 	"fmt"
 )
 
@@ -48,6 +49,10 @@ func (condition *conditionRelationOneToMany) As(alias *alias) Condition {
 	return condition
 }
 
+func (condition *conditionRelationOneToMany) negated() (Condition, error) {
+	return nil, errors.New("negation of a OneToMany relation link is not supported")
+}
+
 // RelationToOne holds information about a relation link on a property.
 // It is used in generated entity code, providing a way to create a query across multiple related entities.
 // Internally, the property value holds an ID of an object in the target entity.
@@ -79,6 +84,9 @@ func (relation RelationToOne) Equals(value uint64) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntEqual(relation.Property, int64(value))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntNotEqual(relation.Property, int64(value))
+		},
 	}
 }
 
@@ -88,6 +96,9 @@ func (relation RelationToOne) NotEquals(value uint64) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.IntNotEqual(relation.Property, int64(value))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.IntEqual(relation.Property, int64(value))
+		},
 	}
 }
 
@@ -107,6 +118,9 @@ func (relation RelationToOne) In(values ...uint64) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.Int64In(relation.Property, relation.int64Slice(values))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.Int64NotIn(relation.Property, relation.int64Slice(values))
+		},
 	}
 }
 
@@ -116,6 +130,9 @@ func (relation RelationToOne) NotIn(values ...uint64) Condition {
 		apply: func(qb *QueryBuilder) (ConditionId, error) {
 			return qb.Int64NotIn(relation.Property, relation.int64Slice(values))
 		},
+		negate: func(qb *QueryBuilder) (ConditionId, error) {
+			return qb.Int64In(relation.Property, relation.int64Slice(values))
+		},
 	}
 }
 
@@ -147,6 +164,10 @@ func (condition *conditionRelationManyToMany) As(alias *alias) Condition {
 	return condition
 }
 
+func (condition *conditionRelationManyToMany) negated() (Condition, error) {
+	return nil, errors.New("negation of a ManyToMany relation link is not supported")
+}
+
 // RelationToMany holds information about a standalone relation link between two entities.
 // It is used in generated entity code, providing a way to create a query across multiple related entities.
 // Internally, the relation is stored separately, holding pairs of source & target object IDs.
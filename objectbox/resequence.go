@@ -0,0 +1,149 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include "objectbox.h"
+*/
+import "C"
+import (
+	"fmt"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/google/flatbuffers/go"
+)
+
+// AllowResequenceWithRelations configures whether ResequenceIds() may be used on this box even though its entity is
+// involved in relations that refer to its objects by ID, see ResequenceIds() for what the caller is responsible for
+// in that case. Like RequireIds(), the setting is kept for the lifetime of the ObjectBox instance. Not allowed by
+// default.
+func (box *Box) AllowResequenceWithRelations(allowed bool) {
+	if allowed {
+		atomic.StoreInt32(&box.resequenceRelations, aTrue)
+	} else {
+		atomic.StoreInt32(&box.resequenceRelations, aFalse)
+	}
+}
+
+// ResequenceIds rewrites all objects of the given box with contiguous IDs starting at 1, keeping their order, e.g. to
+// compact the ID space after removing most of the objects. For each object whose ID changes, remap is called with its
+// old and new ID, after the object has been moved (stored under the new ID, the old one removed), so that the caller
+// can update references to it. All is done in a single write transaction: if any step fails, nothing is changed.
+// The stored data is copied as-is, hooks like SetPreSave() aren't called.
+//
+// This is meant as an offline maintenance tool: nothing else should use the affected IDs meanwhile. Objects are
+// identified by their new IDs afterwards, so any ID kept outside of the database (caches, other systems) must be
+// updated using remap. Note that the ID sequence of the box isn't reset: new objects still get IDs following the
+// highest ID ever assigned.
+//
+// Relations referring to the objects by ID would silently point to wrong objects after resequencing, so this fails
+// for entities involved in them unless explicitly allowed using AllowResequenceWithRelations(). The to-one relations
+// of the entity itself are not affected (the referenced IDs are those of other objects). When allowing it, the caller
+// is responsible for updating the relations in remap; it's called inside the transaction so other boxes can be
+// changed there:
+//   - to-one relation properties of other objects pointing to the moved one need to be set to the new ID,
+//   - standalone (many-to-many) relations with the moved object as the source or the target need to be put again for
+//     the new ID; read them before calling ResequenceIds(), e.g. using Box.RelationIds().
+func ResequenceIds(box *Box, remap func(oldId, newId uint64)) error {
+	if err := box.check(); err != nil {
+		return err
+	}
+
+	if atomic.LoadInt32(&box.resequenceRelations) != aTrue {
+		if related := box.entity.referencingEntity(); related != "" {
+			return fmt.Errorf("can't resequence IDs of entity %s: referenced by relations of entity %s, see "+
+				"Box.AllowResequenceWithRelations()", box.entity.name, related)
+		}
+	}
+
+	var idProperty = box.entity.propertyById(box.entity.idPropertyId)
+	if idProperty == nil {
+		return fmt.Errorf("can't resequence IDs of entity %s: no ID property", box.entity.name)
+	}
+
+	var err = box.ObjectBox.RunInWriteTx(func() error {
+		ids, err := box.AllIds()
+		if err != nil {
+			return err
+		}
+
+		for i, oldId := range ids {
+			var newId = uint64(i + 1)
+			if oldId == newId {
+				continue
+			}
+
+			// IDs below the new one are already taken by the objects processed before, the new one itself is free:
+			// it was either never used or its object has been moved to a lower ID already
+			bytes, err := box.getBytesCopy(oldId)
+			if err != nil {
+				return err
+			}
+
+			var table = &flatbuffers.Table{
+				Bytes: bytes,
+				Pos:   flatbuffers.GetUOffsetT(bytes),
+			}
+			if !table.MutateUint64Slot(idProperty.slot(), newId) {
+				return fmt.Errorf("can't change the ID of %s %d: the stored object has no ID field", box.entity.name,
+					oldId)
+			}
+
+			// remove first so that unique indexes don't see the value twice
+			if err := box.RemoveId(oldId); err != nil {
+				return err
+			}
+
+			if err := cCall(func() C.obx_err {
+				return C.obx_box_put5(box.cBox, C.obx_id(newId), unsafe.Pointer(&bytes[0]), C.size_t(len(bytes)),
+					cPutModeInsert)
+			}); err != nil {
+				return err
+			}
+			box.ObjectBox.auditChange(box.entity.id, "put", newId)
+
+			if remap != nil {
+				remap(oldId, newId)
+			}
+		}
+		return nil
+	})
+	return withOperation(err, "resequence", box.entity.name)
+}
+
+// referencingEntity returns the name of an entity with relations referring to objects of this entity by their ID,
+// i.e. standalone relations of this entity or relations (standalone or to-one) of other entities targeting this one
+func (entity *entity) referencingEntity() string {
+	if len(entity.relations) > 0 {
+		return entity.name
+	}
+
+	for _, other := range entity.objectBox.entitiesById {
+		for _, relation := range other.relations {
+			if relation.targetId == entity.id {
+				return other.name
+			}
+		}
+		for _, property := range other.properties {
+			if property.relationTarget == entity.name {
+				return other.name
+			}
+		}
+	}
+	return ""
+}
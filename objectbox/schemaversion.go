@@ -0,0 +1,134 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ErrSchemaTooNew is returned by Builder.BuildOrError() (wrapped, use errors.Is) if the database has been opened by
+// a newer version of the program with a schema version higher than the one configured by Builder.MaxSchemaVersion().
+var ErrSchemaTooNew = errors.New("the database schema is newer than supported by this program")
+
+// schemaVersionFile is stored in the database directory, next to the database files
+const schemaVersionFile = "schema-version"
+
+// SchemaVersion sets the version of the schema (model) used by this program. It's an application-defined number,
+// increased whenever a new release changes the model in a way older releases must not write to, e.g. to protect
+// the data during rolling deployments where old and new binaries run against the same database.
+//
+// The highest version that has opened the database is stored in the database directory. BuildOrError() refuses to
+// open the database if the stored version is higher than the maximum compatible version of this program (defaults to
+// the given version, see MaxSchemaVersion()) and returns an error matching ErrSchemaTooNew. The version isn't updated
+// when opening the store with ReadOnly() or RecoverMode().
+//
+// Note: the version is kept in a separate "schema-version" file next to the database file (data.mdb), not inside the
+// database. Copying or restoring only the database file (e.g. from a backup) loses it, i.e. the check passes until the
+// next program opening the database stores its version again; copy or back up the whole directory instead.
+func (builder *Builder) SchemaVersion(version uint) *Builder {
+	builder.schemaVersion = &version
+	return builder
+}
+
+// MaxSchemaVersion sets the highest stored schema version this program can still work with, e.g. if the next release
+// is known to only add new entities. Defaults to the version given to SchemaVersion(), which is required to be set.
+func (builder *Builder) MaxSchemaVersion(maxCompatible uint) *Builder {
+	builder.maxSchemaVersion = &maxCompatible
+	return builder
+}
+
+// checkSchemaVersion verifies the version stored in the given directory is compatible with the configured one
+func (builder *Builder) checkSchemaVersion(dir string) error {
+	if builder.schemaVersion == nil {
+		if builder.maxSchemaVersion != nil {
+			return errors.New("MaxSchemaVersion() requires SchemaVersion() to be configured as well")
+		}
+		return nil
+	}
+
+	var maxCompatible = *builder.schemaVersion
+	if builder.maxSchemaVersion != nil {
+		if *builder.maxSchemaVersion < *builder.schemaVersion {
+			return fmt.Errorf("maximum schema version %d is lower than the schema version %d",
+				*builder.maxSchemaVersion, *builder.schemaVersion)
+		}
+		maxCompatible = *builder.maxSchemaVersion
+	}
+
+	stored, err := readSchemaVersion(dir)
+	if err != nil {
+		return err
+	}
+
+	if stored > maxCompatible {
+		return fmt.Errorf("%w: database schema version %d, this program supports up to version %d",
+			ErrSchemaTooNew, stored, maxCompatible)
+	}
+	return nil
+}
+
+// storeSchemaVersion records the configured schema version in the given directory unless a higher one is stored or
+// the store is opened read-only (including the recovery mode)
+func (builder *Builder) storeSchemaVersion(dir string) error {
+	if builder.schemaVersion == nil || builder.readOnly || builder.recoverMode || strings.HasPrefix(dir, "memory:") {
+		return nil
+	}
+
+	stored, err := readSchemaVersion(dir)
+	if err != nil {
+		return err
+	} else if stored >= *builder.schemaVersion {
+		return nil
+	}
+
+	var fileMode os.FileMode = 0644
+	if builder.fileMode != nil {
+		fileMode = *builder.fileMode
+	}
+
+	var contents = strconv.FormatUint(uint64(*builder.schemaVersion), 10) + "\n"
+	if err := ioutil.WriteFile(filepath.Join(dir, schemaVersionFile), []byte(contents), fileMode); err != nil {
+		return fmt.Errorf("can't store the schema version: %s", err)
+	}
+	return nil
+}
+
+// readSchemaVersion returns the schema version stored in the given directory or 0 if there's none
+func readSchemaVersion(dir string) (uint, error) {
+	if strings.HasPrefix(dir, "memory:") {
+		return 0, nil
+	}
+
+	contents, err := ioutil.ReadFile(filepath.Join(dir, schemaVersionFile))
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("can't read the schema version: %s", err)
+	}
+
+	version, err := strconv.ParseUint(strings.TrimSpace(string(contents)), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid schema version stored in %s: %s", filepath.Join(dir, schemaVersionFile), err)
+	}
+	return uint(version), nil
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include <stdlib.h>
+#include "objectbox.h"
+*/
+import "C"
+import (
+	"errors"
+	"runtime"
+	"sync"
+)
+
+// Snapshot is a read transaction held open to read data as it was at a single point in time, see ObjectBox.Snapshot().
+type Snapshot struct {
+	objectBox *ObjectBox
+
+	// functions to execute inside the transaction; closed by Release()
+	requests chan func()
+
+	// closed by the snapshot's goroutine after the transaction has been closed
+	done     chan struct{}
+	closeErr error
+
+	releaseMutex sync.Mutex
+	released     bool
+}
+
+// ErrSnapshotReleased is returned when using a Snapshot after its Release().
+var ErrSnapshotReleased = errors.New("snapshot has been released")
+
+// Snapshot starts a read transaction and keeps it open until Release() is called: all reads executed through the
+// returned handle see the data exactly as it was when the snapshot was taken, regardless of the writes committed since.
+// This is useful for reproducible reports or long-running analyses consisting of many reads.
+//
+// Note: native transactions are bound to a thread so the snapshot keeps a dedicated (locked) OS thread and executes
+// all its reads there, one at a time. It also occupies one of the reader slots (see Builder.MaxReaders()) and prevents
+// the database from reusing the space of data changed since it was taken, so the database file may grow while it's
+// open. Therefore, don't keep snapshots open longer than needed and always Release() them before closing the store.
+func (ob *ObjectBox) Snapshot() (*Snapshot, error) {
+	if err := ob.check(); err != nil {
+		return nil, err
+	}
+
+	var snapshot = &Snapshot{
+		objectBox: ob,
+		requests:  make(chan func()),
+		done:      make(chan struct{}),
+	}
+
+	var started = make(chan error, 1)
+	go snapshot.run(started)
+	if err := <-started; err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+func (snapshot *Snapshot) run(started chan<- error) {
+	defer close(snapshot.done)
+
+	// the transaction, and all reads using it, must be executed on the same thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	var cTxn = C.obx_txn_read(snapshot.objectBox.store)
+	if cTxn == nil {
+		started <- createError()
+		return
+	}
+	var countedReader = snapshot.objectBox.txnOpened(true)
+	started <- nil
+
+	for fn := range snapshot.requests {
+		fn()
+	}
+
+	if rc := C.obx_txn_close(cTxn); rc != 0 {
+		snapshot.closeErr = createError()
+	}
+	snapshot.objectBox.txnClosed(countedReader)
+}
+
+// Run executes the given function inside the snapshot's transaction: reads done by fn (e.g. using a generated box
+// or a Query) see the snapshot's data. Calls are executed one at a time, concurrent ones wait for each other.
+// The function must not call the snapshot methods (that would deadlock), start reads in other goroutines (these
+// wouldn't use the snapshot) nor write any data.
+func (snapshot *Snapshot) Run(fn func() error) (err error) {
+	snapshot.releaseMutex.Lock()
+	if snapshot.released {
+		snapshot.releaseMutex.Unlock()
+		return ErrSnapshotReleased
+	}
+
+	var finished = make(chan struct{})
+	snapshot.requests <- func() {
+		defer close(finished)
+		// RunInReadTx makes the reads use (nest in) the snapshot's transaction and handles panics as usual
+		err = snapshot.objectBox.RunInReadTx(fn)
+	}
+	snapshot.releaseMutex.Unlock()
+
+	<-finished
+	return err
+}
+
+// Get reads a single object from the given box as it was when the snapshot was taken, see Box.Get().
+func (snapshot *Snapshot) Get(box *Box, id uint64) (object interface{}, err error) {
+	err = snapshot.Run(func() error {
+		object, err = box.Get(id)
+		return err
+	})
+	return object, err
+}
+
+// GetAll reads all objects of the given box as they were when the snapshot was taken, see Box.GetAll().
+func (snapshot *Snapshot) GetAll(box *Box) (objects interface{}, err error) {
+	err = snapshot.Run(func() error {
+		objects, err = box.GetAll()
+		return err
+	})
+	return objects, err
+}
+
+// Find executes the query against the data as it was when the snapshot was taken, see Query.Find().
+func (snapshot *Snapshot) Find(query *Query) (objects interface{}, err error) {
+	err = snapshot.Run(func() error {
+		objects, err = query.Find()
+		return err
+	})
+	return objects, err
+}
+
+// Release closes the snapshot's transaction, after waiting for a Run() in progress, if any.
+// It's safe to call Release multiple times; calls after the first one do nothing.
+func (snapshot *Snapshot) Release() error {
+	snapshot.releaseMutex.Lock()
+	if snapshot.released {
+		snapshot.releaseMutex.Unlock()
+		return nil
+	}
+	snapshot.released = true
+	close(snapshot.requests)
+	snapshot.releaseMutex.Unlock()
+
+	<-snapshot.done
+	return snapshot.closeErr
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox
+
+/*
+#include "objectbox.h"
+*/
+import "C"
+import (
+	"fmt"
+	"os"
+	"unsafe"
+)
+
+// Warmup reads through all objects of the given entities (all entities in the model if none are given) to pull the
+// database pages they're stored in into the OS page cache, e.g. right after opening the store in a fresh process so
+// that the first queries don't pay for reading "cold" pages from the disk.
+//
+// The objects are streamed in a separate read transaction per entity without being loaded (nor kept) in Go, so the
+// memory used doesn't depend on the size of the database. Note that this is a best-effort optimization: the OS is free
+// to evict the pages again, e.g. if the database is larger than the available memory, and index pages are only read
+// by the queries using them. Returns an error if an entity isn't in the model or reading fails.
+func (ob *ObjectBox) Warmup(typeIds ...TypeId) error {
+	if err := ob.check(); err != nil {
+		return err
+	}
+
+	if len(typeIds) == 0 {
+		for _, box := range ob.AllBoxes() {
+			typeIds = append(typeIds, box.entity.id)
+		}
+	}
+
+	for _, typeId := range typeIds {
+		if ob.entitiesById[typeId] == nil {
+			return fmt.Errorf("can't warm up entity ID %d: no such entity in the model", typeId)
+		}
+		box, err := ob.box(typeId)
+		if err != nil {
+			return err
+		}
+		if err := box.warmup(); err != nil {
+			return fmt.Errorf("can't warm up entity %s: %s", box.entity.name, err)
+		}
+	}
+	return nil
+}
+
+// warmup visits all objects in the box, touching each memory page of their data
+func (box *Box) warmup() error {
+	var pageSize = os.Getpagesize()
+	var checksum byte // only so that the reads can't be optimized away
+	visitor, err := dataVisitorRegister(func(bytes []byte) bool {
+		for i := 0; i < len(bytes); i += pageSize {
+			checksum ^= bytes[i]
+		}
+		if len(bytes) > 0 {
+			checksum ^= bytes[len(bytes)-1]
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	defer dataVisitorUnregister(visitor)
+
+	err = box.ObjectBox.RunInReadTx(func() error {
+		return cCall(func() C.obx_err {
+			return C.obx_box_visit_all(box.cBox, dataVisitor, unsafe.Pointer(&visitor))
+		})
+	})
+	_ = checksum
+	return err
+}
@@ -17,9 +17,11 @@
 package objectbox_test
 
 import (
+	"context"
 	"github.com/objectbox/objectbox-go/objectbox"
 	"github.com/objectbox/objectbox-go/test/model"
 	"testing"
+	"time"
 
 	"github.com/objectbox/objectbox-go/test/assert"
 )
@@ -126,3 +128,154 @@ func testAsync(t *testing.T, asyncF func(box *model.TestEntityInlineBox) *model.
 	assert.NoErr(t, async.RemoveId(object.Id))
 	waitAndCount(1)
 }
+
+func TestBoxGetAfterAsync(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+
+	var box = model.BoxForTestEntityInline(env.ObjectBox)
+
+	var object = &model.TestEntityInline{BaseWithValue: &model.BaseWithValue{Value: 4.7}}
+	id, err := box.Async().Put(object)
+	assert.NoErr(t, err)
+
+	read, err := box.GetAfterAsync(id)
+	assert.NoErr(t, err)
+	assert.True(t, read != nil)
+	assert.Eq(t, object.Value, read.(*model.TestEntityInline).Value)
+}
+
+func TestBoxPutAsyncBlocking(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+
+	var box = model.BoxForTestEntityInline(env.ObjectBox)
+
+	var ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	const count = 1000
+	for i := 0; i < count; i++ {
+		var object = &model.TestEntityInline{BaseWithValue: &model.BaseWithValue{Value: float64(i)}}
+		id, err := box.PutAsyncBlocking(ctx, object)
+		assert.NoErr(t, err)
+		assert.Eq(t, id, object.Id)
+	}
+
+	assert.NoErr(t, env.ObjectBox.AwaitAsyncCompletion())
+	c, err := box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(count), c)
+
+	// errors other than a full queue are returned immediately, e.g. entities with relations aren't supported
+	_, err = env.Box.PutAsyncBlocking(ctx, model.Entity47())
+	assert.Err(t, err)
+}
+
+func TestBoxAwaitAsyncCompletion(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+
+	var box = model.BoxForTestEntityInline(env.ObjectBox)
+	var object = &model.TestEntityInline{BaseWithValue: &model.BaseWithValue{Value: 4.7}}
+	id, err := box.PutAsync(object)
+	assert.NoErr(t, err)
+
+	assert.NoErr(t, box.AwaitAsyncCompletion())
+
+	read, err := box.Get(id)
+	assert.NoErr(t, err)
+	assert.True(t, read != nil)
+	assert.Eq(t, 4.7, read.Value)
+}
+
+func TestBoxRemoveAsync(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+
+	var box = model.BoxForTestEntityInline(env.ObjectBox)
+	var objects = make([]*model.TestEntityInline, 10)
+	for i := range objects {
+		objects[i] = &model.TestEntityInline{BaseWithValue: &model.BaseWithValue{Value: float64(i)}}
+	}
+	ids, err := box.PutMany(objects)
+	assert.NoErr(t, err)
+
+	assert.NoErr(t, box.RemoveAsync(ids[0]))
+	assert.NoErr(t, box.RemoveAsyncMany(ids[1:5]...))
+	assert.NoErr(t, box.Async().RemoveIds(ids[5]))
+	assert.NoErr(t, box.RemoveAsyncMany())
+
+	// removing a missing object fails silently
+	assert.NoErr(t, box.RemoveAsync(ids[0]))
+
+	assert.NoErr(t, box.AwaitAsyncCompletion())
+
+	count, err := box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(4), count)
+
+	contains, err := box.ContainsIds(ids[6:]...)
+	assert.NoErr(t, err)
+	assert.True(t, contains)
+
+	for _, id := range ids[:6] {
+		read, err := box.Get(id)
+		assert.NoErr(t, err)
+		assert.True(t, read == nil)
+	}
+}
+
+func TestCloseGraceful(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+
+	var box = model.BoxForTestEntityInline(env.ObjectBox)
+	var count = 1000
+	for i := 0; i < count; i++ {
+		_, err := box.PutAsync(&model.TestEntityInline{BaseWithValue: &model.BaseWithValue{Value: float64(i)}})
+		assert.NoErr(t, err)
+	}
+
+	assert.NoErr(t, env.ObjectBox.CloseGraceful(10*time.Second))
+
+	// closed already
+	assert.Eq(t, objectbox.ErrStoreClosed, env.ObjectBox.CloseGraceful(time.Second))
+
+	// all the enqueued objects have been written
+	ob, err := objectbox.NewBuilder().Directory(env.Directory).Model(model.ObjectBoxModel()).Build()
+	assert.NoErr(t, err)
+	defer ob.Close()
+
+	actual, err := model.BoxForTestEntityInline(ob).Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(count), actual)
+}
+
+func TestCloseGracefulTimeout(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+
+	var box = model.BoxForTestEntityInline(env.ObjectBox)
+	var count = 10000
+	for i := 0; i < count; i++ {
+		_, err := box.PutAsync(&model.TestEntityInline{BaseWithValue: &model.BaseWithValue{Value: float64(i)}})
+		assert.NoErr(t, err)
+	}
+
+	// the queue can't (usually) be processed in time, the store stays open then...
+	if err := env.ObjectBox.CloseGraceful(time.Nanosecond); err != nil {
+		assert.NoErr(t, env.ObjectBox.Ping())
+
+		// ... and closing it waits for the waiting started by CloseGraceful() so the queue is still processed
+		env.ObjectBox.Close()
+	}
+
+	ob, err := objectbox.NewBuilder().Directory(env.Directory).Model(model.ObjectBoxModel()).Build()
+	assert.NoErr(t, err)
+	defer ob.Close()
+
+	actual, err := model.BoxForTestEntityInline(ob).Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(count), actual)
+}
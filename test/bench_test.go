@@ -21,7 +21,9 @@ import (
 	"github.com/objectbox/objectbox-go/objectbox"
 	"github.com/objectbox/objectbox-go/test/performance/perf"
 	"os"
+	"strings"
 	"testing"
+	"time"
 )
 
 // Implements simple benchmarks as an alternative to the "test/performance". However, it doesn't achieve the optimal
@@ -114,6 +116,100 @@ func BenchmarkPutMany(b *testing.B) {
 	})
 }
 
+// BenchmarkPutManyWide is the same as BenchmarkPutMany but with objects of a few kilobytes each, i.e. ones that don't
+// fit the initial flatbuffers builder size; compare the reported allocations to see the effect of builder reuse: the
+// flattening allocates a few times per batch instead of once per object.
+func BenchmarkPutManyWide(b *testing.B) {
+	var env = newBenchEnv(b)
+	defer env.close()
+	var inserts = prepareBenchData(b, bulkCount())
+
+	b.StopTimer()
+	for i, object := range inserts {
+		object.String = strings.Repeat(fmt.Sprintf("wide entity no. %d;", i), 200)
+	}
+	b.StartTimer()
+
+	b.Run(fmt.Sprintf("count=%v", bulkCount()), func(b *testing.B) {
+		b.SetBytes(int64(bulkCount())) // report speed in MB/s where one B is one object
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			_, err := env.box.PutMany(inserts)
+			env.check(err)
+
+			b.StopTimer()
+			env.box.RemoveAll()
+			b.StartTimer()
+		}
+	})
+}
+
+// reflectionBinding hides the optional ObjectSliceBinding implemented by perf.EntityBinding so PutMany uses reflection
+type reflectionBinding struct {
+	objectbox.ObjectBinding
+}
+
+// newReflectionBenchEnv creates an environment with the perf.Entity registered using reflectionBinding
+func newReflectionBenchEnv(b *testing.B) (*benchmarkEnv, *objectbox.Box) {
+	b.StopTimer()
+	var model = objectbox.NewModel()
+	model.GeneratorVersion(6)
+	model.RegisterBinding(reflectionBinding{perf.EntityBinding})
+	model.LastEntityId(1, perf.EntityBinding.Uid)
+
+	var env = &benchmarkEnv{dbName: "testdata", b: b}
+	var err error
+	env.ob, err = objectbox.NewBuilder().Directory(env.dbName).Model(model).Build()
+	env.check(err)
+	b.StartTimer()
+	return env, env.ob.InternalBox(perf.EntityBinding.Id)
+}
+
+// BenchmarkPutManyReflection is the same as BenchmarkPutMany but with a binding that doesn't implement
+// ObjectSliceBinding, to compare the reflection-based access to the slice.
+func BenchmarkPutManyReflection(b *testing.B) {
+	var env, box = newReflectionBenchEnv(b)
+	defer env.close()
+
+	var inserts = prepareBenchData(b, bulkCount())
+
+	b.Run(fmt.Sprintf("count=%v", bulkCount()), func(b *testing.B) {
+		b.SetBytes(int64(bulkCount())) // report speed in MB/s where one B is one object
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			_, err := box.PutMany(inserts)
+			env.check(err)
+
+			b.StopTimer()
+			env.check(box.RemoveAll())
+			b.StartTimer()
+		}
+	})
+}
+
+// BenchmarkPutConcurrent puts single objects from multiple goroutines, with and without write coalescing
+func BenchmarkPutConcurrent(b *testing.B) {
+	var env = newBenchEnv(b)
+	defer env.close()
+
+	var run = func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetParallelism(8)
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_, err := env.box.Put(&perf.Entity{String: "concurrent", Int64: 42})
+				env.check(err)
+			}
+		})
+	}
+
+	b.Run("direct", run)
+
+	env.check(env.ob.EnableWriteCoalescing(time.Millisecond))
+	b.Run("coalesced", run)
+	env.ob.DisableWriteCoalescing()
+}
+
 func BenchmarkGetAll(b *testing.B) {
 	var env = newBenchEnv(b)
 	defer env.close()
@@ -138,6 +234,97 @@ func BenchmarkGetAll(b *testing.B) {
 	})
 }
 
+// BenchmarkGetAllFallback is the same as BenchmarkGetAll but with a binding that doesn't implement
+// SliceAppenderBinding, to compare building the slice using AppendToSlice() for each object.
+func BenchmarkGetAllFallback(b *testing.B) {
+	var env, box = newReflectionBenchEnv(b)
+	defer env.close()
+	var inserts = prepareBenchData(b, bulkCount())
+
+	b.StopTimer()
+	_, err := box.PutMany(inserts)
+	env.check(err)
+	b.StartTimer()
+
+	b.Run("GetAll", func(b *testing.B) {
+		b.SetBytes(int64(bulkCount())) // report speed in MB/s where one B is one object
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			objects, err := box.GetAll()
+			if err != nil {
+				b.Error(err)
+			} else if len(objects.([]*perf.Entity)) != bulkCount() {
+				b.Errorf("invalid number of objects received: %v instead of %v", len(objects.([]*perf.Entity)),
+					bulkCount())
+			}
+		}
+	})
+}
+
+// BenchmarkSliceAppend compares building the slice of objects read by GetAll() & co. using the binding's typed
+// SliceAppenderBinding to the AppendToSlice() fallback, without the database access
+func BenchmarkSliceAppend(b *testing.B) {
+	var objects = prepareBenchData(b, bulkCount())
+
+	b.Run("SliceAppender", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			var add, result = perf.EntityBinding.NewSliceAppender(len(objects))
+			for _, object := range objects {
+				add(object)
+			}
+			if len(result().([]*perf.Entity)) != len(objects) {
+				b.Error("invalid number of objects")
+			}
+		}
+	})
+
+	b.Run("AppendToSlice", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			var slice = perf.EntityBinding.MakeSlice(len(objects))
+			for _, object := range objects {
+				slice = perf.EntityBinding.AppendToSlice(slice, object)
+			}
+			if len(slice.([]*perf.Entity)) != len(objects) {
+				b.Error("invalid number of objects")
+			}
+		}
+	})
+}
+
+// BenchmarkGetBytes compares reading raw data of many objects in a single transaction to separate reads
+func BenchmarkGetBytes(b *testing.B) {
+	var env = newBenchEnv(b)
+	defer env.close()
+	var inserts = prepareBenchData(b, bulkCount())
+
+	b.StopTimer()
+	ids, err := env.box.PutMany(inserts)
+	env.check(err)
+	b.StartTimer()
+
+	b.Run("GetBytesMany", func(b *testing.B) {
+		b.SetBytes(int64(bulkCount())) // report speed in MB/s where one B is one object
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			_, err := env.box.GetBytesMany(ids...)
+			env.check(err)
+		}
+	})
+
+	b.Run("GetBytes", func(b *testing.B) {
+		b.SetBytes(int64(bulkCount())) // report speed in MB/s where one B is one object
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			for _, id := range ids {
+				_, err := env.box.GetBytes(id)
+				env.check(err)
+			}
+		}
+	})
+}
+
 func BenchmarkRemoveAll(b *testing.B) {
 	var env = newBenchEnv(b)
 	defer env.close()
@@ -0,0 +1,148 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/google/flatbuffers/go"
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/objectbox/fbutils"
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model/iot"
+)
+
+// note is stored using the hand-written noteBinding, registered at runtime; the Body field is stored as the property
+// "Text"
+type note struct {
+	Id   uint64
+	Body string `objectbox:"name:Text"`
+}
+
+const noteEntityId = 3
+
+type noteBinding struct{}
+
+func (noteBinding) AddToModel(model *objectbox.Model) {
+	model.Entity("Note", noteEntityId, 6125541707601651781)
+	model.Property("Id", 6, 1, 2939220280694943568)
+	model.PropertyFlags(1)
+	model.Property("Text", 9, 2, 8416893838032228611)
+	model.EntityLastPropertyId(2, 8416893838032228611)
+}
+
+func (noteBinding) GetId(object interface{}) (uint64, error) {
+	return object.(*note).Id, nil
+}
+
+func (noteBinding) SetId(object interface{}, id uint64) error {
+	object.(*note).Id = id
+	return nil
+}
+
+func (noteBinding) PutRelated(ob *objectbox.ObjectBox, object interface{}, id uint64) error {
+	return nil
+}
+
+func (noteBinding) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
+	var offsetText = fbutils.CreateStringOffset(fbb, object.(*note).Body)
+
+	fbb.StartObject(2)
+	fbutils.SetUint64Slot(fbb, 0, id)
+	fbutils.SetUOffsetTSlot(fbb, 1, offsetText)
+	return nil
+}
+
+func (noteBinding) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}, error) {
+	if len(bytes) == 0 {
+		return nil, errors.New("no data received")
+	}
+
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+	return &note{
+		Id:   table.GetUint64Slot(4, 0),
+		Body: fbutils.GetStringSlot(table, 6),
+	}, nil
+}
+
+func (noteBinding) MakeSlice(capacity int) interface{} {
+	return make([]*note, 0, capacity)
+}
+
+func (noteBinding) AppendToSlice(slice interface{}, object interface{}) interface{} {
+	if object == nil {
+		return append(slice.([]*note), nil)
+	}
+	return append(slice.([]*note), object.(*note))
+}
+
+func (noteBinding) GeneratorVersion() int {
+	return objectbox.BindingVersion
+}
+
+func TestBuilderRegisterEntity(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	ob, err := objectbox.NewBuilder().Directory(dir).Model(iot.ObjectBoxModel()).RegisterEntity(noteBinding{}).
+		BuildOrError()
+	assert.NoErr(t, err)
+	defer ob.Close()
+
+	var box = ob.InternalBox(noteEntityId)
+	id, err := box.Put(&note{Body: "hand-written"})
+	assert.NoErr(t, err)
+
+	object, err := box.Get(id)
+	assert.NoErr(t, err)
+	assert.Eq(t, &note{Id: id, Body: "hand-written"}, object.(*note))
+
+	var text = objectbox.PropertyString{BaseProperty: &objectbox.BaseProperty{
+		Id: 2, Entity: &objectbox.Entity{Id: noteEntityId}}}
+	slice, err := box.Query(text.Equals("hand-written", true)).Find()
+	assert.NoErr(t, err)
+	assert.Eq(t, 1, len(slice.([]*note)))
+
+	// partial updates find the field by its property name
+	assert.NoErr(t, box.PutMerge(id, map[string]interface{}{"Text": "merged"}))
+	assert.Err(t, box.PutMerge(id, map[string]interface{}{"Body": "merged"}))
+	updated, err := box.Query().SetProperty(text, "set")
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1), updated)
+	object, err = box.Get(id)
+	assert.NoErr(t, err)
+	assert.Eq(t, "set", object.(*note).Body)
+
+	// the generated entities are still available
+	_, err = iot.BoxForEvent(ob).Put(&iot.Event{Uid: "1"})
+	assert.NoErr(t, err)
+
+	// an entity ID that's already used
+	_, err = objectbox.NewBuilder().Directory(dir).Model(iot.ObjectBoxModel()).RegisterEntity(noteBinding{}).
+		RegisterEntity(noteBinding{}).BuildOrError()
+	assert.Err(t, err)
+
+	_, err = objectbox.NewBuilder().Directory(dir).RegisterEntity(noteBinding{}).BuildOrError()
+	assert.Err(t, err)
+}
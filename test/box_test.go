@@ -17,11 +17,23 @@
 package objectbox_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/objectbox/objectbox-go/objectbox"
 	"github.com/objectbox/objectbox-go/test/assert"
 	"github.com/objectbox/objectbox-go/test/model"
 	"github.com/objectbox/objectbox-go/test/model/iot"
+	"io/ioutil"
+	"math"
+	"net"
 	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestBox(t *testing.T) {
@@ -100,6 +112,36 @@ func TestUnique(t *testing.T) {
 	assert.Eq(t, uint64(1), count)
 }
 
+func TestStorageError(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	id, err := box.Put(&iot.Event{Uid: "duplicate-uid"})
+	assert.NoErr(t, err)
+
+	_, err = box.Put(&iot.Event{Uid: "duplicate-uid"})
+	var storageErr *objectbox.StorageError
+	assert.True(t, errors.As(err, &storageErr))
+	assert.Eq(t, 10201, storageErr.Code) // OBX_ERROR_UNIQUE_VIOLATED
+	assert.Eq(t, "put", storageErr.Op)
+	assert.Eq(t, "Event", storageErr.Entity)
+	assert.Eq(t, storageErr.Message, err.Error())
+
+	_, err = box.Insert(&iot.Event{Id: id, Uid: "another-uid"})
+	assert.True(t, errors.As(err, &storageErr))
+	assert.Eq(t, 10210, storageErr.Code) // OBX_ERROR_ID_ALREADY_EXISTS
+
+	err = box.Update(&iot.Event{Id: id + 1, Uid: "another-uid"})
+	assert.True(t, errors.As(err, &storageErr))
+	assert.Eq(t, 10211, storageErr.Code) // OBX_ERROR_ID_NOT_FOUND
+
+	// also available through PutManyError
+	_, err = box.PutMany([]*iot.Event{{Uid: "new-uid"}, {Uid: "duplicate-uid"}})
+	assert.True(t, errors.As(err, &storageErr))
+	assert.Eq(t, 10201, storageErr.Code)
+}
+
 func TestBoxBulk(t *testing.T) {
 	env := iot.NewTestEnv()
 	defer env.Close()
@@ -255,6 +297,464 @@ func TestBoxUpdate(t *testing.T) {
 	assert.Eq(t, object, objectRead)
 }
 
+func TestBoxPutMerge(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	id, err := box.Put(&iot.Event{Uid: "1", Device: "dev", Date: 100, Picture: []byte{1}})
+	assert.NoErr(t, err)
+
+	assert.NoErr(t, box.PutMerge(id, map[string]interface{}{"Device": "new device", "Date": 200}))
+	event, err := box.Get(id)
+	assert.NoErr(t, err)
+	assert.Eq(t, iot.Event{Id: id, Uid: "1", Device: "new device", Date: 200, Picture: []byte{1}}, *event)
+
+	// nothing is written if any of the updates is invalid
+	assert.Err(t, box.PutMerge(id, map[string]interface{}{"Device": "other", "Unknown": 1}))
+	assert.Err(t, box.PutMerge(id, map[string]interface{}{"Device": "other", "Date": "invalid"}))
+	assert.Err(t, box.PutMerge(id, map[string]interface{}{"Id": uint64(5)}))
+	event, err = box.Get(id)
+	assert.NoErr(t, err)
+	assert.Eq(t, "new device", event.Device)
+
+	assert.Eq(t, objectbox.ErrNotFound, box.PutMerge(id+1, map[string]interface{}{"Device": "other"}))
+	count, err := box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1), count)
+
+	// no updates - just verifies the object exists
+	assert.NoErr(t, box.PutMerge(id, nil))
+}
+
+func TestBoxPutMergeNumbers(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+
+	id, err := env.Box.Put(&model.Entity{Uint64: 5, Float32: 1})
+	assert.NoErr(t, err)
+
+	assert.NoErr(t, env.Box.PutMerge(id, map[string]interface{}{"Uint64": 7, "Float32": 0.1}))
+	entity, err := env.Box.Get(id)
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(7), entity.Uint64)
+	assert.Eq(t, float32(0.1), entity.Float32)
+
+	// negative values don't fit unsigned fields and nothing is written
+	assert.Err(t, env.Box.PutMerge(id, map[string]interface{}{"Uint64": -1, "Float32": 0.5}))
+	assert.Err(t, env.Box.PutMerge(id, map[string]interface{}{"Uint64": int64(-1)}))
+	assert.Err(t, env.Box.PutMerge(id, map[string]interface{}{"Float32": math.MaxFloat64}))
+	entity, err = env.Box.Get(id)
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(7), entity.Uint64)
+	assert.Eq(t, float32(0.1), entity.Float32)
+}
+
+func TestBoxIncrementProperty(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	id, err := box.Put(&iot.Event{Uid: "counter", Date: 10})
+	assert.NoErr(t, err)
+
+	value, err := box.IncrementProperty(id, "Date", -3)
+	assert.NoErr(t, err)
+	assert.Eq(t, int64(7), value)
+
+	// concurrent increments don't lose any updates
+	const goroutines = 8
+	const increments = 50
+	var wg sync.WaitGroup
+	var errs = make(chan error, goroutines*increments)
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < increments; i++ {
+				if _, err := box.IncrementProperty(id, "Date", 1); err != nil {
+					errs <- err
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.NoErr(t, err)
+	}
+
+	event, err := box.Get(id)
+	assert.NoErr(t, err)
+	assert.Eq(t, int64(7+goroutines*increments), event.Date)
+	assert.Eq(t, "counter", event.Uid)
+
+	// invalid properties, overflows and missing objects don't change anything
+	_, err = box.IncrementProperty(id, "Device", 1)
+	assert.Err(t, err)
+	_, err = box.IncrementProperty(id, "Unknown", 1)
+	assert.Err(t, err)
+	_, err = box.IncrementProperty(id, "Id", 1)
+	assert.Err(t, err)
+	_, err = box.IncrementProperty(id, "Date", math.MaxInt64)
+	assert.Err(t, err)
+	_, err = box.IncrementProperty(id+1, "Date", 1)
+	assert.Eq(t, objectbox.ErrNotFound, err)
+
+	event, err = box.Get(id)
+	assert.NoErr(t, err)
+	assert.Eq(t, int64(7+goroutines*increments), event.Date)
+}
+
+func TestBoxIncrementPropertyUnsigned(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+
+	id, err := env.Box.Put(&model.Entity{Uint64: 1, Uint8: 254})
+	assert.NoErr(t, err)
+
+	value, err := env.Box.IncrementProperty(id, "Uint64", -1)
+	assert.NoErr(t, err)
+	assert.Eq(t, int64(0), value)
+
+	// neither below zero nor above the range of the field type
+	_, err = env.Box.IncrementProperty(id, "Uint64", -1)
+	assert.Err(t, err)
+	_, err = env.Box.IncrementProperty(id, "Uint8", 2)
+	assert.Err(t, err)
+
+	entity, err := env.Box.Get(id)
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(0), entity.Uint64)
+	assert.Eq(t, uint8(254), entity.Uint8)
+}
+
+func TestBoxAfterReopen(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+
+	var box = env.Box.Box
+	id, err := box.Put(model.Entity47())
+	assert.NoErr(t, err)
+
+	env.ObjectBox.Close()
+
+	// the box and its async box refuse to work instead of accessing the closed store
+	_, err = box.Get(id)
+	assert.Eq(t, objectbox.ErrStoreClosed, err)
+	_, err = box.Put(model.Entity47())
+	assert.Eq(t, objectbox.ErrStoreClosed, err)
+	_, err = box.Count()
+	assert.Eq(t, objectbox.ErrStoreClosed, err)
+	_, err = box.Async().Put(model.Entity47())
+	assert.Eq(t, objectbox.ErrStoreClosed, err)
+	_, err = box.Reacquire(env.ObjectBox)
+	assert.Eq(t, objectbox.ErrStoreClosed, err)
+
+	ob, err := objectbox.NewBuilder().Directory(env.Directory).Model(model.ObjectBoxModel()).BuildOrError()
+	assert.NoErr(t, err)
+	defer ob.Close()
+
+	// the old box stays invalid, the reacquired one works with the reopened store
+	_, err = box.Count()
+	assert.Eq(t, objectbox.ErrStoreClosed, err)
+
+	reacquired, err := box.Reacquire(ob)
+	assert.NoErr(t, err)
+	assert.True(t, reacquired == model.BoxForEntity(ob).Box)
+	assert.Eq(t, box.EntityId(), reacquired.EntityId())
+
+	object, err := reacquired.Get(id)
+	assert.NoErr(t, err)
+	assert.Eq(t, id, object.(*model.Entity).Id)
+
+	_, err = reacquired.Put(model.Entity47())
+	assert.NoErr(t, err)
+	count, err := reacquired.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(2), count)
+
+	// a store with a different model doesn't have the entity
+	iotEnv := iot.NewTestEnv()
+	defer iotEnv.Close()
+	_, err = box.Reacquire(iotEnv.ObjectBox)
+	assert.Err(t, err)
+}
+
+func TestBoxCopyTo(t *testing.T) {
+	source := iot.NewTestEnv()
+	defer source.Close()
+	sourceBox := iot.BoxForEvent(source.ObjectBox)
+
+	dest := iot.NewTestEnv()
+	defer dest.Close()
+	destBox := iot.BoxForEvent(dest.ObjectBox)
+
+	// more than a single batch, with gaps in the IDs
+	const count = 2500
+	var events = make([]*iot.Event, count)
+	for i := range events {
+		events[i] = &iot.Event{Uid: fmt.Sprintf("%d", i), Device: "dev", Date: int64(i), Picture: []byte{byte(i)}}
+	}
+	ids, err := sourceBox.PutMany(events)
+	assert.NoErr(t, err)
+	assert.NoErr(t, sourceBox.RemoveId(ids[10]))
+
+	// an object with the same ID in the destination is overwritten
+	_, err = destBox.Put(&iot.Event{Uid: "old", Device: "old"})
+	assert.NoErr(t, err)
+
+	copied, err := sourceBox.CopyTo(destBox.Box)
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(count-1), copied)
+
+	destCount, err := destBox.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(count-1), destCount)
+
+	for _, i := range []int{0, 11, 1000, count - 1} {
+		event, err := destBox.Get(ids[i])
+		assert.NoErr(t, err)
+		assert.Eq(t, *events[i], *event)
+	}
+	event, err := destBox.Get(ids[10])
+	assert.NoErr(t, err)
+	assert.True(t, event == nil)
+
+	// new objects in the destination get IDs after the copied ones
+	id, err := destBox.Put(&iot.Event{Uid: "new"})
+	assert.NoErr(t, err)
+	assert.True(t, id > ids[count-1])
+
+	// the entity must match and the store must differ
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+	_, err = sourceBox.CopyTo(env.Box.Box)
+	assert.Err(t, err)
+	_, err = sourceBox.CopyTo(sourceBox.Box)
+	assert.Err(t, err)
+	_, err = sourceBox.CopyTo(iot.BoxForReading(dest.ObjectBox).Box)
+	assert.Err(t, err)
+}
+
+func TestBoxPostLoad(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	ids, err := box.PutMany([]*iot.Event{{Uid: "1", Device: "a"}, {Uid: "2", Device: "b"}})
+	assert.NoErr(t, err)
+
+	// the hook derives a field on all read paths
+	box.SetPostLoad(func(object interface{}) error {
+		var event = object.(*iot.Event)
+		event.Device = event.Device + "-" + event.Uid
+		return nil
+	})
+
+	event, err := box.Get(ids[0])
+	assert.NoErr(t, err)
+	assert.Eq(t, "a-1", event.Device)
+
+	events, err := box.GetMany(ids...)
+	assert.NoErr(t, err)
+	assert.Eq(t, "b-2", events[1].Device)
+
+	events, err = box.GetAll()
+	assert.NoErr(t, err)
+	assert.Eq(t, 2, len(events))
+	assert.Eq(t, "a-1", events[0].Device)
+
+	events, err = box.Query(iot.Event_.Uid.Equals("2", true)).Find()
+	assert.NoErr(t, err)
+	assert.Eq(t, 1, len(events))
+	assert.Eq(t, "b-2", events[0].Device)
+
+	// the stored data isn't affected
+	bytesEvent, err := box.GetBytes(ids[0])
+	assert.NoErr(t, err)
+	assert.True(t, len(bytesEvent) > 0)
+	count, err := box.Query(iot.Event_.Device.Equals("a", true)).Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1), count)
+
+	// an error aborts the read
+	var errHook = errors.New("can't decrypt")
+	box.SetPostLoad(func(object interface{}) error {
+		if object.(*iot.Event).Uid == "2" {
+			return errHook
+		}
+		return nil
+	})
+
+	_, err = box.Get(ids[0])
+	assert.NoErr(t, err)
+	_, err = box.Get(ids[1])
+	assert.True(t, errors.Is(err, errHook))
+	_, err = box.GetAll()
+	assert.True(t, errors.Is(err, errHook))
+	_, err = box.Query().Find()
+	assert.True(t, errors.Is(err, errHook))
+
+	box.SetPostLoad(nil)
+	events, err = box.GetAll()
+	assert.NoErr(t, err)
+	assert.Eq(t, "b", events[1].Device)
+}
+
+func TestBoxPreSave(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	// the hook "encrypts" the device and counts the calls per object
+	var mutex sync.Mutex
+	var calls = make(map[string]int)
+	box.SetPreSave(func(object interface{}) error {
+		var event = object.(*iot.Event)
+		if event.Uid == "invalid" {
+			return errors.New("invalid event")
+		}
+		mutex.Lock()
+		calls[event.Uid]++
+		mutex.Unlock()
+		event.Device = "enc:" + event.Device
+		return nil
+	})
+	var callsOf = func(uid string) int {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return calls[uid]
+	}
+	var storedDevice = func(id uint64) string {
+		event, err := box.Get(id)
+		assert.NoErr(t, err)
+		return event.Device
+	}
+
+	// Put
+	id, err := box.Put(&iot.Event{Uid: "put", Device: "a"})
+	assert.NoErr(t, err)
+	assert.Eq(t, 1, callsOf("put"))
+	assert.Eq(t, "enc:a", storedDevice(id))
+
+	// PutMany (PutAll) - once per object
+	ids, err := box.PutMany([]*iot.Event{{Uid: "many1", Device: "b"}, {Uid: "many2", Device: "c"}})
+	assert.NoErr(t, err)
+	assert.Eq(t, 1, callsOf("many1"))
+	assert.Eq(t, 1, callsOf("many2"))
+	assert.Eq(t, "enc:c", storedDevice(ids[1]))
+
+	// PutAsync
+	id, err = box.Async().Put(&iot.Event{Uid: "async", Device: "d"})
+	assert.NoErr(t, err)
+	assert.NoErr(t, box.AwaitAsyncCompletion())
+	assert.Eq(t, 1, callsOf("async"))
+	assert.Eq(t, "enc:d", storedDevice(id))
+
+	// coalesced puts, including the fallback after a failed transaction (a unique constraint violation)
+	assert.NoErr(t, env.ObjectBox.EnableWriteCoalescing(20*time.Millisecond))
+	var wg sync.WaitGroup
+	for _, uid := range []string{"coalesced", "put"} {
+		wg.Add(1)
+		go func(uid string) {
+			defer wg.Done()
+			_, _ = box.Put(&iot.Event{Uid: uid, Device: "e"})
+		}(uid)
+	}
+	wg.Wait()
+	env.ObjectBox.DisableWriteCoalescing()
+	assert.Eq(t, 1, callsOf("coalesced"))
+	assert.Eq(t, 2, callsOf("put"))
+
+	// an error aborts the write
+	count, err := box.Count()
+	assert.NoErr(t, err)
+	_, err = box.Put(&iot.Event{Uid: "invalid"})
+	assert.Err(t, err)
+	_, err = box.PutMany([]*iot.Event{{Uid: "many3"}, {Uid: "invalid"}})
+	var putManyErr *objectbox.PutManyError
+	assert.True(t, errors.As(err, &putManyErr))
+	assert.Eq(t, 1, putManyErr.Index)
+	_, err = box.Async().Put(&iot.Event{Uid: "invalid"})
+	assert.Err(t, err)
+	assert.NoErr(t, box.AwaitAsyncCompletion())
+	countAfter, err := box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, count, countAfter)
+
+	box.SetPreSave(nil)
+	id, err = box.Put(&iot.Event{Uid: "plain", Device: "f"})
+	assert.NoErr(t, err)
+	assert.Eq(t, "f", storedDevice(id))
+}
+
+func TestBoxPutAllReportInserts(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	var existing = &iot.Event{Uid: "existing", Device: "old"}
+	_, err := box.Put(existing)
+	assert.NoErr(t, err)
+
+	existing.Device = "updated"
+	var events = []*iot.Event{{Uid: "new1"}, existing, {Uid: "new2"}}
+	ids, inserted, err := box.PutAllReportInserts(events)
+	assert.NoErr(t, err)
+	assert.Eq(t, []bool{true, false, true}, inserted)
+	assert.Eq(t, []uint64{events[0].Id, existing.Id, events[2].Id}, ids)
+
+	count, err := box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(3), count)
+	stored, err := box.Get(existing.Id)
+	assert.NoErr(t, err)
+	assert.Eq(t, "updated", stored.Device)
+
+	// on failure (a unique constraint violation), nothing is reported and the transaction is rolled back
+	ids, inserted, err = box.PutAllReportInserts([]*iot.Event{{Uid: "new3"}, {Uid: "new1"}})
+	assert.Err(t, err)
+	assert.True(t, ids == nil && inserted == nil)
+	count, err = box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(3), count)
+
+	ids, inserted, err = box.PutAllReportInserts([]*iot.Event{})
+	assert.NoErr(t, err)
+	assert.Eq(t, 0, len(ids))
+	assert.Eq(t, 0, len(inserted))
+}
+
+func TestBoxPutReportMode(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+
+	var object = model.Entity47()
+	id, wasUpdate, err := env.Box.PutReportMode(object)
+	assert.NoErr(t, err)
+	assert.True(t, !wasUpdate)
+	assert.Eq(t, id, object.Id)
+
+	object.String = "foo"
+	id, wasUpdate, err = env.Box.PutReportMode(object)
+	assert.NoErr(t, err)
+	assert.True(t, wasUpdate)
+	assert.Eq(t, object.Id, id)
+
+	// a new object with an explicitly given ID is an insert as well
+	object = model.Entity47()
+	object.Id = id + 100
+	id, wasUpdate, err = env.Box.PutReportMode(object)
+	assert.NoErr(t, err)
+	assert.True(t, !wasUpdate)
+	assert.Eq(t, object.Id, id)
+
+	count, err := env.Box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(2), count)
+}
+
 func TestBoxCount(t *testing.T) {
 	var env = model.NewTestEnv(t)
 	defer env.Close()
@@ -376,3 +876,1449 @@ func TestBoxGetMany(t *testing.T) {
 	assert.Eq(t, 1, len(objects))
 	assert.True(t, objects[0].Id == 1)
 }
+
+func TestBoxGetIdRange(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+
+	env.Populate(10)
+
+	// the ID condition is evaluated on the primary key, check it's described as such
+	query, err := env.Box.QueryIdRange(3, 6)
+	assert.NoErr(t, err)
+	desc, err := query.DescribeParams()
+	assert.NoErr(t, err)
+	assert.Eq(t, "Id between 3 and 6", desc)
+	assert.NoErr(t, query.Close())
+
+	objects, err := env.Box.GetIdRange(3, 6)
+	assert.NoErr(t, err)
+	var slice = objects.([]*model.Entity)
+	assert.Eq(t, 4, len(slice))
+	for i, object := range slice {
+		assert.Eq(t, uint64(3+i), object.Id)
+	}
+
+	objects, err = env.Box.GetIdRange(11, 20)
+	assert.NoErr(t, err)
+	assert.Eq(t, 0, len(objects.([]*model.Entity)))
+
+	// the upper bound is limited to the ID range
+	objects, err = env.Box.GetIdRange(8, math.MaxUint64)
+	assert.NoErr(t, err)
+	assert.Eq(t, 3, len(objects.([]*model.Entity)))
+
+	query, err = env.Box.QueryIdRange(8, math.MaxUint64)
+	assert.NoErr(t, err)
+	desc, err = query.DescribeParams()
+	assert.NoErr(t, err)
+	assert.Eq(t, fmt.Sprintf("Id between 8 and %d", math.MaxInt64), desc)
+	assert.NoErr(t, query.Close())
+
+	_, err = env.Box.GetIdRange(6, 3)
+	assert.Err(t, err)
+	_, err = env.Box.GetIdRange(math.MaxUint64, math.MaxUint64)
+	assert.Err(t, err)
+}
+
+func TestBoxPutManySliceBinding(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+
+	// Entity implements the optional interface, TestEntityInline is accessed using reflection
+	var _ objectbox.ObjectSliceBinding = model.EntityBinding
+	var _, isSliceBinding = interface{}(model.TestEntityInlineBinding).(objectbox.ObjectSliceBinding)
+	assert.True(t, !isSliceBinding)
+
+	ids, err := env.Box.PutMany([]*model.Entity{model.Entity47(), model.Entity47()})
+	assert.NoErr(t, err)
+	assert.Eq(t, 2, len(ids))
+
+	var inlineBox = model.BoxForTestEntityInline(env.ObjectBox)
+	ids, err = inlineBox.PutMany([]*model.TestEntityInline{
+		{BaseWithValue: &model.BaseWithValue{Value: 1}},
+		{BaseWithValue: &model.BaseWithValue{Value: 2}},
+	})
+	assert.NoErr(t, err)
+	assert.Eq(t, 2, len(ids))
+
+	ids, err = env.Box.PutAllDedup([]*model.Entity{model.Entity47()}, "String")
+	assert.NoErr(t, err)
+	assert.Eq(t, 1, len(ids))
+
+	// reading builds the typed slice using the binding, or AppendToSlice() as a fallback
+	var _ objectbox.SliceAppenderBinding = model.EntityBinding
+	entities, err := env.Box.GetAll()
+	assert.NoErr(t, err)
+	assert.Eq(t, 3, len(entities))
+	entities, err = env.Box.GetMany(1, 100)
+	assert.NoErr(t, err)
+	assert.Eq(t, 2, len(entities))
+	assert.True(t, entities[0] != nil && entities[1] == nil)
+
+	inlines, err := inlineBox.GetAll()
+	assert.NoErr(t, err)
+	assert.Eq(t, 2, len(inlines))
+}
+
+func TestBoxPutAllProgress(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	var events = make([]*iot.Event, 2500)
+	for i := range events {
+		events[i] = &iot.Event{Device: "device"}
+	}
+
+	var progress []int
+	ids, err := box.PutAllProgress(events, func(done, total int) {
+		assert.Eq(t, len(events), total)
+		progress = append(progress, done)
+	})
+	assert.NoErr(t, err)
+	assert.Eq(t, len(events), len(ids))
+	assert.Eq(t, []int{1000, 2000, 2500}, progress)
+
+	count, err := box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(len(events)), count)
+}
+
+func TestAllBoxes(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+
+	var boxes = env.ObjectBox.AllBoxes()
+	assert.Eq(t, 2, len(boxes))
+	assert.Eq(t, "Event", boxes[0].EntityName())
+	assert.Eq(t, "Reading", boxes[1].EntityName())
+	assert.True(t, boxes[0].EntityId() < boxes[1].EntityId())
+
+	// the same instances as the generated accessors return
+	assert.True(t, boxes[0] == iot.BoxForEvent(env.ObjectBox).Box)
+	assert.True(t, boxes[1] == iot.BoxForReading(env.ObjectBox).Box)
+
+	iot.PutEvents(env.ObjectBox, 3)
+	iot.PutReadings(env.ObjectBox, 2)
+	var total uint64
+	for _, box := range env.ObjectBox.AllBoxes() {
+		count, err := box.Count()
+		assert.NoErr(t, err)
+		total += count
+	}
+	assert.Eq(t, uint64(5), total)
+}
+
+func TestBoxPutAllDedup(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	_, err := box.Put(&iot.Event{Device: "old", Uid: "a"})
+	assert.NoErr(t, err)
+
+	var events = []*iot.Event{
+		{Device: "updated", Uid: "a"}, // existing in the box
+		{Device: "new", Uid: "b"},
+		{Device: "newer", Uid: "b"}, // duplicate inside the slice
+	}
+	ids, err := box.PutAllDedup(events, "Uid")
+	assert.NoErr(t, err)
+	assert.Eq(t, []uint64{1, 2, 2}, ids)
+	assert.Eq(t, uint64(1), events[0].Id)
+
+	all, err := box.GetAll()
+	assert.NoErr(t, err)
+	assert.Eq(t, 2, len(all))
+	assert.Eq(t, "updated", all[0].Device)
+	assert.Eq(t, "newer", all[1].Device)
+
+	// integer keys
+	ids, err = box.PutAllDedup([]*iot.Event{{Device: "x", Date: 47, Uid: "c"}, {Device: "y", Date: 47, Uid: "d"}}, "Date")
+	assert.NoErr(t, err)
+	assert.Eq(t, ids[0], ids[1])
+
+	// objects without the key set (an empty string or zero) aren't merged
+	ids, err = box.PutAllDedup([]*iot.Event{{Uid: "e"}, {Uid: "f"}}, "Device")
+	assert.NoErr(t, err)
+	assert.True(t, ids[0] != ids[1])
+	ids, err = box.PutAllDedup([]*iot.Event{{Uid: "g"}, {Uid: "h"}}, "Date")
+	assert.NoErr(t, err)
+	assert.True(t, ids[0] != ids[1])
+
+	// the key set by the pre-save hook is used for the lookup; the hook is applied once per object
+	var calls int
+	box.SetPreSave(func(object interface{}) error {
+		calls++
+		var event = object.(*iot.Event)
+		if event.Uid == "" {
+			event.Uid = "key-" + event.Device
+		}
+		return nil
+	})
+	existingId, err := box.Put(&iot.Event{Device: "hooked"})
+	assert.NoErr(t, err)
+	ids, err = box.PutAllDedup([]*iot.Event{{Device: "hooked"}, {Device: "other"}}, "Uid")
+	assert.NoErr(t, err)
+	assert.Eq(t, existingId, ids[0])
+	assert.True(t, ids[1] != existingId)
+	assert.Eq(t, 3, calls)
+	box.SetPreSave(nil)
+
+	_, err = box.PutAllDedup(events, "Unknown")
+	assert.Err(t, err)
+}
+
+func TestBoxPutByKey(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	// insert
+	var first = &iot.Event{Device: "first", Uid: "a"}
+	idA, err := box.PutByKey(first, "Uid")
+	assert.NoErr(t, err)
+	assert.True(t, idA != 0)
+	assert.Eq(t, idA, first.Id)
+
+	idB, err := box.PutByKey(&iot.Event{Device: "other", Uid: "b"}, "Uid")
+	assert.NoErr(t, err)
+	assert.True(t, idB != idA)
+
+	// update - the ID is taken from the stored object with the same key
+	var second = &iot.Event{Device: "second", Uid: "a"}
+	id, err := box.PutByKey(second, "Uid")
+	assert.NoErr(t, err)
+	assert.Eq(t, idA, id)
+	assert.Eq(t, idA, second.Id)
+
+	count, err := box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(2), count)
+
+	read, err := box.Get(idA)
+	assert.NoErr(t, err)
+	assert.Eq(t, "second", read.Device)
+
+	// only unique properties can be used as a key
+	_, err = box.PutByKey(&iot.Event{Device: "x"}, "Device")
+	assert.Err(t, err)
+	_, err = box.PutByKey(&iot.Event{Device: "x"}, "Missing")
+	assert.Err(t, err)
+	_, err = box.PutByKey(&iot.Event{Device: "x"}, "Picture")
+	assert.Err(t, err)
+
+	// the key normalized by the pre-save hook is used for the lookup
+	box.SetPreSave(func(object interface{}) error {
+		var event = object.(*iot.Event)
+		event.Uid = strings.ToLower(event.Uid)
+		return nil
+	})
+	id, err = box.PutByKey(&iot.Event{Device: "third", Uid: "A"}, "Uid")
+	assert.NoErr(t, err)
+	assert.Eq(t, idA, id)
+	read, err = box.Get(idA)
+	assert.NoErr(t, err)
+	assert.Eq(t, "third", read.Device)
+	box.SetPreSave(nil)
+}
+
+func TestBoxRequireIds(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	box.RequireIds(true)
+
+	_, err := box.Put(&iot.Event{Device: "no id"})
+	assert.Eq(t, objectbox.ErrIdRequired, err)
+
+	_, err = box.Insert(&iot.Event{Device: "no id"})
+	assert.Eq(t, objectbox.ErrIdRequired, err)
+
+	_, err = box.Async().Put(&iot.Event{Device: "no id"})
+	assert.Eq(t, objectbox.ErrIdRequired, err)
+
+	id, err := box.Put(&iot.Event{Id: 42, Device: "explicit", Uid: "42"})
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(42), id)
+
+	// a single object without an ID rolls back the whole PutMany
+	_, err = box.PutMany([]*iot.Event{{Id: 100, Uid: "100"}, {Uid: "none"}})
+	assert.True(t, errors.Is(err, objectbox.ErrIdRequired))
+	count, err := box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1), count)
+
+	ids, err := box.PutMany([]*iot.Event{{Id: 100, Uid: "100"}, {Id: 101, Uid: "101"}})
+	assert.NoErr(t, err)
+	assert.Eq(t, []uint64{100, 101}, ids)
+
+	// the default - automatic IDs
+	box.RequireIds(false)
+	id, err = box.Put(&iot.Event{Device: "auto"})
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(102), id)
+}
+
+func TestBoxGetBytes(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	ids, err := box.PutMany([]*iot.Event{{Device: "first", Uid: "1"}, {Device: "second", Uid: "2"}})
+	assert.NoErr(t, err)
+
+	bytes, err := box.GetBytes(ids[1])
+	assert.NoErr(t, err)
+	object, err := iot.EventBinding.Load(env.ObjectBox, bytes)
+	assert.NoErr(t, err)
+	assert.Eq(t, "second", object.(*iot.Event).Device)
+
+	bytes, err = box.GetBytes(ids[1] + 1)
+	assert.NoErr(t, err)
+	assert.True(t, bytes == nil)
+
+	slice, err := box.GetBytesMany(ids[0], ids[1]+1, ids[1])
+	assert.NoErr(t, err)
+	assert.Eq(t, 3, len(slice))
+	assert.True(t, slice[1] == nil)
+	object, err = iot.EventBinding.Load(env.ObjectBox, slice[0])
+	assert.NoErr(t, err)
+	assert.Eq(t, "first", object.(*iot.Event).Device)
+	object, err = iot.EventBinding.Load(env.ObjectBox, slice[2])
+	assert.NoErr(t, err)
+	assert.Eq(t, "second", object.(*iot.Event).Device)
+
+	// the data are copies, valid after the transaction and not changed by later writes
+	assert.NoErr(t, box.RemoveAll())
+	object, err = iot.EventBinding.Load(env.ObjectBox, slice[2])
+	assert.NoErr(t, err)
+	assert.Eq(t, "second", object.(*iot.Event).Device)
+
+	slice, err = box.GetBytesMany()
+	assert.NoErr(t, err)
+	assert.Eq(t, 0, len(slice))
+}
+
+func TestBoxGetOwnsData(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	id, err := box.Put(&iot.Event{Device: "original", Picture: []byte{1, 2, 3}})
+	assert.NoErr(t, err)
+
+	// read inside an explicit transaction and use the object after it's closed
+	var event *iot.Event
+	assert.NoErr(t, env.ObjectBox.RunInReadTx(func() error {
+		event, err = box.Get(id)
+		return err
+	}))
+
+	// overwrite the stored object, causing the database memory to be reused
+	for i := 0; i < 100; i++ {
+		_, err = box.Put(&iot.Event{Id: id, Device: "changed", Picture: []byte{9, 9, 9, 9}})
+		assert.NoErr(t, err)
+	}
+	assert.Eq(t, "original", event.Device)
+	assert.Eq(t, []byte{1, 2, 3}, event.Picture)
+
+	// mutating the returned object doesn't affect the stored one nor other objects read before
+	other, err := box.Get(id)
+	assert.NoErr(t, err)
+	other.Picture[0] = 0
+	other.Device = "mutated"
+
+	stored, err := box.Get(id)
+	assert.NoErr(t, err)
+	assert.Eq(t, "changed", stored.Device)
+	assert.Eq(t, []byte{9, 9, 9, 9}, stored.Picture)
+	assert.Eq(t, []byte{1, 2, 3}, event.Picture)
+}
+
+func TestBoxRemoveManyReport(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	ids, err := box.PutMany([]*iot.Event{{Uid: "1"}, {Uid: "2"}, {Uid: "3"}})
+	assert.NoErr(t, err)
+
+	removed, notFound, err := box.RemoveManyReport(ids[2], 100, ids[0], ids[0], 101)
+	assert.NoErr(t, err)
+	assert.Eq(t, []uint64{ids[2], ids[0]}, removed)
+	assert.Eq(t, []uint64{100, ids[0], 101}, notFound)
+
+	count, err := box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1), count)
+
+	removed, notFound, err = box.RemoveManyReport()
+	assert.NoErr(t, err)
+	assert.Eq(t, 0, len(removed))
+	assert.Eq(t, 0, len(notFound))
+}
+
+func TestAuditSink(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	var records []string
+	env.ObjectBox.SetAuditSink(func(typeId objectbox.TypeId, op string, id uint64) {
+		assert.Eq(t, box.EntityId(), typeId)
+		records = append(records, fmt.Sprintf("%s %d", op, id))
+	})
+
+	id, err := box.Put(&iot.Event{Uid: "1"})
+	assert.NoErr(t, err)
+	ids, err := box.PutMany([]*iot.Event{{Uid: "2"}, {Uid: "3"}})
+	assert.NoErr(t, err)
+	assert.NoErr(t, box.RemoveId(id))
+	assert.Eq(t, []string{
+		fmt.Sprintf("put %d", id),
+		fmt.Sprintf("put %d", ids[0]),
+		fmt.Sprintf("put %d", ids[1]),
+		fmt.Sprintf("remove %d", id),
+	}, records)
+
+	// failed operations are not reported
+	records = nil
+	_, err = box.Put(&iot.Event{Uid: "2"})
+	assert.Err(t, err)
+	assert.Err(t, box.RemoveId(id))
+	assert.Eq(t, 0, len(records))
+
+	// changes in a transaction are only reported after the commit
+	assert.NoErr(t, env.ObjectBox.RunInWriteTx(func() error {
+		id, err = box.Put(&iot.Event{Uid: "4"})
+		assert.NoErr(t, err)
+		assert.Eq(t, 0, len(records))
+		return nil
+	}))
+	assert.Eq(t, []string{fmt.Sprintf("put %d", id)}, records)
+
+	// ... and dropped on rollback
+	records = nil
+	assert.Err(t, env.ObjectBox.RunInWriteTx(func() error {
+		_, err := box.Put(&iot.Event{Uid: "5"})
+		assert.NoErr(t, err)
+		return errors.New("rollback")
+	}))
+	assert.Eq(t, 0, len(records))
+
+	// a nested transaction (PutMany) doesn't report the changes of the outer one before it's finished either, and
+	// they're all dropped if the outer one is rolled back
+	assert.Err(t, env.ObjectBox.RunInWriteTx(func() error {
+		_, err := box.Put(&iot.Event{Uid: "5"})
+		assert.NoErr(t, err)
+		_, err = box.PutMany([]*iot.Event{{Uid: "6"}, {Uid: "7"}})
+		assert.NoErr(t, err)
+		assert.Eq(t, 0, len(records))
+		_, err = box.Put(&iot.Event{Uid: "8"})
+		assert.NoErr(t, err)
+		assert.Eq(t, 0, len(records))
+		return errors.New("rollback")
+	}))
+	assert.Eq(t, 0, len(records))
+
+	// ... or all reported together once it's committed
+	var nestedIds []uint64
+	assert.NoErr(t, env.ObjectBox.RunInWriteTx(func() error {
+		nestedIds, err = box.PutMany([]*iot.Event{{Uid: "6"}, {Uid: "7"}})
+		assert.NoErr(t, err)
+		id, err = box.Put(&iot.Event{Uid: "8"})
+		assert.NoErr(t, err)
+		assert.Eq(t, 0, len(records))
+		return nil
+	}))
+	assert.Eq(t, []string{
+		fmt.Sprintf("put %d", nestedIds[0]),
+		fmt.Sprintf("put %d", nestedIds[1]),
+		fmt.Sprintf("put %d", id),
+	}, records)
+	records = nil
+
+	// RemoveIds only reports the objects that actually existed
+	count, err := box.RemoveIds(ids[0], 100)
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1), count)
+	assert.Eq(t, []string{fmt.Sprintf("remove %d", ids[0])}, records)
+
+	// a panic in the sink doesn't affect the operation
+	env.ObjectBox.SetAuditSink(func(typeId objectbox.TypeId, op string, id uint64) {
+		panic("audit sink failure")
+	})
+	_, err = box.Put(&iot.Event{Uid: "9"})
+	assert.NoErr(t, err)
+
+	records = nil
+	env.ObjectBox.SetAuditSink(nil)
+	assert.NoErr(t, box.RemoveAll())
+	assert.Eq(t, 0, len(records))
+}
+
+func TestAuditSinkConcurrentRollback(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	var mutex sync.Mutex
+	var records []string
+	env.ObjectBox.SetAuditSink(func(typeId objectbox.TypeId, op string, id uint64) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		records = append(records, fmt.Sprintf("%s %d", op, id))
+	})
+
+	// a standalone put of another goroutine, committed while (or right after) a transaction is rolled back, is still
+	// reported; only the changes of the rolled back transaction are dropped
+	for i := 0; i < 20; i++ {
+		var txOpen = make(chan struct{})
+		var standaloneId uint64
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-txOpen
+			var err error
+			standaloneId, err = box.Put(&iot.Event{Uid: fmt.Sprintf("standalone %d", i)})
+			assert.NoErr(t, err)
+		}()
+
+		assert.Err(t, env.ObjectBox.RunInWriteTx(func() error {
+			_, err := box.Put(&iot.Event{Uid: fmt.Sprintf("rolled back %d", i)})
+			assert.NoErr(t, err)
+			close(txOpen)
+			time.Sleep(time.Millisecond)
+			return errors.New("rollback")
+		}))
+		wg.Wait()
+
+		mutex.Lock()
+		assert.Eq(t, []string{fmt.Sprintf("put %d", standaloneId)}, records)
+		records = nil
+		mutex.Unlock()
+	}
+}
+
+func TestBoxBulkEmptyInput(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	_, err := box.Put(&iot.Event{Uid: "existing"})
+	assert.NoErr(t, err)
+
+	var noConflict = func(incoming, existing interface{}) (interface{}, error) { return incoming, nil }
+
+	// each function is called with nil (untyped where the signature allows it) and an empty input
+	var testCases = []struct {
+		name string
+		fn   func(empty bool) (result interface{}, err error)
+		len  func(result interface{}) int
+	}{
+		{"PutMany", func(empty bool) (interface{}, error) {
+			if empty {
+				return box.Box.PutMany([]*iot.Event{})
+			}
+			return box.Box.PutMany(nil)
+		}, func(r interface{}) int { return len(r.([]uint64)) }},
+		{"PutAllProgress", func(empty bool) (interface{}, error) {
+			if empty {
+				return box.PutAllProgress([]*iot.Event{}, nil)
+			}
+			return box.PutAllProgress(nil, nil)
+		}, func(r interface{}) int { return len(r.([]uint64)) }},
+		{"PutAllDedup", func(empty bool) (interface{}, error) {
+			if empty {
+				return box.PutAllDedup([]*iot.Event{}, "Uid")
+			}
+			return box.PutAllDedup(nil, "Uid")
+		}, func(r interface{}) int { return len(r.([]uint64)) }},
+		{"PutAllWithConflict", func(empty bool) (interface{}, error) {
+			if empty {
+				return box.PutAllWithConflict([]*iot.Event{}, noConflict)
+			}
+			return box.PutAllWithConflict(nil, noConflict)
+		}, func(r interface{}) int { return len(r.([]uint64)) }},
+		{"PutAllWithinMemory", func(empty bool) (interface{}, error) {
+			if empty {
+				return box.PutAllWithinMemory([]*iot.Event{}, 1000)
+			}
+			return box.PutAllWithinMemory(nil, 1000)
+		}, func(r interface{}) int { return len(r.([]uint64)) }},
+		{"GetMany", func(empty bool) (interface{}, error) {
+			if empty {
+				return box.GetMany([]uint64{}...)
+			}
+			return box.GetMany()
+		}, func(r interface{}) int { return len(r.([]*iot.Event)) }},
+		{"GetManyExisting", func(empty bool) (interface{}, error) {
+			if empty {
+				return box.GetManyExisting([]uint64{}...)
+			}
+			return box.GetManyExisting()
+		}, func(r interface{}) int { return len(r.([]*iot.Event)) }},
+		{"GetBytesMany", func(empty bool) (interface{}, error) {
+			if empty {
+				return box.GetBytesMany([]uint64{}...)
+			}
+			return box.GetBytesMany()
+		}, func(r interface{}) int { return len(r.([][]byte)) }},
+		{"RemoveIds", func(empty bool) (interface{}, error) {
+			if empty {
+				return box.RemoveIds([]uint64{}...)
+			}
+			return box.RemoveIds()
+		}, func(r interface{}) int { return int(r.(uint64)) }},
+		{"RemoveMany", func(empty bool) (interface{}, error) {
+			if empty {
+				return box.RemoveMany([]*iot.Event{}...)
+			}
+			return box.RemoveMany()
+		}, func(r interface{}) int { return int(r.(uint64)) }},
+		{"RemoveManyReport", func(empty bool) (interface{}, error) {
+			var removed, notFound []uint64
+			var err error
+			if empty {
+				removed, notFound, err = box.RemoveManyReport([]uint64{}...)
+			} else {
+				removed, notFound, err = box.RemoveManyReport()
+			}
+			if removed == nil || notFound == nil {
+				return nil, err
+			}
+			return append(removed, notFound...), err
+		}, func(r interface{}) int { return len(r.([]uint64)) }},
+		{"ContainsIds", func(empty bool) (interface{}, error) {
+			if empty {
+				return box.ContainsIds([]uint64{}...)
+			}
+			return box.ContainsIds()
+		}, func(r interface{}) int {
+			if r.(bool) {
+				return 0
+			}
+			return 1
+		}},
+	}
+
+	for _, tc := range testCases {
+		for _, empty := range []bool{false, true} {
+			result, err := tc.fn(empty)
+			if err != nil {
+				assert.Failf(t, "%s (empty=%v) failed: %s", tc.name, empty, err)
+			} else if result == nil || reflect.ValueOf(result).Kind() == reflect.Slice && reflect.ValueOf(result).IsNil() {
+				assert.Failf(t, "%s (empty=%v) returned a nil result", tc.name, empty)
+			} else if tc.len(result) != 0 {
+				assert.Failf(t, "%s (empty=%v) returned a non-empty result %v", tc.name, empty, result)
+			}
+		}
+	}
+
+	count, err := box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1), count)
+}
+
+func TestBoxPutAllFromChan(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	ids, err := box.PutAllFromChan(nil)
+	assert.NoErr(t, err)
+	assert.Eq(t, 0, len(ids))
+
+	// more than a single batch
+	var count = 25000
+	var ch = make(chan interface{}, 100)
+	go func() {
+		for i := 0; i < count; i++ {
+			ch <- &iot.Event{Device: fmt.Sprintf("dev-%d", i)}
+		}
+		close(ch)
+	}()
+
+	ids, err = box.PutAllFromChan(ch)
+	assert.NoErr(t, err)
+	assert.Eq(t, count, len(ids))
+	for i, id := range ids {
+		assert.Eq(t, uint64(i+1), id)
+	}
+
+	stored, err := box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(count), stored)
+
+	// a failing object rolls back its batch - here all the objects are in a single batch because they're all buffered
+	ch = make(chan interface{}, 3)
+	ch <- &iot.Event{Uid: "unique"}
+	ch <- &iot.Event{Uid: "other"}
+	ch <- &iot.Event{Uid: "unique"}
+	close(ch)
+
+	ids, err = box.PutAllFromChan(ch)
+	assert.Err(t, err)
+	assert.Eq(t, 0, len(ids))
+	// the database reports the unique constraint violation for the whole batch
+	var putErr *objectbox.PutManyError
+	assert.True(t, errors.As(err, &putErr))
+	assert.Eq(t, 0, putErr.Index)
+	assert.Eq(t, 3, putErr.EndIndex)
+
+	stored, err = box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(count), stored)
+}
+
+func TestBoxMaxObjectSize(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+	assert.Eq(t, 0, box.MaxObjectSizeBytes())
+
+	var event = &iot.Event{Uid: "limit", Picture: make([]byte, 1000)}
+	id, err := box.Put(event)
+	assert.NoErr(t, err)
+
+	// the size with the ID set, i.e. exactly as stored
+	size, err := box.SerializedSize(event)
+	assert.NoErr(t, err)
+
+	// an object of exactly the limit is accepted
+	box.SetMaxObjectSizeBytes(size)
+	assert.Eq(t, size, box.MaxObjectSizeBytes())
+	_, err = box.Put(event)
+	assert.NoErr(t, err)
+
+	// a larger one is rejected (more than the FlatBuffers alignment padding) and the stored object isn't changed
+	event.Picture = make([]byte, 1008)
+	_, err = box.Put(event)
+	assert.True(t, errors.Is(err, objectbox.ErrObjectTooLarge))
+	stored, err := box.Get(id)
+	assert.NoErr(t, err)
+	assert.Eq(t, 1000, len(stored.Picture))
+
+	// PutMany rolls back the whole transaction
+	count, err := box.Count()
+	assert.NoErr(t, err)
+	_, err = box.PutMany([]*iot.Event{{Uid: "small"}, {Uid: "large", Picture: make([]byte, 2000)}})
+	assert.True(t, errors.Is(err, objectbox.ErrObjectTooLarge))
+	countAfter, err := box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, count, countAfter)
+
+	_, err = box.Async().Put(&iot.Event{Uid: "async", Picture: make([]byte, 2000)})
+	assert.True(t, errors.Is(err, objectbox.ErrObjectTooLarge))
+
+	// the limit can be changed at runtime
+	box.SetMaxObjectSizeBytes(0)
+	_, err = box.Put(event)
+	assert.NoErr(t, err)
+}
+
+func TestBoxPutAllWithinMemory(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	var small = &iot.Event{Uid: "small", Picture: make([]byte, 100)}
+	size, err := box.SerializedSize(small)
+	assert.NoErr(t, err)
+	assert.True(t, size > 100 && size < 200)
+	assert.Eq(t, uint64(0), small.Id)
+
+	// mixed sizes, including an object larger than the budget
+	var events []*iot.Event
+	for i := 0; i < 50; i++ {
+		var pictureSize = 100
+		if i%10 == 0 {
+			pictureSize = 6000
+		} else if i == 25 {
+			pictureSize = 20000
+		}
+		events = append(events, &iot.Event{Uid: fmt.Sprintf("%d", i), Picture: make([]byte, pictureSize)})
+	}
+
+	ids, err := box.PutAllWithinMemory(events, 10000)
+	assert.NoErr(t, err)
+	assert.Eq(t, 50, len(ids))
+	for i, event := range events {
+		assert.Eq(t, ids[i], event.Id)
+	}
+
+	stored, err := box.Get(ids[25])
+	assert.NoErr(t, err)
+	assert.Eq(t, 20000, len(stored.Picture))
+
+	// a failure in a later batch keeps the previous batches and reports the index in the given slice
+	events = []*iot.Event{
+		{Uid: "new1", Picture: make([]byte, 6000)},
+		{Uid: "new2", Picture: make([]byte, 6000)},
+		{Uid: "0"},
+	}
+	ids, err = box.PutAllWithinMemory(events, 10000)
+	assert.Err(t, err)
+	var putErr *objectbox.PutManyError
+	assert.True(t, errors.As(err, &putErr))
+	assert.Eq(t, 2, putErr.Index)
+	assert.Eq(t, 3, putErr.EndIndex)
+	assert.Eq(t, 1, len(ids))
+
+	count, err := box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(51), count)
+
+	ids, err = box.PutAllWithinMemory([]*iot.Event{}, 10000)
+	assert.NoErr(t, err)
+	assert.Eq(t, 0, len(ids))
+
+	_, err = box.PutAllWithinMemory(events, 0)
+	assert.Err(t, err)
+}
+
+func TestBoxPutAllWithConflict(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	_, err := box.PutMany([]*iot.Event{
+		{Device: "stored a", Date: 1, Uid: "a"},
+		{Device: "stored b", Date: 2, Uid: "b"},
+	})
+	assert.NoErr(t, err)
+
+	var resolved []string
+	var resolve = func(incoming, existing interface{}) (interface{}, error) {
+		var in, ex = incoming.(*iot.Event), existing.(*iot.Event)
+		resolved = append(resolved, in.Uid)
+		if in.Uid == "b" {
+			return nil, nil // skip
+		}
+		// merge - keep the stored device, take the new date
+		ex.Date = in.Date
+		return ex, nil
+	}
+
+	ids, err := box.PutAllWithConflict([]*iot.Event{
+		{Device: "incoming a", Date: 10, Uid: "a"},
+		{Device: "incoming b", Date: 20, Uid: "b"},
+		{Device: "incoming c", Date: 30, Uid: "c"},
+	}, resolve)
+	assert.NoErr(t, err)
+	assert.Eq(t, []string{"a", "b"}, resolved)
+	assert.Eq(t, []uint64{1, 0, 3}, ids)
+
+	all, err := box.GetAll()
+	assert.NoErr(t, err)
+	assert.Eq(t, 3, len(all))
+	assert.Eq(t, "stored a", all[0].Device)
+	assert.Eq(t, int64(10), all[0].Date)
+	assert.Eq(t, "stored b", all[1].Device)
+	assert.Eq(t, int64(2), all[1].Date)
+	assert.Eq(t, "incoming c", all[2].Device)
+
+	// an error from the resolver rolls back everything
+	_, err = box.PutAllWithConflict([]*iot.Event{{Device: "d", Uid: "d"}, {Device: "a", Uid: "a"}},
+		func(incoming, existing interface{}) (interface{}, error) {
+			return nil, errors.New("conflict")
+		})
+	assert.Err(t, err)
+	count, err := box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(3), count)
+
+	// the unique values normalized by the pre-save hook are used for the lookup; the hook is also applied to an object
+	// returned by the resolver
+	box.SetPreSave(func(object interface{}) error {
+		var event = object.(*iot.Event)
+		event.Uid = strings.ToLower(event.Uid)
+		event.Device = strings.TrimSuffix(event.Device, " (saved)") + " (saved)"
+		return nil
+	})
+	resolved = nil
+	ids, err = box.PutAllWithConflict([]*iot.Event{{Device: "incoming C", Date: 40, Uid: "C"}}, resolve)
+	assert.NoErr(t, err)
+	assert.Eq(t, []string{"c"}, resolved)
+	assert.Eq(t, []uint64{3}, ids)
+	box.SetPreSave(nil)
+
+	read, err := box.Get(3)
+	assert.NoErr(t, err)
+	assert.Eq(t, "incoming c (saved)", read.Device)
+	assert.Eq(t, int64(40), read.Date)
+}
+
+func TestBoxPutManyErrorIndex(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+	var box = model.BoxForTestEntityConverters(env.ObjectBox)
+
+	var objects = []*model.TestEntityConverters{
+		{IP: net.ParseIP("10.0.0.1")},
+		{IP: net.ParseIP("10.0.0.2")},
+		{IP: net.IP{1, 2, 3}}, // invalid - the converter fails
+		{IP: net.ParseIP("10.0.0.4")},
+	}
+	_, err := box.PutMany(objects)
+	assert.Err(t, err)
+
+	putErr, ok := err.(*objectbox.PutManyError)
+	assert.True(t, ok)
+	assert.Eq(t, 2, putErr.Index)
+	assert.True(t, putErr.Unwrap() != nil)
+	assert.Eq(t, 0, putErr.EndIndex)
+	assert.MustMatch(t, regexp.MustCompile(`^objectbox: PutMany failed at index 2( \(id \d+\))?: converter`), err.Error())
+
+	count, err := box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(0), count)
+}
+
+func TestBoxPutManyErrorRange(t *testing.T) {
+	var env = iot.NewTestEnv()
+	defer env.Close()
+	var box = iot.BoxForEvent(env.ObjectBox)
+
+	// a failure reported by the database for all the objects put together only identifies their range
+	_, err := box.PutMany([]*iot.Event{{Uid: "1"}, {Uid: "2"}, {Uid: "3"}, {Uid: "2"}})
+	assert.Err(t, err)
+	putErr, ok := err.(*objectbox.PutManyError)
+	assert.True(t, ok)
+	assert.Eq(t, 0, putErr.Index)
+	assert.Eq(t, 4, putErr.EndIndex)
+	var storageErr *objectbox.StorageError
+	assert.True(t, errors.As(err, &storageErr))
+	assert.Eq(t, 10201, storageErr.Code) // OBX_ERROR_UNIQUE_VIOLATED
+	assert.MustMatch(t, regexp.MustCompile(`^objectbox: PutMany failed at index 0 to 3: `), err.Error())
+
+	count, err := box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(0), count)
+}
+
+func TestBoxGetContext(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+
+	env.Populate(1)
+
+	object, err := env.Box.GetContext(context.Background(), 1)
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1), object.(*model.Entity).Id)
+
+	object, err = env.Box.GetContext(context.Background(), 2)
+	assert.NoErr(t, err)
+	assert.True(t, object == nil)
+
+	var ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, err = env.Box.GetContext(ctx, 1)
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestBoxGetContextConcurrent(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	ob, err := objectbox.NewBuilder().Directory(dir).Model(iot.ObjectBoxModel()).MaxReaders(2).BuildOrError()
+	assert.NoErr(t, err)
+	defer ob.Close()
+
+	var box = iot.BoxForEvent(ob)
+	id, err := box.Put(&iot.Event{Device: "dev"})
+	assert.NoErr(t, err)
+
+	// more concurrent calls than reader slots wait for each other instead of failing
+	const count = 20
+	var errs = make(chan error, count)
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			object, err := box.Box.GetContext(context.Background(), id)
+			if err == nil && object.(*iot.Event).Device != "dev" {
+				err = errors.New("unexpected object")
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.NoErr(t, err)
+	}
+}
+
+func TestBoxGetContextDefaultReaders(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	// zero means the native default, the reads must not wait for a slot that doesn't exist
+	ob, err := objectbox.NewBuilder().Directory(dir).Model(iot.ObjectBoxModel()).MaxReaders(0).BuildOrError()
+	assert.NoErr(t, err)
+	defer ob.Close()
+
+	_, max, err := ob.ReadersInUse()
+	assert.NoErr(t, err)
+	assert.Eq(t, 126, max)
+
+	var box = iot.BoxForEvent(ob)
+	id, err := box.Put(&iot.Event{Device: "dev"})
+	assert.NoErr(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	object, err := box.Box.GetContext(ctx, id)
+	assert.NoErr(t, err)
+	assert.Eq(t, "dev", object.(*iot.Event).Device)
+}
+
+func TestBoxWaitForId(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		env.Populate(1)
+	}()
+
+	var ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	object, err := env.Box.WaitForId(ctx, 1, time.Millisecond)
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1), object.(*model.Entity).Id)
+
+	// times out if the object doesn't appear
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	object, err = env.Box.WaitForId(ctx, 2, time.Millisecond)
+	assert.True(t, object == nil)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	_, err = env.Box.WaitForId(context.Background(), 1, 0)
+	assert.Err(t, err)
+}
+
+func TestBoxForEachReverse(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	var collect = func(limit int) []uint64 {
+		var ids = []uint64{}
+		var errLimit = errors.New("limit reached")
+		err := box.ForEachReverse(func(object interface{}) error {
+			ids = append(ids, object.(*iot.Event).Id)
+			if len(ids) == limit {
+				return errLimit
+			}
+			return nil
+		})
+		if limit > 0 && len(ids) == limit {
+			assert.Eq(t, errLimit, err)
+		} else {
+			assert.NoErr(t, err)
+		}
+		return ids
+	}
+
+	assert.Eq(t, []uint64{}, collect(0))
+
+	ids, err := box.PutMany([]*iot.Event{{Uid: "1"}, {Uid: "2"}, {Uid: "3"}, {Uid: "4"}, {Uid: "5"}})
+	assert.NoErr(t, err)
+	assert.NoErr(t, box.RemoveId(ids[3]))
+
+	assert.Eq(t, []uint64{ids[4], ids[2], ids[1], ids[0]}, collect(0))
+	assert.Eq(t, []uint64{ids[4], ids[2]}, collect(2))
+}
+
+func TestBoxGetAllWhere(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+
+	env.Populate(20)
+
+	all, err := env.Box.GetAll()
+	assert.NoErr(t, err)
+
+	var expected = make([]*model.Entity, 0)
+	for _, object := range all {
+		if object.Int64 > 0 {
+			expected = append(expected, object)
+		}
+	}
+	assert.True(t, len(expected) > 0 && len(expected) < len(all))
+
+	slice, err := env.Box.GetAllWhere(func(object interface{}) bool {
+		return object.(*model.Entity).Int64 > 0
+	})
+	assert.NoErr(t, err)
+	assert.Eq(t, expected, slice.([]*model.Entity))
+
+	slice, err = env.Box.GetAllWhere(func(object interface{}) bool { return false })
+	assert.NoErr(t, err)
+	assert.Eq(t, 0, len(slice.([]*model.Entity)))
+}
+
+func TestBoxTTL(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	var nowMs = time.Now().UnixNano() / int64(time.Millisecond)
+	var hourMs = int64(time.Hour / time.Millisecond)
+	ids, err := box.PutMany([]*iot.Event{
+		{Uid: "old", Device: "a", Date: nowMs - 2*hourMs},
+		{Uid: "new", Device: "a", Date: nowMs},
+		{Uid: "none", Device: "a"},
+		{Uid: "negative", Device: "a", Date: -hourMs}, // before 1970, expired as well
+	})
+	assert.NoErr(t, err)
+
+	assert.Err(t, box.SetTTL("Unknown", time.Hour, false))
+	assert.Err(t, box.SetTTL("Device", time.Hour, false))
+	assert.Err(t, box.SetTTL("Date", -time.Hour, false))
+	_, err = box.PurgeExpired()
+	assert.Err(t, err)
+
+	assert.NoErr(t, box.SetTTL("Date", time.Hour, false))
+
+	// expired objects are skipped by all reads returning objects
+	event, err := box.Get(ids[0])
+	assert.NoErr(t, err)
+	assert.True(t, event == nil)
+
+	event, err = box.Get(ids[1])
+	assert.NoErr(t, err)
+	assert.Eq(t, "new", event.Uid)
+
+	events, err := box.GetMany(ids...)
+	assert.NoErr(t, err)
+	assert.Eq(t, 4, len(events))
+	assert.True(t, events[0] == nil)
+	assert.Eq(t, "none", events[2].Uid)
+	assert.True(t, events[3] == nil)
+
+	events, err = box.GetAll()
+	assert.NoErr(t, err)
+	assert.Eq(t, 2, len(events))
+
+	events, err = box.Query(iot.Event_.Device.Equals("a", true)).Find()
+	assert.NoErr(t, err)
+	assert.Eq(t, 2, len(events))
+	assert.Eq(t, "new", events[0].Uid)
+
+	// ...but stay in the database until purged
+	count, err := box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(4), count)
+
+	// purging removes exactly the objects skipped by the reads
+	removed, err := box.PurgeExpired()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(2), removed)
+
+	count, err = box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(2), count)
+
+	// a shorter TTL makes the remaining dated object expire; it's removed in the background after a read
+	assert.NoErr(t, box.SetTTL("Date", 0, true))
+	events, err = box.GetAll()
+	assert.NoErr(t, err)
+	assert.Eq(t, 1, len(events))
+	assert.Eq(t, "none", events[0].Uid)
+
+	assert.NoErr(t, waitUntil(time.Second, func() (bool, error) {
+		count, err := box.Count()
+		return count == 1, err
+	}))
+
+	// disabling expiry
+	assert.NoErr(t, box.SetTTL("", 0, false))
+	event, err = box.Get(ids[2])
+	assert.NoErr(t, err)
+	assert.Eq(t, "none", event.Uid)
+}
+
+func TestBoxTTLPurgeOnClose(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	var nowMs = time.Now().UnixNano() / int64(time.Millisecond)
+	var events = make([]*iot.Event, 1000)
+	for i := range events {
+		events[i] = &iot.Event{Uid: fmt.Sprintf("%d", i), Date: nowMs - int64(i+1)}
+	}
+	_, err := box.PutMany(events)
+	assert.NoErr(t, err)
+
+	// closing right after a read started the background purge waits for it instead of freeing the store under it
+	assert.NoErr(t, box.SetTTL("Date", 0, true))
+	all, err := box.GetAll()
+	assert.NoErr(t, err)
+	assert.Eq(t, 0, len(all))
+	env.ObjectBox.Close()
+
+	_, err = box.Count()
+	assert.Eq(t, objectbox.ErrStoreClosed, err)
+}
+
+func TestBoxAllIds(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForReading(env.ObjectBox)
+
+	ids, err := box.AllIds()
+	assert.NoErr(t, err)
+	assert.Eq(t, 0, len(ids))
+
+	// more than a single batch of ForEachId()
+	var readings = make([]*iot.Reading, 10005)
+	for i := range readings {
+		readings[i] = &iot.Reading{ValueName: fmt.Sprintf("r%d", i)}
+	}
+	putIds, err := box.PutMany(readings)
+	assert.NoErr(t, err)
+	assert.NoErr(t, box.RemoveId(putIds[0]))
+	assert.NoErr(t, box.RemoveId(putIds[5000]))
+	putIds = append(putIds[1:5000], putIds[5001:]...)
+
+	// an explicit ID lower than some of the existing ones is still returned in order
+	assert.NoErr(t, box.RemoveId(putIds[1]))
+	_, err = box.Put(&iot.Reading{Id: putIds[1]})
+	assert.NoErr(t, err)
+
+	count, err := box.Count()
+	assert.NoErr(t, err)
+
+	ids, err = box.AllIds()
+	assert.NoErr(t, err)
+	assert.Eq(t, count, uint64(len(ids)))
+	assert.Eq(t, putIds, ids)
+
+	var streamed []uint64
+	assert.NoErr(t, box.ForEachId(func(id uint64) error {
+		streamed = append(streamed, id)
+		return nil
+	}))
+	assert.Eq(t, ids, streamed)
+
+	// stopping early
+	var stop = errors.New("stop")
+	streamed = nil
+	err = box.ForEachId(func(id uint64) error {
+		streamed = append(streamed, id)
+		if len(streamed) == 3 {
+			return stop
+		}
+		return nil
+	})
+	assert.True(t, err == stop)
+	assert.Eq(t, ids[:3], streamed)
+}
+
+func TestBoxForEachBatch(t *testing.T) {
+	for _, consistency := range []objectbox.ReadConsistency{objectbox.ReadSnapshot, objectbox.ReadLatest} {
+		env := iot.NewTestEnv()
+		box := iot.BoxForReading(env.ObjectBox)
+
+		var readings = make([]*iot.Reading, 10)
+		for i := range readings {
+			readings[i] = &iot.Reading{ValueName: "original"}
+		}
+		ids, err := box.PutMany(readings)
+		assert.NoErr(t, err)
+
+		var seen []*iot.Reading
+		var batches int
+		assert.NoErr(t, box.ForEachBatch(3, consistency, func(slice interface{}) error {
+			batches++
+			seen = append(seen, slice.([]*iot.Reading)...)
+
+			if batches == 1 {
+				// a concurrent writer, committing during the scan
+				var wg sync.WaitGroup
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, err := box.PutMany([]*iot.Reading{{ValueName: "new"}, {ValueName: "new"}})
+					assert.NoErr(t, err)
+					_, err = box.Put(&iot.Reading{Id: ids[9], ValueName: "changed"})
+					assert.NoErr(t, err)
+				}()
+				wg.Wait()
+			}
+			return nil
+		}))
+
+		// the snapshot doesn't see the mid-scan writes while reading the latest state does
+		assert.Eq(t, 4, batches)
+		if consistency == objectbox.ReadSnapshot {
+			assert.Eq(t, 10, len(seen))
+			assert.Eq(t, "original", seen[9].ValueName)
+		} else {
+			assert.Eq(t, 12, len(seen))
+			assert.Eq(t, "changed", seen[9].ValueName)
+		}
+		for i := 1; i < len(seen); i++ {
+			assert.True(t, seen[i-1].Id < seen[i].Id)
+		}
+
+		assert.Err(t, box.ForEachBatch(0, consistency, func(interface{}) error { return nil }))
+		env.Close()
+	}
+
+	env := iot.NewTestEnv()
+	defer env.Close()
+	assert.Err(t, iot.BoxForReading(env.ObjectBox).ForEachBatch(1, objectbox.ReadConsistency(42),
+		func(interface{}) error { return nil }))
+}
+
+func TestBoxPutAndBytes(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	var event = &iot.Event{Uid: "1", Device: "first"}
+	id, data, err := box.PutAndBytes(event)
+	assert.NoErr(t, err)
+	assert.Eq(t, id, event.Id)
+
+	stored, err := box.GetBytes(id)
+	assert.NoErr(t, err)
+	assert.Eq(t, stored, data)
+
+	// the returned data is a copy, not affected by further puts (reusing the builder)
+	var dataCopy = append([]byte(nil), data...)
+	_, data2, err := box.PutAndBytes(&iot.Event{Uid: "2", Device: "second, a longer one"})
+	assert.NoErr(t, err)
+	assert.Eq(t, dataCopy, data)
+	assert.True(t, !reflect.DeepEqual(data, data2))
+
+	// and modifying it doesn't affect the stored data
+	data[len(data)-1]++
+	stored, err = box.GetBytes(id)
+	assert.NoErr(t, err)
+	assert.Eq(t, dataCopy, stored)
+
+	// errors don't return any data
+	_, data, err = box.PutAndBytes(&iot.Event{Uid: "1"})
+	assert.Err(t, err)
+	assert.True(t, data == nil)
+
+	// entities with relations
+	var env2 = model.NewTestEnv(t)
+	defer env2.Close()
+	var entity = model.Entity47()
+	id, data, err = env2.Box.PutAndBytes(entity)
+	assert.NoErr(t, err)
+	stored, err = env2.Box.GetBytes(id)
+	assert.NoErr(t, err)
+	assert.Eq(t, stored, data)
+}
+
+func TestResequenceIds(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	events := iot.BoxForEvent(env.ObjectBox)
+	readings := iot.BoxForReading(env.ObjectBox)
+
+	var objects = make([]*iot.Reading, 20)
+	for i := range objects {
+		objects[i] = &iot.Reading{ValueName: fmt.Sprintf("r%d", i)}
+	}
+	ids, err := readings.PutMany(objects)
+	assert.NoErr(t, err)
+
+	// keep every third reading
+	var kept []string
+	for i, id := range ids {
+		if i%3 == 2 {
+			kept = append(kept, objects[i].ValueName)
+		} else {
+			assert.NoErr(t, readings.RemoveId(id))
+		}
+	}
+
+	var remapped = make(map[uint64]uint64)
+	assert.NoErr(t, objectbox.ResequenceIds(readings.Box, func(oldId, newId uint64) {
+		remapped[oldId] = newId
+	}))
+
+	all, err := readings.GetAll()
+	assert.NoErr(t, err)
+	assert.Eq(t, len(kept), len(all))
+	for i, reading := range all {
+		assert.Eq(t, uint64(i+1), reading.Id)
+		assert.Eq(t, kept[i], reading.ValueName)
+		assert.Eq(t, reading.Id, remapped[ids[3*i+2]])
+	}
+	assert.Eq(t, len(kept), len(remapped))
+
+	// the entity is referenced by a to-one relation (Reading.EventId), which requires an explicit opt-in
+	eventIds, err := events.PutMany([]*iot.Event{{Uid: "a"}, {Uid: "b"}, {Uid: "c"}})
+	assert.NoErr(t, err)
+	assert.NoErr(t, events.RemoveId(eventIds[0]))
+	readingId, err := readings.Put(&iot.Reading{EventId: eventIds[2]})
+	assert.NoErr(t, err)
+
+	assert.Err(t, objectbox.ResequenceIds(events.Box, nil))
+
+	events.AllowResequenceWithRelations(true)
+	var calls int
+	assert.NoErr(t, objectbox.ResequenceIds(events.Box, func(oldId, newId uint64) {
+		calls++
+		related, err := readings.Query(iot.Reading_.EventId.Equals(oldId)).Find()
+		assert.NoErr(t, err)
+		for _, reading := range related {
+			reading.EventId = newId
+			_, err = readings.Put(reading)
+			assert.NoErr(t, err)
+		}
+	}))
+	assert.Eq(t, 2, calls)
+
+	reading, err := readings.Get(readingId)
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(2), reading.EventId)
+	event, err := events.Get(reading.EventId)
+	assert.NoErr(t, err)
+	assert.Eq(t, "c", event.Uid)
+
+	// the unique index still works
+	_, err = events.Put(&iot.Event{Uid: "b"})
+	assert.Err(t, err)
+}
+
+func TestObjectBoxWarmup(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+
+	// an empty store
+	assert.NoErr(t, env.ObjectBox.Warmup())
+
+	iot.PutEvents(env.ObjectBox, 1000)
+	iot.PutReadings(env.ObjectBox, 100)
+	var box = iot.BoxForEvent(env.ObjectBox)
+	_, err := box.Put(&iot.Event{Uid: "large", Picture: make([]byte, 100*1024)})
+	assert.NoErr(t, err)
+
+	assert.NoErr(t, env.ObjectBox.Warmup())
+	assert.NoErr(t, env.ObjectBox.Warmup(box.EntityId()))
+	assert.Err(t, env.ObjectBox.Warmup(box.EntityId(), 99))
+
+	// the data is unchanged
+	count, err := box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1001), count)
+}
@@ -0,0 +1,373 @@
+/*
+ * Copyright 2018-2022 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model/iot"
+)
+
+func TestBuilderAlreadyOpen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	var open = func(directory string) (*objectbox.ObjectBox, error) {
+		return objectbox.NewBuilder().Directory(directory).Model(iot.ObjectBoxModel()).BuildOrError()
+	}
+
+	ob, err := open(dir)
+	assert.NoErr(t, err)
+
+	// the same directory, even if specified differently, must not be opened twice
+	ob2, err := open(dir)
+	assert.Eq(t, objectbox.ErrAlreadyOpen, err)
+	assert.True(t, ob2 == nil)
+
+	_, err = open(filepath.Join(dir, "..", filepath.Base(dir)))
+	assert.Eq(t, objectbox.ErrAlreadyOpen, err)
+
+	// the first instance is still usable
+	_, err = iot.BoxForEvent(ob).Put(&iot.Event{Device: "first"})
+	assert.NoErr(t, err)
+
+	ob.Close()
+
+	// after closing, the directory can be opened again
+	ob, err = open(dir)
+	assert.NoErr(t, err)
+	defer ob.Close()
+
+	count, err := iot.BoxForEvent(ob).Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1), count)
+}
+
+func TestPing(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+
+	assert.NoErr(t, env.ObjectBox.Ping())
+
+	var wg sync.WaitGroup
+	var errs = make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- env.ObjectBox.Ping()
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.NoErr(t, err)
+	}
+
+	env.ObjectBox.Close()
+	assert.Err(t, env.ObjectBox.Ping())
+}
+
+func TestBuilderDirectoryMissing(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	var nested = filepath.Join(dir, "missing", "parent", "db")
+
+	// the parent doesn't exist and isn't created by default
+	_, err = objectbox.NewBuilder().Directory(nested).Model(iot.ObjectBoxModel()).BuildOrError()
+	assert.True(t, errors.Is(err, objectbox.ErrDirectoryMissing))
+
+	// create the whole path if requested
+	ob, err := objectbox.NewBuilder().Directory(nested).CreateDirectory(0700).Model(iot.ObjectBoxModel()).BuildOrError()
+	assert.NoErr(t, err)
+	defer ob.Close()
+
+	info, err := os.Stat(filepath.Join(dir, "missing"))
+	assert.NoErr(t, err)
+	assert.True(t, info.IsDir())
+	assert.Eq(t, os.FileMode(0700), info.Mode().Perm())
+}
+
+func TestBuilderCorrupt(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	// a valid store first, to get a proper file name
+	ob, err := objectbox.NewBuilder().Directory(dir).Model(iot.ObjectBoxModel()).BuildOrError()
+	assert.NoErr(t, err)
+	ob.Close()
+
+	files, err := ioutil.ReadDir(dir)
+	assert.NoErr(t, err)
+	for _, file := range files {
+		if strings.HasSuffix(file.Name(), ".mdb") && !strings.Contains(file.Name(), "lock") {
+			var garbage = bytes.Repeat([]byte("garbage!"), 1024)
+			assert.NoErr(t, ioutil.WriteFile(filepath.Join(dir, file.Name()), garbage, 0644))
+		}
+	}
+
+	_, err = objectbox.NewBuilder().Directory(dir).Model(iot.ObjectBoxModel()).BuildOrError()
+	assert.Err(t, err)
+	assert.True(t, errors.Is(err, objectbox.ErrCorrupt))
+
+	_, err = objectbox.NewBuilder().Directory(dir).Model(iot.ObjectBoxModel()).ValidateOnOpen(10).BuildOrError()
+	assert.True(t, errors.Is(err, objectbox.ErrCorrupt))
+
+	// a garbage file can't be recovered but it must not panic either
+	ob, err = objectbox.NewBuilder().Directory(dir).Model(iot.ObjectBoxModel()).RecoverMode().BuildOrError()
+	if err == nil {
+		ob.Close()
+	}
+}
+
+func TestBuilderValidationLevel(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	// some data to validate
+	ob, err := objectbox.NewBuilder().Directory(dir).Model(iot.ObjectBoxModel()).BuildOrError()
+	assert.NoErr(t, err)
+	_, err = iot.BoxForEvent(ob).PutMany([]*iot.Event{{Uid: "1"}, {Uid: "2"}, {Uid: "3"}})
+	assert.NoErr(t, err)
+	ob.Close()
+
+	for _, level := range []objectbox.ValidationLevel{
+		objectbox.ValidationNone, objectbox.ValidationQuick, objectbox.ValidationFull} {
+		ob, err := objectbox.NewBuilder().Directory(dir).Model(iot.ObjectBoxModel()).ValidationLevel(level).BuildOrError()
+		assert.NoErr(t, err)
+
+		count, err := iot.BoxForEvent(ob).Count()
+		assert.NoErr(t, err)
+		assert.Eq(t, uint64(3), count)
+		ob.Close()
+	}
+
+	_, err = objectbox.NewBuilder().ValidationLevel(objectbox.ValidationLevel(42)).Directory(dir).
+		Model(iot.ObjectBoxModel()).BuildOrError()
+	assert.Err(t, err)
+}
+
+func TestOpenReadOnly(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	var liveDir = filepath.Join(dir, "live")
+	var backupDir = filepath.Join(dir, "backup")
+	assert.NoErr(t, os.Mkdir(backupDir, 0755))
+
+	ob, err := objectbox.NewBuilder().Directory(liveDir).Model(iot.ObjectBoxModel()).BuildOrError()
+	assert.NoErr(t, err)
+	defer ob.Close()
+	ids, err := iot.BoxForEvent(ob).PutMany([]*iot.Event{{Uid: "1"}, {Uid: "2"}, {Uid: "3"}})
+	assert.NoErr(t, err)
+
+	// the backup: a copy of the data file, taken while the live store is idle
+	data, err := ioutil.ReadFile(filepath.Join(liveDir, "data.mdb"))
+	assert.NoErr(t, err)
+	assert.NoErr(t, ioutil.WriteFile(filepath.Join(backupDir, "data.mdb"), data, 0644))
+
+	// the directory of the open live store can't be opened again in this process
+	_, err = objectbox.OpenReadOnly(liveDir, iot.ObjectBoxModel())
+	assert.True(t, errors.Is(err, objectbox.ErrAlreadyOpen))
+
+	for _, path := range []string{backupDir, filepath.Join(backupDir, "data.mdb")} {
+		backup, err := objectbox.OpenReadOnly(path, iot.ObjectBoxModel())
+		assert.NoErr(t, err)
+
+		var box = iot.BoxForEvent(backup)
+		count, err := box.Count()
+		assert.NoErr(t, err)
+		assert.Eq(t, uint64(3), count)
+
+		event, err := box.Get(ids[1])
+		assert.NoErr(t, err)
+		assert.Eq(t, "2", event.Uid)
+
+		// writes fail on the backup while the live store stays writable
+		_, err = box.Put(&iot.Event{Uid: "4"})
+		assert.Err(t, err)
+		_, err = iot.BoxForEvent(ob).Put(&iot.Event{Uid: path})
+		assert.NoErr(t, err)
+
+		backup.Close()
+	}
+
+	_, err = objectbox.OpenReadOnly(filepath.Join(dir, "missing"), iot.ObjectBoxModel())
+	assert.Err(t, err)
+	_, err = objectbox.OpenReadOnly(filepath.Join(liveDir, "lock.mdb"), iot.ObjectBoxModel())
+	assert.Err(t, err)
+}
+
+func TestBuilderSchemaVersion(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objectbox-test")
+	assert.NoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	var open = func(configure func(builder *objectbox.Builder)) error {
+		var builder = objectbox.NewBuilder().Directory(dir).Model(iot.ObjectBoxModel())
+		configure(builder)
+		ob, err := builder.BuildOrError()
+		if err == nil {
+			ob.Close()
+		}
+		return err
+	}
+
+	assert.NoErr(t, open(func(builder *objectbox.Builder) { builder.SchemaVersion(1) }))
+	assert.NoErr(t, open(func(builder *objectbox.Builder) { builder.SchemaVersion(2) }))
+
+	// the database has been opened by a newer version
+	err = open(func(builder *objectbox.Builder) { builder.SchemaVersion(1) })
+	assert.True(t, errors.Is(err, objectbox.ErrSchemaTooNew))
+
+	// unless declared compatible; the stored version stays the same
+	assert.NoErr(t, open(func(builder *objectbox.Builder) { builder.SchemaVersion(1).MaxSchemaVersion(2) }))
+	err = open(func(builder *objectbox.Builder) { builder.SchemaVersion(1) })
+	assert.True(t, errors.Is(err, objectbox.ErrSchemaTooNew))
+
+	// simulate a database written by a future version
+	assert.NoErr(t, ioutil.WriteFile(filepath.Join(dir, "schema-version"), []byte("5\n"), 0644))
+	err = open(func(builder *objectbox.Builder) { builder.SchemaVersion(2).MaxSchemaVersion(4) })
+	assert.True(t, errors.Is(err, objectbox.ErrSchemaTooNew))
+	assert.NoErr(t, open(func(builder *objectbox.Builder) { builder.SchemaVersion(6) }))
+	err = open(func(builder *objectbox.Builder) { builder.SchemaVersion(5) })
+	assert.True(t, errors.Is(err, objectbox.ErrSchemaTooNew))
+
+	// opening read-only (including the recovery mode) doesn't store the version
+	assert.NoErr(t, open(func(builder *objectbox.Builder) { builder.SchemaVersion(7).ReadOnly() }))
+	assert.NoErr(t, open(func(builder *objectbox.Builder) { builder.SchemaVersion(8).RecoverMode() }))
+	contents, err := ioutil.ReadFile(filepath.Join(dir, "schema-version"))
+	assert.NoErr(t, err)
+	assert.Eq(t, "6\n", string(contents))
+
+	// the check is opt-in
+	assert.NoErr(t, open(func(builder *objectbox.Builder) {}))
+
+	// invalid configurations
+	assert.Err(t, open(func(builder *objectbox.Builder) { builder.MaxSchemaVersion(5) }))
+	assert.Err(t, open(func(builder *objectbox.Builder) { builder.SchemaVersion(7).MaxSchemaVersion(6) }))
+}
+
+func TestStoreClosed(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+
+	var box = iot.BoxForEvent(env.ObjectBox)
+	id, err := box.Put(&iot.Event{Device: "dev"})
+	assert.NoErr(t, err)
+	var query = box.Query(iot.Event_.Device.Equals("dev", true))
+	var propertyQuery = query.Property(iot.Event_.Date)
+	var async = box.Async()
+
+	env.ObjectBox.Close()
+
+	var assertClosed = func(err error) {
+		t.Helper()
+		assert.True(t, errors.Is(err, objectbox.ErrStoreClosed))
+	}
+
+	// ObjectBox
+	assertClosed(env.ObjectBox.Ping())
+	assertClosed(env.ObjectBox.RunInReadTx(func() error { return nil }))
+	assertClosed(env.ObjectBox.RunInWriteTx(func() error { return nil }))
+	assertClosed(env.ObjectBox.SetDebugFlags(0))
+	assertClosed(env.ObjectBox.AwaitAsyncCompletion())
+	assertClosed(env.ObjectBox.PutWithOutbox(&iot.Event{}, &iot.Reading{}))
+
+	// Box
+	var event = &iot.Event{Id: id}
+	_, err = box.Box.Put(event)
+	assertClosed(err)
+	_, err = box.Box.Insert(&iot.Event{})
+	assertClosed(err)
+	assertClosed(box.Box.Update(event))
+	_, err = box.Box.PutMany([]*iot.Event{{}})
+	assertClosed(err)
+	_, err = box.Box.PutAsync(&iot.Event{})
+	assertClosed(err)
+	_, err = box.Box.Get(id)
+	assertClosed(err)
+	_, err = box.Box.GetMany(id)
+	assertClosed(err)
+	_, err = box.Box.GetManyExisting(id)
+	assertClosed(err)
+	_, err = box.Box.GetAll()
+	assertClosed(err)
+	_, err = box.Box.GetIdRange(0, id)
+	assertClosed(err)
+	_, err = box.Box.GetAllWhere(func(interface{}) bool { return true })
+	assertClosed(err)
+	_, err = box.Box.Contains(id)
+	assertClosed(err)
+	_, err = box.Box.ContainsIds(id)
+	assertClosed(err)
+	_, err = box.Box.Count()
+	assertClosed(err)
+	_, err = box.Box.IsEmpty()
+	assertClosed(err)
+	assertClosed(box.Box.Remove(event))
+	assertClosed(box.Box.RemoveId(id))
+	_, err = box.Box.RemoveIds(id)
+	assertClosed(err)
+	assertClosed(box.Box.RemoveAll())
+	_, err = box.Box.QueryOrError()
+	assertClosed(err)
+	_, err = box.Box.Subscribe(func() {})
+	assertClosed(err)
+	_, err = box.Box.CachedCount()
+	assertClosed(err)
+
+	// AsyncBox
+	_, err = async.Put(&iot.Event{})
+	assertClosed(err)
+	assertClosed(async.RemoveId(id))
+	assertClosed(async.AwaitCompletion())
+	assertClosed(async.AwaitSubmitted())
+
+	// Query
+	_, err = query.Find()
+	assertClosed(err)
+	_, err = query.FindIds()
+	assertClosed(err)
+	_, err = query.Count()
+	assertClosed(err)
+	_, err = query.Remove()
+	assertClosed(err)
+	_, err = propertyQuery.Count()
+	assertClosed(err)
+	_, err = propertyQuery.FindInt64s(nil)
+	assertClosed(err)
+
+	// closing the query and the store again still works
+	assert.NoErr(t, query.Close())
+	env.ObjectBox.Close()
+}
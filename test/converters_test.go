@@ -18,6 +18,9 @@ package objectbox_test
 
 import (
 	"github.com/objectbox/objectbox-go/objectbox"
+	"net"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -161,3 +164,189 @@ func TestTimeBinaryConverter(t *testing.T) {
 		assert.Eq(t, date, value)
 	}
 }
+
+func TestIPConverter(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+	var box = model.BoxForTestEntityConverters(env.ObjectBox)
+
+	for _, text := range []string{"192.168.1.47", "2001:db8::47", "::ffff:10.0.0.1"} {
+		var ip = net.ParseIP(text)
+		id, err := box.Put(&model.TestEntityConverters{IP: ip})
+		assert.NoErr(t, err)
+
+		read, err := box.Get(id)
+		assert.NoErr(t, err)
+		assert.True(t, ip.Equal(read.IP))
+		assert.Eq(t, ip.String(), read.IP.String())
+	}
+
+	// IPv4 addresses are stored using the 4-byte representation
+	value, err := objectbox.IPBytesConvertToDatabaseValue(net.ParseIP("192.168.1.47"))
+	assert.NoErr(t, err)
+	assert.Eq(t, []byte{192, 168, 1, 47}, value)
+
+	// nil is stored as an empty vector and read back as nil
+	id, err := box.Put(&model.TestEntityConverters{})
+	assert.NoErr(t, err)
+	read, err := box.Get(id)
+	assert.NoErr(t, err)
+	assert.True(t, read.IP == nil)
+
+	_, err = objectbox.IPBytesConvertToEntityProperty([]byte{1, 2, 3})
+	assert.Err(t, err)
+	_, err = objectbox.IPBytesConvertToDatabaseValue(net.IP{1, 2, 3})
+	assert.Err(t, err)
+}
+
+func TestDurationConverter(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+	var box = model.BoxForTestEntityConverters(env.ObjectBox)
+
+	for _, duration := range []time.Duration{0, time.Nanosecond, 47 * time.Hour, -time.Minute} {
+		id, err := box.Put(&model.TestEntityConverters{Duration: duration})
+		assert.NoErr(t, err)
+
+		read, err := box.Get(id)
+		assert.NoErr(t, err)
+		assert.Eq(t, duration, read.Duration)
+	}
+}
+
+func TestStringMapConverter(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+	var box = model.BoxForTestEntityConverters(env.ObjectBox)
+
+	for _, metadata := range []map[string]string{
+		nil,
+		{},
+		{"key": "value"},
+		{"": "empty key", "empty value": "", "unicode": "žluťoučký kůň", "quotes": `"{}"`},
+	} {
+		id, err := box.Put(&model.TestEntityConverters{Metadata: metadata})
+		assert.NoErr(t, err)
+
+		read, err := box.Get(id)
+		assert.NoErr(t, err)
+		assert.Eq(t, metadata, read.Metadata)
+		assert.Eq(t, metadata == nil, read.Metadata == nil)
+	}
+
+	// keys are sorted so the stored value doesn't depend on the map iteration order
+	value, err := objectbox.StringMapBytesConvertToDatabaseValue(map[string]string{"b": "2", "a": "1"})
+	assert.NoErr(t, err)
+	assert.Eq(t, `{"a":"1","b":"2"}`, string(value))
+
+	_, err = objectbox.StringMapBytesConvertToEntityProperty([]byte("not json"))
+	assert.Err(t, err)
+}
+
+func TestGzipConverters(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+	var box = model.BoxForTestEntityConverters(env.ObjectBox)
+
+	var large = strings.Repeat("All work and no play makes Jack a dull boy. ", 10000)
+	for _, text := range []string{"", "x", "žluťoučký kůň", large} {
+		id, err := box.Put(&model.TestEntityConverters{Text: text})
+		assert.NoErr(t, err)
+
+		read, err := box.Get(id)
+		assert.NoErr(t, err)
+		assert.Eq(t, text, read.Text)
+	}
+
+	for _, blob := range [][]byte{nil, {}, {0}, []byte(large)} {
+		id, err := box.Put(&model.TestEntityConverters{Blob: blob})
+		assert.NoErr(t, err)
+
+		read, err := box.Get(id)
+		assert.NoErr(t, err)
+		assert.Eq(t, blob, read.Blob)
+		assert.Eq(t, blob == nil, read.Blob == nil)
+	}
+
+	// the stored object is much smaller than the uncompressed text
+	id, err := box.Put(&model.TestEntityConverters{Text: large})
+	assert.NoErr(t, err)
+	stored, err := box.GetBytes(id)
+	assert.NoErr(t, err)
+	t.Logf("stored %d bytes for a text of %d bytes", len(stored), len(large))
+	assert.True(t, len(stored) < len(large)/10)
+
+	_, err = objectbox.GzipStringConvertToEntityProperty([]byte("not gzip"))
+	assert.Err(t, err)
+}
+
+func TestRegisterCodec(t *testing.T) {
+	var reverse = func(data []byte) ([]byte, error) {
+		var result = make([]byte, len(data))
+		for i, b := range data {
+			result[len(data)-1-i] = b
+		}
+		return result, nil
+	}
+
+	assert.Err(t, objectbox.RegisterCodec("", reverse, reverse))
+	assert.Err(t, objectbox.RegisterCodec("reverse", reverse, nil))
+	assert.NoErr(t, objectbox.RegisterCodec("reverse", reverse, reverse))
+
+	compressed, err := objectbox.Compress("reverse", []byte("abc"))
+	assert.NoErr(t, err)
+	assert.Eq(t, []byte("cba"), compressed)
+
+	data, err := objectbox.Decompress("reverse", compressed)
+	assert.NoErr(t, err)
+	assert.Eq(t, []byte("abc"), data)
+
+	compressed, err = objectbox.Compress("gzip", []byte("abc"))
+	assert.NoErr(t, err)
+	data, err = objectbox.Decompress("gzip", compressed)
+	assert.NoErr(t, err)
+	assert.Eq(t, []byte("abc"), data)
+
+	_, err = objectbox.Compress("unknown", []byte("abc"))
+	assert.Err(t, err)
+	_, err = objectbox.Decompress("gzip", []byte("abc"))
+	assert.Err(t, err)
+}
+
+func TestRegisteredConverter(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+	var box = model.BoxForTestEntityRegisteredConverter(env.ObjectBox)
+	var moneyType = reflect.TypeOf(model.Money{})
+
+	// the database value type must be natively supported
+	assert.Err(t, objectbox.RegisterConverter(moneyType,
+		func(goValue interface{}) interface{} { return goValue },
+		func(dbValue interface{}) interface{} { return dbValue }))
+	assert.Err(t, objectbox.RegisterConverter(moneyType, nil, nil))
+
+	assert.NoErr(t, objectbox.RegisterConverter(moneyType,
+		func(goValue interface{}) interface{} { return goValue.(model.Money).Cents },
+		func(dbValue interface{}) interface{} { return model.Money{Cents: dbValue.(int64)} }))
+
+	var object = &model.TestEntityRegisteredConverter{Price: model.Money{Cents: 4747}}
+	id, err := box.Put(object)
+	assert.NoErr(t, err)
+
+	read, err := box.Get(id)
+	assert.NoErr(t, err)
+	assert.Eq(t, object, read)
+
+	// stored as the DB-side type; can be queried as such
+	count, err := box.Query(model.TestEntityRegisteredConverter_.Price.Equals(4747)).Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1), count)
+
+	// mismatching DB value type
+	_, err = objectbox.ConvertToEntityProperty(moneyType, int32(47))
+	assert.Err(t, err)
+
+	// unregistered type
+	_, err = objectbox.ConvertToDatabaseValue(struct{}{})
+	assert.Err(t, err)
+}
@@ -0,0 +1,49 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model"
+)
+
+func TestExportCSV(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+
+	_, err := env.Box.PutMany([]*model.Entity{
+		{String: "plain", Int64: -47, Uint64: 47, Bool: true},
+		{String: "with, comma", Float64: 1.5},
+		{String: "with \"quotes\" and\nnew line"},
+	})
+	assert.NoErr(t, err)
+
+	var buf bytes.Buffer
+	assert.NoErr(t, env.Box.ExportCSV(&buf, []string{"Id", "String", "Int64", "Uint64", "Bool", "Float64"}))
+	assert.Eq(t, "Id,String,Int64,Uint64,Bool,Float64\n"+
+		"1,plain,-47,47,true,0\n"+
+		"2,\"with, comma\",0,0,false,1.5\n"+
+		"3,\"with \"\"quotes\"\" and\nnew line\",0,0,false,0\n", buf.String())
+
+	// unknown properties are reported before anything is written
+	buf.Reset()
+	assert.Err(t, env.Box.ExportCSV(&buf, []string{"Id", "NonExistent"}))
+	assert.Eq(t, 0, buf.Len())
+}
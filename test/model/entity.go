@@ -16,7 +16,10 @@
 
 package model
 
-import "time"
+import (
+	"net"
+	"time"
+)
 
 //go:generate go run github.com/objectbox/objectbox-go/cmd/objectbox-gogen
 
@@ -105,3 +108,50 @@ type TestEntitySynced struct {
 	Id   uint64
 	Name string
 }
+
+// TestEntityTransient model
+type TestEntityTransient struct {
+	Id       uint64
+	Name     string
+	Computed string `objectbox:"-"` // not persisted, doesn't get a property ID in the model
+	Count    int
+}
+
+// TestEntityConverters model using converters for standard library types
+type TestEntityConverters struct {
+	Id       uint64
+	IP       net.IP            `objectbox:"type:[]byte converter:objectbox.IPBytesConvert"`
+	Duration time.Duration     `objectbox:"type:int64 converter:objectbox.DurationInt64Convert"`
+	Metadata map[string]string `objectbox:"type:[]byte converter:objectbox.StringMapBytesConvert"`
+	Text     string            `objectbox:"type:[]byte converter:objectbox.GzipStringConvert"`
+	Blob     []byte            `objectbox:"type:[]byte converter:objectbox.GzipBytesConvert"`
+}
+
+// TestEntityRegisteredConverter model using a custom type with a converter registered at runtime
+type TestEntityRegisteredConverter struct {
+	Id    uint64
+	Price Money `objectbox:"type:int64 converter:moneyCents"`
+}
+
+// SliceLen implements objectbox.ObjectSliceBinding, letting PutMany() access the objects without reflection
+func (entity_EntityInfo) SliceLen(slice interface{}) int {
+	return len(slice.([]*Entity))
+}
+
+// SliceObject implements objectbox.ObjectSliceBinding
+func (entity_EntityInfo) SliceObject(slice interface{}, index int) interface{} {
+	return slice.([]*Entity)[index]
+}
+
+// NewSliceAppender implements objectbox.SliceAppenderBinding, letting GetAll() & co. build []*Entity directly
+func (entity_EntityInfo) NewSliceAppender(capacity int) (func(object interface{}), func() interface{}) {
+	var slice = make([]*Entity, 0, capacity)
+	var add = func(object interface{}) {
+		if object == nil {
+			slice = append(slice, nil)
+		} else {
+			slice = append(slice, object.(*Entity))
+		}
+	}
+	return add, func() interface{} { return slice }
+}
@@ -2376,3 +2376,1119 @@ func (query *TestEntitySyncedQuery) Limit(limit uint64) *TestEntitySyncedQuery {
 	query.Query.Limit(limit)
 	return query
 }
+
+type testEntityTransient_EntityInfo struct {
+	objectbox.Entity
+	Uid uint64
+}
+
+var TestEntityTransientBinding = testEntityTransient_EntityInfo{
+	Entity: objectbox.Entity{
+		Id: 9,
+	},
+	Uid: 2557949956931900356,
+}
+
+// TestEntityTransient_ contains type-based Property helpers to facilitate some common operations such as Queries.
+var TestEntityTransient_ = struct {
+	Id    *objectbox.PropertyUint64
+	Name  *objectbox.PropertyString
+	Count *objectbox.PropertyInt
+}{
+	Id: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     1,
+			Entity: &TestEntityTransientBinding.Entity,
+		},
+	},
+	Name: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     2,
+			Entity: &TestEntityTransientBinding.Entity,
+		},
+	},
+	Count: &objectbox.PropertyInt{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     3,
+			Entity: &TestEntityTransientBinding.Entity,
+		},
+	},
+}
+
+// GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
+func (testEntityTransient_EntityInfo) GeneratorVersion() int {
+	return 6
+}
+
+// AddToModel is called by ObjectBox during model build
+func (testEntityTransient_EntityInfo) AddToModel(model *objectbox.Model) {
+	model.Entity("TestEntityTransient", 9, 2557949956931900356)
+	model.Property("Id", 6, 1, 3928729900778336466)
+	model.PropertyFlags(1)
+	model.Property("Name", 9, 2, 5756038558011926772)
+	model.Property("Count", 6, 3, 8750828243366379688)
+	model.EntityLastPropertyId(3, 8750828243366379688)
+}
+
+// GetId is called by ObjectBox during Put operations to check for existing ID on an object
+func (testEntityTransient_EntityInfo) GetId(object interface{}) (uint64, error) {
+	return object.(*TestEntityTransient).Id, nil
+}
+
+// SetId is called by ObjectBox during Put to update an ID on an object that has just been inserted
+func (testEntityTransient_EntityInfo) SetId(object interface{}, id uint64) error {
+	object.(*TestEntityTransient).Id = id
+	return nil
+}
+
+// PutRelated is called by ObjectBox to put related entities before the object itself is flattened and put
+func (testEntityTransient_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{}, id uint64) error {
+	return nil
+}
+
+// Flatten is called by ObjectBox to transform an object to a FlatBuffer
+func (testEntityTransient_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
+	obj := object.(*TestEntityTransient)
+	var offsetName = fbutils.CreateStringOffset(fbb, obj.Name)
+
+	// build the FlatBuffers object
+	fbb.StartObject(3)
+	fbutils.SetUint64Slot(fbb, 0, id)
+	fbutils.SetUOffsetTSlot(fbb, 1, offsetName)
+	fbutils.SetInt64Slot(fbb, 2, int64(obj.Count))
+	return nil
+}
+
+// Load is called by ObjectBox to load an object from a FlatBuffer
+func (testEntityTransient_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}, error) {
+	if len(bytes) == 0 { // sanity check, should "never" happen
+		return nil, errors.New("can't deserialize an object of type 'TestEntityTransient' - no data received")
+	}
+
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+
+	var propId = table.GetUint64Slot(4, 0)
+
+	return &TestEntityTransient{
+		Id:    propId,
+		Name:  fbutils.GetStringSlot(table, 6),
+		Count: fbutils.GetIntSlot(table, 8),
+	}, nil
+}
+
+// MakeSlice is called by ObjectBox to construct a new slice to hold the read objects
+func (testEntityTransient_EntityInfo) MakeSlice(capacity int) interface{} {
+	return make([]*TestEntityTransient, 0, capacity)
+}
+
+// AppendToSlice is called by ObjectBox to fill the slice of the read objects
+func (testEntityTransient_EntityInfo) AppendToSlice(slice interface{}, object interface{}) interface{} {
+	if object == nil {
+		return append(slice.([]*TestEntityTransient), nil)
+	}
+	return append(slice.([]*TestEntityTransient), object.(*TestEntityTransient))
+}
+
+// Box provides CRUD access to TestEntityTransient objects
+type TestEntityTransientBox struct {
+	*objectbox.Box
+}
+
+// BoxForTestEntityTransient opens a box of TestEntityTransient objects
+func BoxForTestEntityTransient(ob *objectbox.ObjectBox) *TestEntityTransientBox {
+	return &TestEntityTransientBox{
+		Box: ob.InternalBox(9),
+	}
+}
+
+// Put synchronously inserts/updates a single object.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the TestEntityTransient.Id property on the passed object will be assigned the new ID as well.
+func (box *TestEntityTransientBox) Put(object *TestEntityTransient) (uint64, error) {
+	return box.Box.Put(object)
+}
+
+// Insert synchronously inserts a single object. As opposed to Put, Insert will fail if given an ID that already exists.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the TestEntityTransient.Id property on the passed object will be assigned the new ID as well.
+func (box *TestEntityTransientBox) Insert(object *TestEntityTransient) (uint64, error) {
+	return box.Box.Insert(object)
+}
+
+// Update synchronously updates a single object.
+// As opposed to Put, Update will fail if an object with the same ID is not found in the database.
+func (box *TestEntityTransientBox) Update(object *TestEntityTransient) error {
+	return box.Box.Update(object)
+}
+
+// PutAsync asynchronously inserts/updates a single object.
+// Deprecated: use box.Async().Put() instead
+func (box *TestEntityTransientBox) PutAsync(object *TestEntityTransient) (uint64, error) {
+	return box.Box.PutAsync(object)
+}
+
+// PutMany inserts multiple objects in single transaction.
+// In case Ids are not set on the objects, they would be assigned automatically (auto-increment).
+//
+// Returns: IDs of the put objects (in the same order).
+// When inserting, the TestEntityTransient.Id property on the objects in the slice will be assigned the new IDs as well.
+//
+// Note: In case an error occurs during the transaction, some of the objects may already have the TestEntityTransient.Id assigned
+// even though the transaction has been rolled back and the objects are not stored under those IDs.
+//
+// Note: The slice may be empty or even nil; in both cases, an empty IDs slice and no error is returned.
+func (box *TestEntityTransientBox) PutMany(objects []*TestEntityTransient) ([]uint64, error) {
+	return box.Box.PutMany(objects)
+}
+
+// Get reads a single object.
+//
+// Returns nil (and no error) in case the object with the given ID doesn't exist.
+func (box *TestEntityTransientBox) Get(id uint64) (*TestEntityTransient, error) {
+	object, err := box.Box.Get(id)
+	if err != nil {
+		return nil, err
+	} else if object == nil {
+		return nil, nil
+	}
+	return object.(*TestEntityTransient), nil
+}
+
+// GetMany reads multiple objects at once.
+// If any of the objects doesn't exist, its position in the return slice is nil
+func (box *TestEntityTransientBox) GetMany(ids ...uint64) ([]*TestEntityTransient, error) {
+	objects, err := box.Box.GetMany(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*TestEntityTransient), nil
+}
+
+// GetManyExisting reads multiple objects at once, skipping those that do not exist.
+func (box *TestEntityTransientBox) GetManyExisting(ids ...uint64) ([]*TestEntityTransient, error) {
+	objects, err := box.Box.GetManyExisting(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*TestEntityTransient), nil
+}
+
+// GetAll reads all stored objects
+func (box *TestEntityTransientBox) GetAll() ([]*TestEntityTransient, error) {
+	objects, err := box.Box.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*TestEntityTransient), nil
+}
+
+// Remove deletes a single object
+func (box *TestEntityTransientBox) Remove(object *TestEntityTransient) error {
+	return box.Box.Remove(object)
+}
+
+// RemoveMany deletes multiple objects at once.
+// Returns the number of deleted object or error on failure.
+// Note that this method will not fail if an object is not found (e.g. already removed).
+// In case you need to strictly check whether all of the objects exist before removing them,
+// you can execute multiple box.Contains() and box.Remove() inside a single write transaction.
+func (box *TestEntityTransientBox) RemoveMany(objects ...*TestEntityTransient) (uint64, error) {
+	var ids = make([]uint64, len(objects))
+	for k, object := range objects {
+		ids[k] = object.Id
+	}
+	return box.Box.RemoveIds(ids...)
+}
+
+// Creates a query with the given conditions. Use the fields of the TestEntityTransient_ struct to create conditions.
+// Keep the *TestEntityTransientQuery if you intend to execute the query multiple times.
+// Note: this function panics if you try to create illegal queries; e.g. use properties of an alien type.
+// This is typically a programming error. Use QueryOrError instead if you want the explicit error check.
+func (box *TestEntityTransientBox) Query(conditions ...objectbox.Condition) *TestEntityTransientQuery {
+	return &TestEntityTransientQuery{
+		box.Box.Query(conditions...),
+	}
+}
+
+// Creates a query with the given conditions. Use the fields of the TestEntityTransient_ struct to create conditions.
+// Keep the *TestEntityTransientQuery if you intend to execute the query multiple times.
+func (box *TestEntityTransientBox) QueryOrError(conditions ...objectbox.Condition) (*TestEntityTransientQuery, error) {
+	if query, err := box.Box.QueryOrError(conditions...); err != nil {
+		return nil, err
+	} else {
+		return &TestEntityTransientQuery{query}, nil
+	}
+}
+
+// Async provides access to the default Async Box for asynchronous operations. See TestEntityTransientAsyncBox for more information.
+func (box *TestEntityTransientBox) Async() *TestEntityTransientAsyncBox {
+	return &TestEntityTransientAsyncBox{AsyncBox: box.Box.Async()}
+}
+
+// TestEntityTransientAsyncBox provides asynchronous operations on TestEntityTransient objects.
+//
+// Asynchronous operations are executed on a separate internal thread for better performance.
+//
+// There are two main use cases:
+//
+// 1) "execute & forget:" you gain faster put/remove operations as you don't have to wait for the transaction to finish.
+//
+// 2) Many small transactions: if your write load is typically a lot of individual puts that happen in parallel,
+// this will merge small transactions into bigger ones. This results in a significant gain in overall throughput.
+//
+// In situations with (extremely) high async load, an async method may be throttled (~1ms) or delayed up to 1 second.
+// In the unlikely event that the object could still not be enqueued (full queue), an error will be returned.
+//
+// Note that async methods do not give you hard durability guarantees like the synchronous Box provides.
+// There is a small time window in which the data may not have been committed durably yet.
+type TestEntityTransientAsyncBox struct {
+	*objectbox.AsyncBox
+}
+
+// AsyncBoxForTestEntityTransient creates a new async box with the given operation timeout in case an async queue is full.
+// The returned struct must be freed explicitly using the Close() method.
+// It's usually preferable to use TestEntityTransientBox::Async() which takes care of resource management and doesn't require closing.
+func AsyncBoxForTestEntityTransient(ob *objectbox.ObjectBox, timeoutMs uint64) *TestEntityTransientAsyncBox {
+	var async, err = objectbox.NewAsyncBox(ob, 9, timeoutMs)
+	if err != nil {
+		panic("Could not create async box for entity ID 9: %s" + err.Error())
+	}
+	return &TestEntityTransientAsyncBox{AsyncBox: async}
+}
+
+// Put inserts/updates a single object asynchronously.
+// When inserting a new object, the Id property on the passed object will be assigned the new ID the entity would hold
+// if the insert is ultimately successful. The newly assigned ID may not become valid if the insert fails.
+func (asyncBox *TestEntityTransientAsyncBox) Put(object *TestEntityTransient) (uint64, error) {
+	return asyncBox.AsyncBox.Put(object)
+}
+
+// Insert a single object asynchronously.
+// The Id property on the passed object will be assigned the new ID the entity would hold if the insert is ultimately
+// successful. The newly assigned ID may not become valid if the insert fails.
+// Fails silently if an object with the same ID already exists (this error is not returned).
+func (asyncBox *TestEntityTransientAsyncBox) Insert(object *TestEntityTransient) (id uint64, err error) {
+	return asyncBox.AsyncBox.Insert(object)
+}
+
+// Update a single object asynchronously.
+// The object must already exists or the update fails silently (without an error returned).
+func (asyncBox *TestEntityTransientAsyncBox) Update(object *TestEntityTransient) error {
+	return asyncBox.AsyncBox.Update(object)
+}
+
+// Remove deletes a single object asynchronously.
+func (asyncBox *TestEntityTransientAsyncBox) Remove(object *TestEntityTransient) error {
+	return asyncBox.AsyncBox.Remove(object)
+}
+
+// Query provides a way to search stored objects
+//
+// For example, you can find all TestEntityTransient which Id is either 42 or 47:
+// 		box.Query(TestEntityTransient_.Id.In(42, 47)).Find()
+type TestEntityTransientQuery struct {
+	*objectbox.Query
+}
+
+// Find returns all objects matching the query
+func (query *TestEntityTransientQuery) Find() ([]*TestEntityTransient, error) {
+	objects, err := query.Query.Find()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*TestEntityTransient), nil
+}
+
+// Offset defines the index of the first object to process (how many objects to skip)
+func (query *TestEntityTransientQuery) Offset(offset uint64) *TestEntityTransientQuery {
+	query.Query.Offset(offset)
+	return query
+}
+
+// Limit sets the number of elements to process by the query
+func (query *TestEntityTransientQuery) Limit(limit uint64) *TestEntityTransientQuery {
+	query.Query.Limit(limit)
+	return query
+}
+
+type testEntityConverters_EntityInfo struct {
+	objectbox.Entity
+	Uid uint64
+}
+
+var TestEntityConvertersBinding = testEntityConverters_EntityInfo{
+	Entity: objectbox.Entity{
+		Id: 10,
+	},
+	Uid: 8393834535668275107,
+}
+
+// TestEntityConverters_ contains type-based Property helpers to facilitate some common operations such as Queries.
+var TestEntityConverters_ = struct {
+	Id       *objectbox.PropertyUint64
+	IP       *objectbox.PropertyByteVector
+	Duration *objectbox.PropertyInt64
+	Metadata *objectbox.PropertyByteVector
+	Text     *objectbox.PropertyByteVector
+	Blob     *objectbox.PropertyByteVector
+}{
+	Id: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     1,
+			Entity: &TestEntityConvertersBinding.Entity,
+		},
+	},
+	IP: &objectbox.PropertyByteVector{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     2,
+			Entity: &TestEntityConvertersBinding.Entity,
+		},
+	},
+	Duration: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     3,
+			Entity: &TestEntityConvertersBinding.Entity,
+		},
+	},
+	Metadata: &objectbox.PropertyByteVector{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     4,
+			Entity: &TestEntityConvertersBinding.Entity,
+		},
+	},
+	Text: &objectbox.PropertyByteVector{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     5,
+			Entity: &TestEntityConvertersBinding.Entity,
+		},
+	},
+	Blob: &objectbox.PropertyByteVector{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     6,
+			Entity: &TestEntityConvertersBinding.Entity,
+		},
+	},
+}
+
+// GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
+func (testEntityConverters_EntityInfo) GeneratorVersion() int {
+	return 6
+}
+
+// AddToModel is called by ObjectBox during model build
+func (testEntityConverters_EntityInfo) AddToModel(model *objectbox.Model) {
+	model.Entity("TestEntityConverters", 10, 8393834535668275107)
+	model.Property("Id", 6, 1, 1817632852506335748)
+	model.PropertyFlags(1)
+	model.Property("IP", 23, 2, 3979912632362126238)
+	model.Property("Duration", 6, 3, 1346251146646514151)
+	model.Property("Metadata", 23, 4, 4414697224009749850)
+	model.Property("Text", 23, 5, 7286013376184643708)
+	model.Property("Blob", 23, 6, 2749336721360328061)
+	model.EntityLastPropertyId(6, 2749336721360328061)
+}
+
+// GetId is called by ObjectBox during Put operations to check for existing ID on an object
+func (testEntityConverters_EntityInfo) GetId(object interface{}) (uint64, error) {
+	return object.(*TestEntityConverters).Id, nil
+}
+
+// SetId is called by ObjectBox during Put to update an ID on an object that has just been inserted
+func (testEntityConverters_EntityInfo) SetId(object interface{}, id uint64) error {
+	object.(*TestEntityConverters).Id = id
+	return nil
+}
+
+// PutRelated is called by ObjectBox to put related entities before the object itself is flattened and put
+func (testEntityConverters_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{}, id uint64) error {
+	return nil
+}
+
+// Flatten is called by ObjectBox to transform an object to a FlatBuffer
+func (testEntityConverters_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
+	obj := object.(*TestEntityConverters)
+	var propIP []byte
+	{
+		var err error
+		propIP, err = objectbox.IPBytesConvertToDatabaseValue(obj.IP)
+		if err != nil {
+			return errors.New("converter objectbox.IPBytesConvertToDatabaseValue() failed on TestEntityConverters.IP: " + err.Error())
+		}
+	}
+
+	var propDuration int64
+	{
+		var err error
+		propDuration, err = objectbox.DurationInt64ConvertToDatabaseValue(obj.Duration)
+		if err != nil {
+			return errors.New("converter objectbox.DurationInt64ConvertToDatabaseValue() failed on TestEntityConverters.Duration: " + err.Error())
+		}
+	}
+
+	var propMetadata []byte
+	{
+		var err error
+		propMetadata, err = objectbox.StringMapBytesConvertToDatabaseValue(obj.Metadata)
+		if err != nil {
+			return errors.New("converter objectbox.StringMapBytesConvertToDatabaseValue() failed on TestEntityConverters.Metadata: " + err.Error())
+		}
+	}
+
+	var propText []byte
+	{
+		var err error
+		propText, err = objectbox.GzipStringConvertToDatabaseValue(obj.Text)
+		if err != nil {
+			return errors.New("converter objectbox.GzipStringConvertToDatabaseValue() failed on TestEntityConverters.Text: " + err.Error())
+		}
+	}
+
+	var propBlob []byte
+	{
+		var err error
+		propBlob, err = objectbox.GzipBytesConvertToDatabaseValue(obj.Blob)
+		if err != nil {
+			return errors.New("converter objectbox.GzipBytesConvertToDatabaseValue() failed on TestEntityConverters.Blob: " + err.Error())
+		}
+	}
+
+	var offsetIP = fbutils.CreateByteVectorOffset(fbb, propIP)
+	var offsetMetadata = fbutils.CreateByteVectorOffset(fbb, propMetadata)
+	var offsetText = fbutils.CreateByteVectorOffset(fbb, propText)
+	var offsetBlob = fbutils.CreateByteVectorOffset(fbb, propBlob)
+
+	// build the FlatBuffers object
+	fbb.StartObject(6)
+	fbutils.SetUint64Slot(fbb, 0, id)
+	fbutils.SetUOffsetTSlot(fbb, 1, offsetIP)
+	fbutils.SetInt64Slot(fbb, 2, propDuration)
+	fbutils.SetUOffsetTSlot(fbb, 3, offsetMetadata)
+	fbutils.SetUOffsetTSlot(fbb, 4, offsetText)
+	fbutils.SetUOffsetTSlot(fbb, 5, offsetBlob)
+	return nil
+}
+
+// Load is called by ObjectBox to load an object from a FlatBuffer
+func (testEntityConverters_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}, error) {
+	if len(bytes) == 0 { // sanity check, should "never" happen
+		return nil, errors.New("can't deserialize an object of type 'TestEntityConverters' - no data received")
+	}
+
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+
+	var propId = table.GetUint64Slot(4, 0)
+
+	propIP, err := objectbox.IPBytesConvertToEntityProperty(fbutils.GetByteVectorSlot(table, 6))
+	if err != nil {
+		return nil, errors.New("converter objectbox.IPBytesConvertToEntityProperty() failed on TestEntityConverters.IP: " + err.Error())
+	}
+
+	propDuration, err := objectbox.DurationInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 8))
+	if err != nil {
+		return nil, errors.New("converter objectbox.DurationInt64ConvertToEntityProperty() failed on TestEntityConverters.Duration: " + err.Error())
+	}
+
+	propMetadata, err := objectbox.StringMapBytesConvertToEntityProperty(fbutils.GetByteVectorSlot(table, 10))
+	if err != nil {
+		return nil, errors.New("converter objectbox.StringMapBytesConvertToEntityProperty() failed on TestEntityConverters.Metadata: " + err.Error())
+	}
+
+	propText, err := objectbox.GzipStringConvertToEntityProperty(fbutils.GetByteVectorSlot(table, 12))
+	if err != nil {
+		return nil, errors.New("converter objectbox.GzipStringConvertToEntityProperty() failed on TestEntityConverters.Text: " + err.Error())
+	}
+
+	propBlob, err := objectbox.GzipBytesConvertToEntityProperty(fbutils.GetByteVectorSlot(table, 14))
+	if err != nil {
+		return nil, errors.New("converter objectbox.GzipBytesConvertToEntityProperty() failed on TestEntityConverters.Blob: " + err.Error())
+	}
+
+	return &TestEntityConverters{
+		Id:       propId,
+		IP:       propIP,
+		Duration: propDuration,
+		Metadata: propMetadata,
+		Text:     propText,
+		Blob:     propBlob,
+	}, nil
+}
+
+// MakeSlice is called by ObjectBox to construct a new slice to hold the read objects
+func (testEntityConverters_EntityInfo) MakeSlice(capacity int) interface{} {
+	return make([]*TestEntityConverters, 0, capacity)
+}
+
+// AppendToSlice is called by ObjectBox to fill the slice of the read objects
+func (testEntityConverters_EntityInfo) AppendToSlice(slice interface{}, object interface{}) interface{} {
+	if object == nil {
+		return append(slice.([]*TestEntityConverters), nil)
+	}
+	return append(slice.([]*TestEntityConverters), object.(*TestEntityConverters))
+}
+
+// Box provides CRUD access to TestEntityConverters objects
+type TestEntityConvertersBox struct {
+	*objectbox.Box
+}
+
+// BoxForTestEntityConverters opens a box of TestEntityConverters objects
+func BoxForTestEntityConverters(ob *objectbox.ObjectBox) *TestEntityConvertersBox {
+	return &TestEntityConvertersBox{
+		Box: ob.InternalBox(10),
+	}
+}
+
+// Put synchronously inserts/updates a single object.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the TestEntityConverters.Id property on the passed object will be assigned the new ID as well.
+func (box *TestEntityConvertersBox) Put(object *TestEntityConverters) (uint64, error) {
+	return box.Box.Put(object)
+}
+
+// Insert synchronously inserts a single object. As opposed to Put, Insert will fail if given an ID that already exists.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the TestEntityConverters.Id property on the passed object will be assigned the new ID as well.
+func (box *TestEntityConvertersBox) Insert(object *TestEntityConverters) (uint64, error) {
+	return box.Box.Insert(object)
+}
+
+// Update synchronously updates a single object.
+// As opposed to Put, Update will fail if an object with the same ID is not found in the database.
+func (box *TestEntityConvertersBox) Update(object *TestEntityConverters) error {
+	return box.Box.Update(object)
+}
+
+// PutAsync asynchronously inserts/updates a single object.
+// Deprecated: use box.Async().Put() instead
+func (box *TestEntityConvertersBox) PutAsync(object *TestEntityConverters) (uint64, error) {
+	return box.Box.PutAsync(object)
+}
+
+// PutMany inserts multiple objects in single transaction.
+// In case Ids are not set on the objects, they would be assigned automatically (auto-increment).
+//
+// Returns: IDs of the put objects (in the same order).
+// When inserting, the TestEntityConverters.Id property on the objects in the slice will be assigned the new IDs as well.
+//
+// Note: In case an error occurs during the transaction, some of the objects may already have the TestEntityConverters.Id assigned
+// even though the transaction has been rolled back and the objects are not stored under those IDs.
+//
+// Note: The slice may be empty or even nil; in both cases, an empty IDs slice and no error is returned.
+func (box *TestEntityConvertersBox) PutMany(objects []*TestEntityConverters) ([]uint64, error) {
+	return box.Box.PutMany(objects)
+}
+
+// Get reads a single object.
+//
+// Returns nil (and no error) in case the object with the given ID doesn't exist.
+func (box *TestEntityConvertersBox) Get(id uint64) (*TestEntityConverters, error) {
+	object, err := box.Box.Get(id)
+	if err != nil {
+		return nil, err
+	} else if object == nil {
+		return nil, nil
+	}
+	return object.(*TestEntityConverters), nil
+}
+
+// GetMany reads multiple objects at once.
+// If any of the objects doesn't exist, its position in the return slice is nil
+func (box *TestEntityConvertersBox) GetMany(ids ...uint64) ([]*TestEntityConverters, error) {
+	objects, err := box.Box.GetMany(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*TestEntityConverters), nil
+}
+
+// GetManyExisting reads multiple objects at once, skipping those that do not exist.
+func (box *TestEntityConvertersBox) GetManyExisting(ids ...uint64) ([]*TestEntityConverters, error) {
+	objects, err := box.Box.GetManyExisting(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*TestEntityConverters), nil
+}
+
+// GetAll reads all stored objects
+func (box *TestEntityConvertersBox) GetAll() ([]*TestEntityConverters, error) {
+	objects, err := box.Box.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*TestEntityConverters), nil
+}
+
+// Remove deletes a single object
+func (box *TestEntityConvertersBox) Remove(object *TestEntityConverters) error {
+	return box.Box.Remove(object)
+}
+
+// RemoveMany deletes multiple objects at once.
+// Returns the number of deleted object or error on failure.
+// Note that this method will not fail if an object is not found (e.g. already removed).
+// In case you need to strictly check whether all of the objects exist before removing them,
+// you can execute multiple box.Contains() and box.Remove() inside a single write transaction.
+func (box *TestEntityConvertersBox) RemoveMany(objects ...*TestEntityConverters) (uint64, error) {
+	var ids = make([]uint64, len(objects))
+	for k, object := range objects {
+		ids[k] = object.Id
+	}
+	return box.Box.RemoveIds(ids...)
+}
+
+// Creates a query with the given conditions. Use the fields of the TestEntityConverters_ struct to create conditions.
+// Keep the *TestEntityConvertersQuery if you intend to execute the query multiple times.
+// Note: this function panics if you try to create illegal queries; e.g. use properties of an alien type.
+// This is typically a programming error. Use QueryOrError instead if you want the explicit error check.
+func (box *TestEntityConvertersBox) Query(conditions ...objectbox.Condition) *TestEntityConvertersQuery {
+	return &TestEntityConvertersQuery{
+		box.Box.Query(conditions...),
+	}
+}
+
+// Creates a query with the given conditions. Use the fields of the TestEntityConverters_ struct to create conditions.
+// Keep the *TestEntityConvertersQuery if you intend to execute the query multiple times.
+func (box *TestEntityConvertersBox) QueryOrError(conditions ...objectbox.Condition) (*TestEntityConvertersQuery, error) {
+	if query, err := box.Box.QueryOrError(conditions...); err != nil {
+		return nil, err
+	} else {
+		return &TestEntityConvertersQuery{query}, nil
+	}
+}
+
+// Async provides access to the default Async Box for asynchronous operations. See TestEntityConvertersAsyncBox for more information.
+func (box *TestEntityConvertersBox) Async() *TestEntityConvertersAsyncBox {
+	return &TestEntityConvertersAsyncBox{AsyncBox: box.Box.Async()}
+}
+
+// TestEntityConvertersAsyncBox provides asynchronous operations on TestEntityConverters objects.
+//
+// Asynchronous operations are executed on a separate internal thread for better performance.
+//
+// There are two main use cases:
+//
+// 1) "execute & forget:" you gain faster put/remove operations as you don't have to wait for the transaction to finish.
+//
+// 2) Many small transactions: if your write load is typically a lot of individual puts that happen in parallel,
+// this will merge small transactions into bigger ones. This results in a significant gain in overall throughput.
+//
+// In situations with (extremely) high async load, an async method may be throttled (~1ms) or delayed up to 1 second.
+// In the unlikely event that the object could still not be enqueued (full queue), an error will be returned.
+//
+// Note that async methods do not give you hard durability guarantees like the synchronous Box provides.
+// There is a small time window in which the data may not have been committed durably yet.
+type TestEntityConvertersAsyncBox struct {
+	*objectbox.AsyncBox
+}
+
+// AsyncBoxForTestEntityConverters creates a new async box with the given operation timeout in case an async queue is full.
+// The returned struct must be freed explicitly using the Close() method.
+// It's usually preferable to use TestEntityConvertersBox::Async() which takes care of resource management and doesn't require closing.
+func AsyncBoxForTestEntityConverters(ob *objectbox.ObjectBox, timeoutMs uint64) *TestEntityConvertersAsyncBox {
+	var async, err = objectbox.NewAsyncBox(ob, 10, timeoutMs)
+	if err != nil {
+		panic("Could not create async box for entity ID 10: %s" + err.Error())
+	}
+	return &TestEntityConvertersAsyncBox{AsyncBox: async}
+}
+
+// Put inserts/updates a single object asynchronously.
+// When inserting a new object, the Id property on the passed object will be assigned the new ID the entity would hold
+// if the insert is ultimately successful. The newly assigned ID may not become valid if the insert fails.
+func (asyncBox *TestEntityConvertersAsyncBox) Put(object *TestEntityConverters) (uint64, error) {
+	return asyncBox.AsyncBox.Put(object)
+}
+
+// Insert a single object asynchronously.
+// The Id property on the passed object will be assigned the new ID the entity would hold if the insert is ultimately
+// successful. The newly assigned ID may not become valid if the insert fails.
+// Fails silently if an object with the same ID already exists (this error is not returned).
+func (asyncBox *TestEntityConvertersAsyncBox) Insert(object *TestEntityConverters) (id uint64, err error) {
+	return asyncBox.AsyncBox.Insert(object)
+}
+
+// Update a single object asynchronously.
+// The object must already exists or the update fails silently (without an error returned).
+func (asyncBox *TestEntityConvertersAsyncBox) Update(object *TestEntityConverters) error {
+	return asyncBox.AsyncBox.Update(object)
+}
+
+// Remove deletes a single object asynchronously.
+func (asyncBox *TestEntityConvertersAsyncBox) Remove(object *TestEntityConverters) error {
+	return asyncBox.AsyncBox.Remove(object)
+}
+
+// Query provides a way to search stored objects
+//
+// For example, you can find all TestEntityConverters which Id is either 42 or 47:
+// 		box.Query(TestEntityConverters_.Id.In(42, 47)).Find()
+type TestEntityConvertersQuery struct {
+	*objectbox.Query
+}
+
+// Find returns all objects matching the query
+func (query *TestEntityConvertersQuery) Find() ([]*TestEntityConverters, error) {
+	objects, err := query.Query.Find()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*TestEntityConverters), nil
+}
+
+// Offset defines the index of the first object to process (how many objects to skip)
+func (query *TestEntityConvertersQuery) Offset(offset uint64) *TestEntityConvertersQuery {
+	query.Query.Offset(offset)
+	return query
+}
+
+// Limit sets the number of elements to process by the query
+func (query *TestEntityConvertersQuery) Limit(limit uint64) *TestEntityConvertersQuery {
+	query.Query.Limit(limit)
+	return query
+}
+
+type testEntityRegisteredConverter_EntityInfo struct {
+	objectbox.Entity
+	Uid uint64
+}
+
+var TestEntityRegisteredConverterBinding = testEntityRegisteredConverter_EntityInfo{
+	Entity: objectbox.Entity{
+		Id: 11,
+	},
+	Uid: 535084041464666475,
+}
+
+// TestEntityRegisteredConverter_ contains type-based Property helpers to facilitate some common operations such as Queries.
+var TestEntityRegisteredConverter_ = struct {
+	Id    *objectbox.PropertyUint64
+	Price *objectbox.PropertyInt64
+}{
+	Id: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     1,
+			Entity: &TestEntityRegisteredConverterBinding.Entity,
+		},
+	},
+	Price: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     2,
+			Entity: &TestEntityRegisteredConverterBinding.Entity,
+		},
+	},
+}
+
+// GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
+func (testEntityRegisteredConverter_EntityInfo) GeneratorVersion() int {
+	return 6
+}
+
+// AddToModel is called by ObjectBox during model build
+func (testEntityRegisteredConverter_EntityInfo) AddToModel(model *objectbox.Model) {
+	model.Entity("TestEntityRegisteredConverter", 11, 535084041464666475)
+	model.Property("Id", 6, 1, 4335527886425195635)
+	model.PropertyFlags(1)
+	model.Property("Price", 6, 2, 6368499475298449334)
+	model.EntityLastPropertyId(2, 6368499475298449334)
+}
+
+// GetId is called by ObjectBox during Put operations to check for existing ID on an object
+func (testEntityRegisteredConverter_EntityInfo) GetId(object interface{}) (uint64, error) {
+	return object.(*TestEntityRegisteredConverter).Id, nil
+}
+
+// SetId is called by ObjectBox during Put to update an ID on an object that has just been inserted
+func (testEntityRegisteredConverter_EntityInfo) SetId(object interface{}, id uint64) error {
+	object.(*TestEntityRegisteredConverter).Id = id
+	return nil
+}
+
+// PutRelated is called by ObjectBox to put related entities before the object itself is flattened and put
+func (testEntityRegisteredConverter_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{}, id uint64) error {
+	return nil
+}
+
+// Flatten is called by ObjectBox to transform an object to a FlatBuffer
+func (testEntityRegisteredConverter_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
+	obj := object.(*TestEntityRegisteredConverter)
+	var propPrice int64
+	{
+		var err error
+		propPrice, err = moneyCentsToDatabaseValue(obj.Price)
+		if err != nil {
+			return errors.New("converter moneyCentsToDatabaseValue() failed on TestEntityRegisteredConverter.Price: " + err.Error())
+		}
+	}
+
+	// build the FlatBuffers object
+	fbb.StartObject(2)
+	fbutils.SetUint64Slot(fbb, 0, id)
+	fbutils.SetInt64Slot(fbb, 1, propPrice)
+	return nil
+}
+
+// Load is called by ObjectBox to load an object from a FlatBuffer
+func (testEntityRegisteredConverter_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}, error) {
+	if len(bytes) == 0 { // sanity check, should "never" happen
+		return nil, errors.New("can't deserialize an object of type 'TestEntityRegisteredConverter' - no data received")
+	}
+
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+
+	var propId = table.GetUint64Slot(4, 0)
+
+	propPrice, err := moneyCentsToEntityProperty(fbutils.GetInt64Slot(table, 6))
+	if err != nil {
+		return nil, errors.New("converter moneyCentsToEntityProperty() failed on TestEntityRegisteredConverter.Price: " + err.Error())
+	}
+
+	return &TestEntityRegisteredConverter{
+		Id:    propId,
+		Price: propPrice,
+	}, nil
+}
+
+// MakeSlice is called by ObjectBox to construct a new slice to hold the read objects
+func (testEntityRegisteredConverter_EntityInfo) MakeSlice(capacity int) interface{} {
+	return make([]*TestEntityRegisteredConverter, 0, capacity)
+}
+
+// AppendToSlice is called by ObjectBox to fill the slice of the read objects
+func (testEntityRegisteredConverter_EntityInfo) AppendToSlice(slice interface{}, object interface{}) interface{} {
+	if object == nil {
+		return append(slice.([]*TestEntityRegisteredConverter), nil)
+	}
+	return append(slice.([]*TestEntityRegisteredConverter), object.(*TestEntityRegisteredConverter))
+}
+
+// Box provides CRUD access to TestEntityRegisteredConverter objects
+type TestEntityRegisteredConverterBox struct {
+	*objectbox.Box
+}
+
+// BoxForTestEntityRegisteredConverter opens a box of TestEntityRegisteredConverter objects
+func BoxForTestEntityRegisteredConverter(ob *objectbox.ObjectBox) *TestEntityRegisteredConverterBox {
+	return &TestEntityRegisteredConverterBox{
+		Box: ob.InternalBox(11),
+	}
+}
+
+// Put synchronously inserts/updates a single object.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the TestEntityRegisteredConverter.Id property on the passed object will be assigned the new ID as well.
+func (box *TestEntityRegisteredConverterBox) Put(object *TestEntityRegisteredConverter) (uint64, error) {
+	return box.Box.Put(object)
+}
+
+// Insert synchronously inserts a single object. As opposed to Put, Insert will fail if given an ID that already exists.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the TestEntityRegisteredConverter.Id property on the passed object will be assigned the new ID as well.
+func (box *TestEntityRegisteredConverterBox) Insert(object *TestEntityRegisteredConverter) (uint64, error) {
+	return box.Box.Insert(object)
+}
+
+// Update synchronously updates a single object.
+// As opposed to Put, Update will fail if an object with the same ID is not found in the database.
+func (box *TestEntityRegisteredConverterBox) Update(object *TestEntityRegisteredConverter) error {
+	return box.Box.Update(object)
+}
+
+// PutAsync asynchronously inserts/updates a single object.
+// Deprecated: use box.Async().Put() instead
+func (box *TestEntityRegisteredConverterBox) PutAsync(object *TestEntityRegisteredConverter) (uint64, error) {
+	return box.Box.PutAsync(object)
+}
+
+// PutMany inserts multiple objects in single transaction.
+// In case Ids are not set on the objects, they would be assigned automatically (auto-increment).
+//
+// Returns: IDs of the put objects (in the same order).
+// When inserting, the TestEntityRegisteredConverter.Id property on the objects in the slice will be assigned the new IDs as well.
+//
+// Note: In case an error occurs during the transaction, some of the objects may already have the TestEntityRegisteredConverter.Id assigned
+// even though the transaction has been rolled back and the objects are not stored under those IDs.
+//
+// Note: The slice may be empty or even nil; in both cases, an empty IDs slice and no error is returned.
+func (box *TestEntityRegisteredConverterBox) PutMany(objects []*TestEntityRegisteredConverter) ([]uint64, error) {
+	return box.Box.PutMany(objects)
+}
+
+// Get reads a single object.
+//
+// Returns nil (and no error) in case the object with the given ID doesn't exist.
+func (box *TestEntityRegisteredConverterBox) Get(id uint64) (*TestEntityRegisteredConverter, error) {
+	object, err := box.Box.Get(id)
+	if err != nil {
+		return nil, err
+	} else if object == nil {
+		return nil, nil
+	}
+	return object.(*TestEntityRegisteredConverter), nil
+}
+
+// GetMany reads multiple objects at once.
+// If any of the objects doesn't exist, its position in the return slice is nil
+func (box *TestEntityRegisteredConverterBox) GetMany(ids ...uint64) ([]*TestEntityRegisteredConverter, error) {
+	objects, err := box.Box.GetMany(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*TestEntityRegisteredConverter), nil
+}
+
+// GetManyExisting reads multiple objects at once, skipping those that do not exist.
+func (box *TestEntityRegisteredConverterBox) GetManyExisting(ids ...uint64) ([]*TestEntityRegisteredConverter, error) {
+	objects, err := box.Box.GetManyExisting(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*TestEntityRegisteredConverter), nil
+}
+
+// GetAll reads all stored objects
+func (box *TestEntityRegisteredConverterBox) GetAll() ([]*TestEntityRegisteredConverter, error) {
+	objects, err := box.Box.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*TestEntityRegisteredConverter), nil
+}
+
+// Remove deletes a single object
+func (box *TestEntityRegisteredConverterBox) Remove(object *TestEntityRegisteredConverter) error {
+	return box.Box.Remove(object)
+}
+
+// RemoveMany deletes multiple objects at once.
+// Returns the number of deleted object or error on failure.
+// Note that this method will not fail if an object is not found (e.g. already removed).
+// In case you need to strictly check whether all of the objects exist before removing them,
+// you can execute multiple box.Contains() and box.Remove() inside a single write transaction.
+func (box *TestEntityRegisteredConverterBox) RemoveMany(objects ...*TestEntityRegisteredConverter) (uint64, error) {
+	var ids = make([]uint64, len(objects))
+	for k, object := range objects {
+		ids[k] = object.Id
+	}
+	return box.Box.RemoveIds(ids...)
+}
+
+// Creates a query with the given conditions. Use the fields of the TestEntityRegisteredConverter_ struct to create conditions.
+// Keep the *TestEntityRegisteredConverterQuery if you intend to execute the query multiple times.
+// Note: this function panics if you try to create illegal queries; e.g. use properties of an alien type.
+// This is typically a programming error. Use QueryOrError instead if you want the explicit error check.
+func (box *TestEntityRegisteredConverterBox) Query(conditions ...objectbox.Condition) *TestEntityRegisteredConverterQuery {
+	return &TestEntityRegisteredConverterQuery{
+		box.Box.Query(conditions...),
+	}
+}
+
+// Creates a query with the given conditions. Use the fields of the TestEntityRegisteredConverter_ struct to create conditions.
+// Keep the *TestEntityRegisteredConverterQuery if you intend to execute the query multiple times.
+func (box *TestEntityRegisteredConverterBox) QueryOrError(conditions ...objectbox.Condition) (*TestEntityRegisteredConverterQuery, error) {
+	if query, err := box.Box.QueryOrError(conditions...); err != nil {
+		return nil, err
+	} else {
+		return &TestEntityRegisteredConverterQuery{query}, nil
+	}
+}
+
+// Async provides access to the default Async Box for asynchronous operations. See TestEntityRegisteredConverterAsyncBox for more information.
+func (box *TestEntityRegisteredConverterBox) Async() *TestEntityRegisteredConverterAsyncBox {
+	return &TestEntityRegisteredConverterAsyncBox{AsyncBox: box.Box.Async()}
+}
+
+// TestEntityRegisteredConverterAsyncBox provides asynchronous operations on TestEntityRegisteredConverter objects.
+//
+// Asynchronous operations are executed on a separate internal thread for better performance.
+//
+// There are two main use cases:
+//
+// 1) "execute & forget:" you gain faster put/remove operations as you don't have to wait for the transaction to finish.
+//
+// 2) Many small transactions: if your write load is typically a lot of individual puts that happen in parallel,
+// this will merge small transactions into bigger ones. This results in a significant gain in overall throughput.
+//
+// In situations with (extremely) high async load, an async method may be throttled (~1ms) or delayed up to 1 second.
+// In the unlikely event that the object could still not be enqueued (full queue), an error will be returned.
+//
+// Note that async methods do not give you hard durability guarantees like the synchronous Box provides.
+// There is a small time window in which the data may not have been committed durably yet.
+type TestEntityRegisteredConverterAsyncBox struct {
+	*objectbox.AsyncBox
+}
+
+// AsyncBoxForTestEntityRegisteredConverter creates a new async box with the given operation timeout in case an async queue is full.
+// The returned struct must be freed explicitly using the Close() method.
+// It's usually preferable to use TestEntityRegisteredConverterBox::Async() which takes care of resource management and doesn't require closing.
+func AsyncBoxForTestEntityRegisteredConverter(ob *objectbox.ObjectBox, timeoutMs uint64) *TestEntityRegisteredConverterAsyncBox {
+	var async, err = objectbox.NewAsyncBox(ob, 11, timeoutMs)
+	if err != nil {
+		panic("Could not create async box for entity ID 11: %s" + err.Error())
+	}
+	return &TestEntityRegisteredConverterAsyncBox{AsyncBox: async}
+}
+
+// Put inserts/updates a single object asynchronously.
+// When inserting a new object, the Id property on the passed object will be assigned the new ID the entity would hold
+// if the insert is ultimately successful. The newly assigned ID may not become valid if the insert fails.
+func (asyncBox *TestEntityRegisteredConverterAsyncBox) Put(object *TestEntityRegisteredConverter) (uint64, error) {
+	return asyncBox.AsyncBox.Put(object)
+}
+
+// Insert a single object asynchronously.
+// The Id property on the passed object will be assigned the new ID the entity would hold if the insert is ultimately
+// successful. The newly assigned ID may not become valid if the insert fails.
+// Fails silently if an object with the same ID already exists (this error is not returned).
+func (asyncBox *TestEntityRegisteredConverterAsyncBox) Insert(object *TestEntityRegisteredConverter) (id uint64, err error) {
+	return asyncBox.AsyncBox.Insert(object)
+}
+
+// Update a single object asynchronously.
+// The object must already exists or the update fails silently (without an error returned).
+func (asyncBox *TestEntityRegisteredConverterAsyncBox) Update(object *TestEntityRegisteredConverter) error {
+	return asyncBox.AsyncBox.Update(object)
+}
+
+// Remove deletes a single object asynchronously.
+func (asyncBox *TestEntityRegisteredConverterAsyncBox) Remove(object *TestEntityRegisteredConverter) error {
+	return asyncBox.AsyncBox.Remove(object)
+}
+
+// Query provides a way to search stored objects
+//
+// For example, you can find all TestEntityRegisteredConverter which Id is either 42 or 47:
+// 		box.Query(TestEntityRegisteredConverter_.Id.In(42, 47)).Find()
+type TestEntityRegisteredConverterQuery struct {
+	*objectbox.Query
+}
+
+// Find returns all objects matching the query
+func (query *TestEntityRegisteredConverterQuery) Find() ([]*TestEntityRegisteredConverter, error) {
+	objects, err := query.Query.Find()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*TestEntityRegisteredConverter), nil
+}
+
+// Offset defines the index of the first object to process (how many objects to skip)
+func (query *TestEntityRegisteredConverterQuery) Offset(offset uint64) *TestEntityRegisteredConverterQuery {
+	query.Query.Offset(offset)
+	return query
+}
+
+// Limit sets the number of elements to process by the query
+func (query *TestEntityRegisteredConverterQuery) Limit(limit uint64) *TestEntityRegisteredConverterQuery {
+	query.Query.Limit(limit)
+	return query
+}
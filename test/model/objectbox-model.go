@@ -20,7 +20,10 @@ func ObjectBoxModel() *objectbox.Model {
 	model.RegisterBinding(TSDateBinding)
 	model.RegisterBinding(TSDateNanoBinding)
 	model.RegisterBinding(TestEntitySyncedBinding)
-	model.LastEntityId(8, 1967687883385423038)
+	model.RegisterBinding(TestEntityTransientBinding)
+	model.RegisterBinding(TestEntityConvertersBinding)
+	model.RegisterBinding(TestEntityRegisteredConverterBinding)
+	model.LastEntityId(11, 535084041464666475)
 	model.LastIndexId(4, 3414034888235702623)
 	model.LastRelationId(6, 3119566795324383223)
 
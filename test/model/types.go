@@ -3,6 +3,9 @@ package model
 import (
 	"bytes"
 	"encoding/gob"
+	"reflect"
+
+	"github.com/objectbox/objectbox-go/objectbox"
 )
 
 // BaseWithDate model
@@ -34,3 +37,26 @@ func complex128BytesToDatabaseValue(goValue complex128) ([]byte, error) {
 	err := encoder.Encode(goValue)
 	return b.Bytes(), err
 }
+
+// Money is a custom type stored using a converter registered by objectbox.RegisterConverter()
+type Money struct {
+	Cents int64
+}
+
+// converts int64 cents to Money using the converter registered at runtime
+func moneyCentsToEntityProperty(dbValue int64) (Money, error) {
+	value, err := objectbox.ConvertToEntityProperty(reflect.TypeOf(Money{}), dbValue)
+	if err != nil {
+		return Money{}, err
+	}
+	return value.(Money), nil
+}
+
+// converts Money to int64 cents using the converter registered at runtime
+func moneyCentsToDatabaseValue(goValue Money) (int64, error) {
+	value, err := objectbox.ConvertToDatabaseValue(goValue)
+	if err != nil {
+		return 0, err
+	}
+	return value.(int64), nil
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model"
+	"github.com/objectbox/objectbox-go/test/model/iot"
+)
+
+func TestModelDuplicatePropertyUid(t *testing.T) {
+	var model = objectbox.NewModel()
+	model.Entity("Renamed", 1, 1001)
+	model.Property("Id", 6, 1, 1002)
+	model.PropertyFlags(1)
+	model.Property("Name", 9, 2, 1003)
+	assert.NoErr(t, model.Error)
+
+	// e.g. a field was renamed and the original "uid" annotation copied instead of moved
+	model.Property("FullName", 9, 3, 1003)
+	assert.Err(t, model.Error)
+	assert.MustMatch(t, regexp.MustCompile("duplicate property UID 1003 on entity Renamed"), model.Error.Error())
+}
+
+// testModelJSON is the part of objectbox-model.json that's available at runtime, see ObjectBox.ModelJSON()
+type testModelJSON struct {
+	Entities []struct {
+		Id             string
+		LastPropertyId string
+		Name           string
+		Flags          int
+		Properties     []struct {
+			Id             string
+			Name           string
+			IndexId        string
+			Type           int
+			Flags          int
+			RelationTarget string
+		}
+		Relations []struct {
+			Id       string
+			TargetId string
+		}
+	}
+	LastEntityId   string
+	LastIndexId    string
+	LastRelationId string
+}
+
+func TestModelJSON(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+
+	data, err := env.ObjectBox.ModelJSON()
+	assert.NoErr(t, err)
+
+	var actual testModelJSON
+	assert.NoErr(t, json.Unmarshal(data, &actual))
+
+	fileData, err := ioutil.ReadFile("model/objectbox-model.json")
+	assert.NoErr(t, err)
+
+	var expected testModelJSON
+	assert.NoErr(t, json.Unmarshal(fileData, &expected))
+
+	assert.Eq(t, expected, actual)
+	assert.Eq(t, "Entity", actual.Entities[0].Name)
+	assert.Eq(t, "1:1213346202559552829", actual.Entities[0].Properties[0].Id)
+}
+
+// customBinding replaces the model definition of the wrapped binding
+type customBinding struct {
+	objectbox.ObjectBinding
+	addToModel func(model *objectbox.Model)
+}
+
+func (binding customBinding) AddToModel(model *objectbox.Model) {
+	binding.addToModel(model)
+}
+
+func TestModelValidateIds(t *testing.T) {
+	var build = func(addToModel func(model *objectbox.Model), lastEntityId objectbox.TypeId, lastEntityUid uint64,
+		lastIndexId objectbox.TypeId, lastIndexUid uint64) error {
+		var m = objectbox.NewModel()
+		m.GeneratorVersion(6)
+		m.RegisterBinding(iot.EventBinding)
+		m.RegisterBinding(customBinding{iot.EventBinding, addToModel})
+		m.LastEntityId(lastEntityId, lastEntityUid)
+		m.LastIndexId(lastIndexId, lastIndexUid)
+
+		dir, err := ioutil.TempDir("", "objectbox-test")
+		assert.NoErr(t, err)
+		defer os.RemoveAll(dir)
+
+		ob, err := objectbox.NewBuilder().Directory(dir).Model(m).Build()
+		if err == nil {
+			ob.Close()
+		}
+		return err
+	}
+
+	var entity = func(id objectbox.TypeId, uid uint64, propertyUid uint64, indexId objectbox.TypeId,
+		indexUid uint64) func(model *objectbox.Model) {
+		return func(model *objectbox.Model) {
+			model.Entity("Device", id, uid)
+			model.Property("Id", 6, 1, 1002)
+			model.PropertyFlags(1)
+			model.Property("Name", 9, 2, propertyUid)
+			model.PropertyFlags(8) // indexed
+			model.PropertyIndex(indexId, indexUid)
+			model.EntityLastPropertyId(2, propertyUid)
+		}
+	}
+
+	// consistent
+	assert.NoErr(t, build(entity(2, 1001, 1003, 2, 1004), 2, 1001, 2, 1004))
+
+	// a UID used by another entity's index
+	var err = build(entity(2, 1001, 3297791712577314158, 2, 1004), 2, 1001, 2, 1004)
+	assert.Err(t, err)
+	assert.Eq(t, "duplicate UID 3297791712577314158: used by both index on Event.Uid and property Device.Name",
+		err.Error())
+
+	// the same index ID as another entity
+	err = build(entity(2, 1001, 1003, 1, 1004), 2, 1001, 2, 1004)
+	assert.Err(t, err)
+	assert.Eq(t, "duplicate index ID 1: used by both index on Event.Uid and index on Device.Name", err.Error())
+
+	// IDs not covered by the last IDs
+	err = build(entity(2, 1001, 1003, 3, 1004), 2, 1001, 2, 1004)
+	assert.Err(t, err)
+	assert.Eq(t, "index ID 3 of index on Device.Name is higher than the last index ID 2", err.Error())
+
+	err = build(entity(3, 1001, 1003, 2, 1004), 2, 1001, 2, 1004)
+	assert.Err(t, err)
+	assert.Eq(t, "entity ID 3 of entity Device is higher than the last entity ID 2", err.Error())
+
+	err = build(entity(2, 1001, 1003, 2, 1004), 2, 1009, 2, 1004)
+	assert.Err(t, err)
+	assert.Eq(t, "UID 1001 of entity Device doesn't match the last entity UID 1009", err.Error())
+}
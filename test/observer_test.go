@@ -0,0 +1,222 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model"
+)
+
+func TestObserver(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+
+	var count int32
+	observer, err := env.Box.Subscribe(func() {
+		atomic.AddInt32(&count, 1)
+	})
+	assert.NoErr(t, err)
+
+	env.Populate(1)
+	assert.NoErr(t, waitUntil(time.Second, func() (bool, error) { return atomic.LoadInt32(&count) == 1, nil }))
+
+	// changes of other types aren't observed
+	_, err = model.BoxForTestEntityRelated(env.ObjectBox).Put(&model.TestEntityRelated{Name: "other"})
+	assert.NoErr(t, err)
+
+	assert.NoErr(t, env.Box.RemoveAll())
+	assert.NoErr(t, waitUntil(time.Second, func() (bool, error) { return atomic.LoadInt32(&count) == 2, nil }))
+
+	assert.NoErr(t, observer.Close())
+	assert.NoErr(t, observer.Close()) // closing again is a no-op
+
+	env.Populate(1)
+	time.Sleep(10 * time.Millisecond)
+	assert.Eq(t, int32(2), atomic.LoadInt32(&count))
+}
+
+func TestSubscribeDebounced(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+
+	var count int32
+	var window = 50 * time.Millisecond
+	observer, err := env.ObjectBox.SubscribeDebounced(env.Box.EntityId(), window, func() {
+		atomic.AddInt32(&count, 1)
+	})
+	assert.NoErr(t, err)
+
+	// many small transactions in a quick succession result in a single callback after the last one
+	for i := 0; i < 10; i++ {
+		env.Populate(1)
+	}
+	assert.Eq(t, int32(0), atomic.LoadInt32(&count))
+	assert.NoErr(t, waitUntil(time.Second, func() (bool, error) { return atomic.LoadInt32(&count) == 1, nil }))
+	time.Sleep(2 * window)
+	assert.Eq(t, int32(1), atomic.LoadInt32(&count))
+
+	// closing cancels the pending callback
+	env.Populate(1)
+	time.Sleep(window / 5)
+	assert.NoErr(t, observer.Close())
+	time.Sleep(2 * window)
+	assert.Eq(t, int32(1), atomic.LoadInt32(&count))
+	assert.Eq(t, 0, env.ObjectBox.ObserverCount())
+
+	_, err = env.ObjectBox.SubscribeDebounced(env.Box.EntityId(), 0, func() {})
+	assert.Err(t, err)
+	_, err = env.ObjectBox.SubscribeDebounced(12345, window, func() {})
+	assert.Err(t, err)
+}
+
+func TestSubscribeTx(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+
+	var relatedBox = model.BoxForTestEntityRelated(env.ObjectBox)
+
+	var mutex sync.Mutex
+	var notifications [][]objectbox.TypeId
+	observer, err := env.ObjectBox.SubscribeTx(func(changes []objectbox.TypeChange) {
+		var typeIds = make([]objectbox.TypeId, len(changes))
+		for i, change := range changes {
+			typeIds[i] = change.TypeId
+		}
+		sort.Slice(typeIds, func(i, j int) bool { return typeIds[i] < typeIds[j] })
+
+		mutex.Lock()
+		notifications = append(notifications, typeIds)
+		mutex.Unlock()
+	})
+	assert.NoErr(t, err)
+	assert.Eq(t, 1, env.ObjectBox.ObserverCount())
+
+	var received = func(count int) func() (bool, error) {
+		return func() (bool, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			return len(notifications) == count, nil
+		}
+	}
+
+	// a transaction changing two types is delivered once, listing both
+	assert.NoErr(t, env.ObjectBox.RunInWriteTx(func() error {
+		if _, err := env.Box.Put(&model.Entity{}); err != nil {
+			return err
+		}
+		if _, err := relatedBox.Put(&model.TestEntityRelated{Name: "rel"}); err != nil {
+			return err
+		}
+		_, err := env.Box.Put(&model.Entity{})
+		return err
+	}))
+	assert.NoErr(t, waitUntil(time.Second, received(1)))
+
+	var expected = []objectbox.TypeId{env.Box.EntityId(), relatedBox.EntityId()}
+	sort.Slice(expected, func(i, j int) bool { return expected[i] < expected[j] })
+	mutex.Lock()
+	assert.Eq(t, expected, notifications[0])
+	mutex.Unlock()
+
+	// a single-type change (a removal) lists only that type
+	assert.NoErr(t, relatedBox.RemoveAll())
+	assert.NoErr(t, waitUntil(time.Second, received(2)))
+	mutex.Lock()
+	assert.Eq(t, []objectbox.TypeId{relatedBox.EntityId()}, notifications[1])
+	mutex.Unlock()
+
+	// a rolled-back transaction isn't delivered
+	assert.Err(t, env.ObjectBox.RunInWriteTx(func() error {
+		if _, err := env.Box.Put(&model.Entity{}); err != nil {
+			return err
+		}
+		return errors.New("rollback")
+	}))
+
+	assert.NoErr(t, observer.Close())
+	assert.Eq(t, 0, env.ObjectBox.ObserverCount())
+	env.Populate(1)
+	time.Sleep(50 * time.Millisecond)
+	mutex.Lock()
+	assert.Eq(t, 2, len(notifications))
+	mutex.Unlock()
+}
+
+func TestBoxCachedCount(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+
+	count, err := env.Box.CachedCount()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(0), count)
+
+	env.Populate(3)
+	assert.NoErr(t, waitUntil(time.Second, func() (bool, error) {
+		count, err := env.Box.CachedCount()
+		return count == 3, err
+	}))
+
+	assert.NoErr(t, env.Box.RemoveAll())
+	assert.NoErr(t, waitUntil(time.Second, func() (bool, error) {
+		count, err := env.Box.CachedCount()
+		return count == 0, err
+	}))
+}
+
+func TestUnsubscribeAll(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+
+	assert.Eq(t, 0, env.ObjectBox.ObserverCount())
+
+	var count int32
+	var callback = func() { atomic.AddInt32(&count, 1) }
+	first, err := env.Box.Subscribe(callback)
+	assert.NoErr(t, err)
+	_, err = model.BoxForTestEntityRelated(env.ObjectBox).Subscribe(callback)
+	assert.NoErr(t, err)
+	_, err = env.Box.CachedCount()
+	assert.NoErr(t, err)
+	assert.Eq(t, 3, env.ObjectBox.ObserverCount())
+
+	// an observer closed individually is removed as well
+	assert.NoErr(t, first.Close())
+	assert.Eq(t, 2, env.ObjectBox.ObserverCount())
+
+	assert.NoErr(t, env.ObjectBox.UnsubscribeAll())
+	assert.Eq(t, 0, env.ObjectBox.ObserverCount())
+	assert.NoErr(t, first.Close())
+	assert.NoErr(t, env.ObjectBox.UnsubscribeAll())
+
+	env.Populate(1)
+	time.Sleep(10 * time.Millisecond)
+	assert.Eq(t, int32(0), atomic.LoadInt32(&count))
+
+	// the cached count doesn't miss the change made while unsubscribed
+	cachedCount, err := env.Box.CachedCount()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1), cachedCount)
+	assert.Eq(t, 1, env.ObjectBox.ObserverCount())
+}
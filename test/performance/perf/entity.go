@@ -26,3 +26,26 @@ type Entity struct {
 	String  string
 	Float64 float64
 }
+
+// SliceLen implements objectbox.ObjectSliceBinding, letting PutMany() access the objects without reflection
+func (entity_EntityInfo) SliceLen(slice interface{}) int {
+	return len(slice.([]*Entity))
+}
+
+// SliceObject implements objectbox.ObjectSliceBinding
+func (entity_EntityInfo) SliceObject(slice interface{}, index int) interface{} {
+	return slice.([]*Entity)[index]
+}
+
+// NewSliceAppender implements objectbox.SliceAppenderBinding, letting GetAll() & co. build []*Entity directly
+func (entity_EntityInfo) NewSliceAppender(capacity int) (func(object interface{}), func() interface{}) {
+	var slice = make([]*Entity, 0, capacity)
+	var add = func(object interface{}) {
+		if object == nil {
+			slice = append(slice, nil)
+		} else {
+			slice = append(slice, object.(*Entity))
+		}
+	}
+	return add, func() interface{} { return slice }
+}
@@ -331,3 +331,65 @@ func testPropertyQueriesAggregate(t *testing.T, env *model.TestEnv, testCases []
 		propertyQueryAssertResultFloat64(t, "sumF", tc.sumF, pq.SumFloat64)
 	}
 }
+
+func TestQueryCountGroupBy(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+
+	var statuses = []string{"open", "closed", "open", "pending", "open", "closed"}
+	var entities = make([]*model.Entity, len(statuses))
+	for i, status := range statuses {
+		entities[i] = &model.Entity{
+			String: status,
+			Int32:  int32(i % 3),
+			Int8:   int8(-1),
+			Uint16: uint16(60000 + i%2),
+			Int64:  int64(i) * math.MaxInt32,
+			Bool:   i%2 == 0,
+		}
+	}
+	_, err := env.Box.PutMany(entities)
+	assert.NoErr(t, err)
+
+	var query = env.Box.Query()
+
+	byStatus, err := query.CountGroupByString(model.Entity_.String)
+	assert.NoErr(t, err)
+	assert.Eq(t, map[string]uint64{"open": 3, "closed": 2, "pending": 1}, byStatus)
+
+	byInt, err := query.CountGroupByInt(model.Entity_.Int32)
+	assert.NoErr(t, err)
+	assert.Eq(t, map[int64]uint64{0: 2, 1: 2, 2: 2}, byInt)
+
+	byInt, err = query.CountGroupByInt(model.Entity_.Int8)
+	assert.NoErr(t, err)
+	assert.Eq(t, map[int64]uint64{-1: 6}, byInt)
+
+	byInt, err = query.CountGroupByInt(model.Entity_.Uint16)
+	assert.NoErr(t, err)
+	assert.Eq(t, map[int64]uint64{60000: 3, 60001: 3}, byInt)
+
+	byInt, err = query.CountGroupByInt(model.Entity_.Int64)
+	assert.NoErr(t, err)
+	assert.Eq(t, 6, len(byInt))
+	assert.Eq(t, uint64(1), byInt[5*math.MaxInt32])
+
+	byInt, err = query.CountGroupByInt(model.Entity_.Bool)
+	assert.NoErr(t, err)
+	assert.Eq(t, map[int64]uint64{0: 3, 1: 3}, byInt)
+
+	// counting only the objects matching the query
+	byStatus, err = env.Box.Query(model.Entity_.Bool.Equals(true)).CountGroupByString(model.Entity_.String)
+	assert.NoErr(t, err)
+	assert.Eq(t, map[string]uint64{"open": 3}, byStatus)
+
+	// type mismatches and properties of another entity
+	_, err = query.CountGroupByInt(model.Entity_.String)
+	assert.Err(t, err)
+	_, err = query.CountGroupByString(model.Entity_.Int32)
+	assert.Err(t, err)
+	_, err = query.CountGroupByInt(model.Entity_.Float64)
+	assert.Err(t, err)
+	_, err = query.CountGroupByString(model.TestEntityRelated_.Name)
+	assert.Err(t, err)
+}
@@ -19,15 +19,18 @@ package objectbox_test
 import (
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"regexp"
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/objectbox/objectbox-go/objectbox"
 	"github.com/objectbox/objectbox-go/test/assert"
 	"github.com/objectbox/objectbox-go/test/model"
+	"github.com/objectbox/objectbox-go/test/model/iot"
 )
 
 // Following methods use many test-cases defined as a list of queryTestCase and run all Query.* methods on each test case
@@ -615,6 +618,285 @@ func TestQueryAndOr(t *testing.T) {
 	})
 }
 
+func TestQueryNot(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+
+	var box = env.Box
+	var E = model.Entity_
+
+	env.Populate(1000)
+
+	var countOf = func(conditions ...objectbox.Condition) uint64 {
+		query, err := box.QueryOrError(conditions...)
+		assert.NoErr(t, err)
+		defer query.Close()
+		count, err := query.Count()
+		assert.NoErr(t, err)
+		return count
+	}
+
+	// a negated condition must match exactly the objects the original one doesn't
+	var conditions = []objectbox.Condition{
+		E.Int.Equals(0),
+		E.Int64.GreaterThan(0),
+		E.Int32.Between(-47000, 47000),
+		E.Float64.LessOrEqual(0),
+		E.String.Equals("val-1", true),
+		E.String.GreaterThan("val", false),
+		E.Bool.Equals(true),
+		E.Int.In(0, 47, -47),
+		objectbox.All(E.Int.GreaterThan(0), E.Bool.Equals(false)),
+		objectbox.Any(E.Int64.LessThan(0), E.String.Equals("val-1", true), E.Int8.NotEquals(47)),
+		objectbox.All(objectbox.Any(E.Int.LessThan(0), E.Int.GreaterThan(10)), E.Uint64.NotEquals(47)),
+	}
+	for _, condition := range conditions {
+		var positive = countOf(condition)
+		var negative = countOf(objectbox.Not(condition))
+		assert.Eq(t, uint64(1000), positive+negative)
+
+		// double negation
+		assert.Eq(t, positive, countOf(objectbox.Not(objectbox.Not(condition))))
+	}
+
+	assert.Eq(t, countOf(E.Bool.Equals(false)), countOf(E.Bool.NotEquals(true)))
+
+	// combined with other conditions & ordering
+	query, err := box.QueryOrError(E.Int.GreaterThan(0), objectbox.Not(E.Int.Equals(47)), E.Int.OrderAsc())
+	assert.NoErr(t, err)
+	defer query.Close()
+	desc, err := query.DescribeParams()
+	assert.NoErr(t, err)
+	assert.Eq(t, "(Int > 0 AND Int != 47)", desc)
+
+	// conditions without a complement aren't supported
+	_, err = box.QueryOrError(objectbox.Not(E.String.Contains("val", true)))
+	assert.Err(t, err)
+	_, err = box.QueryOrError(objectbox.Not(objectbox.All()))
+	assert.Err(t, err)
+}
+
+func TestBoxPage(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+
+	env.Populate(10)
+	allIds, err := env.Box.Query().FindIds()
+	assert.NoErr(t, err)
+
+	// the generated box doesn't wrap Page(), the slice holds the objects like Box.GetAll()
+	var page = func(afterId uint64, size int) ([]*model.Entity, uint64, error) {
+		slice, next, err := env.Box.Box.Page(afterId, size)
+		if err != nil {
+			return nil, 0, err
+		}
+		return slice.([]*model.Entity), next, nil
+	}
+
+	var pagedIds []uint64
+	var pages int
+	var cursor uint64
+	for {
+		items, next, err := page(cursor, 4)
+		assert.NoErr(t, err)
+		pages++
+
+		assert.True(t, len(items) <= 4)
+		for _, item := range items {
+			pagedIds = append(pagedIds, item.Id)
+		}
+
+		if next == 0 {
+			break
+		}
+		assert.Eq(t, items[len(items)-1].Id, next)
+		cursor = next
+	}
+	assert.Eq(t, allIds, pagedIds)
+	assert.Eq(t, 3, pages)
+
+	// a full last page is followed by an empty one
+	items, next, err := page(allIds[4], 5)
+	assert.NoErr(t, err)
+	assert.Eq(t, 5, len(items))
+	assert.Eq(t, allIds[5], items[0].Id)
+	assert.Eq(t, allIds[9], next)
+	items, next, err = page(next, 5)
+	assert.NoErr(t, err)
+	assert.Eq(t, 0, len(items))
+	assert.Eq(t, uint64(0), next)
+
+	_, _, err = page(0, 0)
+	assert.Err(t, err)
+}
+
+func TestQueryIdGreaterThan(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+
+	env.Populate(10)
+	allIds, err := env.Box.Query().FindIds()
+	assert.NoErr(t, err)
+
+	var query = env.Box.QueryIdGreaterThan(0).Limit(3)
+	defer query.Close()
+
+	desc, err := query.DescribeParams()
+	assert.NoErr(t, err)
+	assert.Eq(t, `Id > 0`, desc)
+
+	// keyset pagination
+	var pagedIds []uint64
+	for {
+		ids, err := query.FindIds()
+		assert.NoErr(t, err)
+		if len(ids) == 0 {
+			break
+		}
+		assert.True(t, len(ids) <= 3)
+		pagedIds = append(pagedIds, ids...)
+		assert.NoErr(t, query.SetInt64Params(model.Entity_.Id, int64(ids[len(ids)-1])))
+	}
+	assert.Eq(t, allIds, pagedIds)
+
+	objects, err := env.Box.QueryIdGreaterThan(allIds[7]).Find()
+	assert.NoErr(t, err)
+	assert.Eq(t, 2, len(objects.([]*model.Entity)))
+	assert.Eq(t, allIds[8], objects.([]*model.Entity)[0].Id)
+}
+
+func TestQueryRemoveBatched(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+
+	env.Populate(25)
+
+	var query = env.Box.Query(model.Entity_.Int.GreaterThan(0))
+	defer query.Close()
+
+	matching, err := query.Count()
+	assert.NoErr(t, err)
+	assert.True(t, matching > 4) // more than a single batch
+
+	removed, err := query.RemoveBatched(4)
+	assert.NoErr(t, err)
+	assert.Eq(t, matching, removed)
+
+	count, err := env.Box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, 25-matching, count)
+
+	// nothing left to remove
+	removed, err = query.RemoveBatched(4)
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(0), removed)
+
+	// a limit set before survives the call
+	query.Limit(2)
+	_, err = query.RemoveBatched(4)
+	assert.NoErr(t, err)
+	env.Populate(25)
+	ids, err := query.FindIds()
+	assert.NoErr(t, err)
+	assert.Eq(t, 2, len(ids))
+
+	_, err = query.RemoveBatched(0)
+	assert.Err(t, err)
+}
+
+func TestQueryCountEstimate(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+
+	env.Populate(10)
+
+	var query = env.Box.Query(model.Entity_.Int.GreaterThan(0))
+	defer query.Close()
+
+	matching, err := query.Count()
+	assert.NoErr(t, err)
+	assert.True(t, matching > 3)
+
+	// capped
+	count, err := query.CountEstimate(3)
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(3), count)
+
+	// exact if below the limit
+	count, err = query.CountEstimate(100)
+	assert.NoErr(t, err)
+	assert.Eq(t, matching, count)
+
+	count, err = query.CountEstimate(matching)
+	assert.NoErr(t, err)
+	assert.Eq(t, matching, count)
+
+	count, err = query.CountEstimate(0)
+	assert.NoErr(t, err)
+	assert.Eq(t, matching, count)
+
+	// no limit is left over afterwards
+	ids, err := query.FindIds()
+	assert.NoErr(t, err)
+	assert.Eq(t, matching, uint64(len(ids)))
+
+	// a limit set before applies to the count and survives the call
+	query.Limit(2)
+	count, err = query.CountEstimate(3)
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(2), count)
+	ids, err = query.FindIds()
+	assert.NoErr(t, err)
+	assert.Eq(t, 2, len(ids))
+	query.Limit(0)
+
+	var empty = env.Box.Query(model.Entity_.Int.LessThan(-1000000000000))
+	defer empty.Close()
+	count, err = empty.CountEstimate(5)
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(0), count)
+}
+
+func TestQueryCached(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+
+	env.Populate(10)
+
+	var query = env.Box.Query(model.Entity_.Int.GreaterThan(0))
+	defer query.Close()
+	cached, err := query.Cached()
+	assert.NoErr(t, err)
+	defer cached.Close()
+
+	var find = func() []*model.Entity {
+		objects, err := cached.Find()
+		assert.NoErr(t, err)
+		return objects.([]*model.Entity)
+	}
+
+	var first = find()
+	assert.True(t, len(first) > 0)
+
+	// served from the cache - the very same slice
+	var second = find()
+	assert.True(t, &first[0] == &second[0])
+
+	// a change invalidates the cached result
+	var object = model.Entity47()
+	object.Int = 1
+	_, err = env.Box.Put(object)
+	assert.NoErr(t, err)
+	assert.NoErr(t, waitUntil(time.Second, func() (bool, error) { return len(find()) == len(first)+1, nil }))
+
+	// manual invalidation
+	var third = find()
+	cached.Invalidate()
+	var fourth = find()
+	assert.True(t, &third[0] != &fourth[0])
+	assert.Eq(t, third, fourth)
+}
+
 func TestQueryLinks(t *testing.T) {
 	env := model.NewTestEnv(t).SetOptions(model.TestEnvOptions{PopulateRelations: true})
 	defer env.Close()
@@ -911,6 +1193,429 @@ func TestQueryUint(t *testing.T) {
 	assert.Eq(t, uint64(1), count)
 }
 
+func TestQueryIsNull(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+
+	var E = model.Entity_
+	var zero = 0
+	var value = 47
+
+	idNil, err := env.Box.Put(&model.Entity{})
+	assert.NoErr(t, err)
+	idZero, err := env.Box.Put(&model.Entity{IntPtr: &zero, StringPtr: new(string)})
+	assert.NoErr(t, err)
+	idValue, err := env.Box.Put(&model.Entity{IntPtr: &value})
+	assert.NoErr(t, err)
+
+	// a stored zero value is read back as such, not as nil
+	read, err := env.Box.Get(idZero)
+	assert.NoErr(t, err)
+	assert.True(t, read.IntPtr != nil && *read.IntPtr == 0)
+	assert.True(t, read.StringPtr != nil && *read.StringPtr == "")
+
+	var findIds = func(condition objectbox.Condition) []uint64 {
+		ids, err := env.Box.Query(condition).FindIds()
+		assert.NoErr(t, err)
+		return ids
+	}
+
+	assert.Eq(t, []uint64{idNil}, findIds(objectbox.IsNull(E.IntPtr)))
+	assert.Eq(t, []uint64{idZero, idValue}, findIds(objectbox.NotNull(E.IntPtr)))
+	assert.Eq(t, []uint64{idNil, idValue}, findIds(objectbox.IsNull(E.StringPtr)))
+	assert.Eq(t, []uint64{idZero}, findIds(objectbox.NotNull(E.StringPtr)))
+	assert.Eq(t, []uint64{idNil, idValue}, findIds(objectbox.Not(objectbox.NotNull(E.StringPtr))))
+}
+
+func TestQueryWithinBox(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+
+	// Float64 holds the latitude, Float64Ptr the longitude
+	var E = model.Entity_
+	var put = func(lat, lon float64) uint64 {
+		id, err := env.Box.Put(&model.Entity{Float64: lat, Float64Ptr: &lon})
+		assert.NoErr(t, err)
+		return id
+	}
+
+	var vienna = put(48.21, 16.37)
+	var munich = put(48.14, 11.58)
+	var london = put(51.51, -0.13)
+	var fiji = put(-17.71, 178.07)
+	var samoa = put(-13.76, -172.10)
+	_, err := env.Box.Put(&model.Entity{Float64: 48.2}) // no longitude
+	assert.NoErr(t, err)
+
+	var findIds = func(condition objectbox.Condition) []uint64 {
+		ids, err := env.Box.Query(condition).FindIds()
+		assert.NoErr(t, err)
+		return ids
+	}
+
+	assert.Eq(t, []uint64{vienna}, findIds(objectbox.WithinBox(E.Float64, E.Float64Ptr, 48, 49, 16, 17)))
+	assert.Eq(t, []uint64{vienna, munich}, findIds(objectbox.WithinBox(E.Float64, E.Float64Ptr, 48, 49, 10, 17)))
+	assert.Eq(t, []uint64{vienna, munich, london},
+		findIds(objectbox.WithinBox(E.Float64, E.Float64Ptr, 45, 55, -5, 20)))
+
+	// borders are included
+	assert.Eq(t, []uint64{london}, findIds(objectbox.WithinBox(E.Float64, E.Float64Ptr, 51.51, 52, -0.13, 0)))
+
+	// crossing the antimeridian
+	assert.Eq(t, []uint64{fiji, samoa}, findIds(objectbox.WithinBox(E.Float64, E.Float64Ptr, -20, -10, 170, -170)))
+
+	// combined with other conditions
+	assert.Eq(t, []uint64{munich}, findIds(objectbox.All(
+		objectbox.WithinBox(E.Float64, E.Float64Ptr, 45, 55, -5, 20),
+		E.Float64Ptr.LessThan(15),
+		E.Float64Ptr.GreaterThan(0),
+	)))
+	assert.Eq(t, 0, len(findIds(objectbox.WithinBox(E.Float64, E.Float64Ptr, 0, 10, 0, 10))))
+}
+
+func TestQuerySetProperty(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	var events []*iot.Event
+	for i := 1; i <= 10; i++ {
+		events = append(events, &iot.Event{Uid: fmt.Sprintf("%d", i), Device: "active", Date: int64(i)})
+	}
+	_, err := box.PutMany(events)
+	assert.NoErr(t, err)
+
+	var query = box.Query(iot.Event_.Date.LessThan(5))
+	defer query.Close()
+
+	updated, err := query.SetProperty(iot.Event_.Device, "archived")
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(4), updated)
+
+	count, err := box.Query(iot.Event_.Device.Equals("archived", true)).Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(4), count)
+
+	// changing the property used in the condition; an untyped constant is converted to int64
+	updated, err = query.SetProperty(iot.Event_.Date, 1)
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(4), updated)
+	count, err = box.Query(iot.Event_.Date.Equals(1)).Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(4), count)
+
+	// invalid values and properties don't change anything
+	_, err = query.SetProperty(iot.Event_.Device, 42)
+	assert.Err(t, err)
+	_, err = query.SetProperty(iot.Event_.Id, uint64(1))
+	assert.Err(t, err)
+	_, err = query.SetProperty(iot.Reading_.ValueName, "name")
+	assert.Err(t, err)
+	count, err = box.Query(iot.Event_.Device.Equals("archived", true)).Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(4), count)
+
+	// a failure rolls back all the changes, here because of the unique Uid
+	_, err = box.Query().SetProperty(iot.Event_.Uid, "same")
+	assert.Err(t, err)
+	count, err = box.Query(iot.Event_.Uid.Equals("same", true)).Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(0), count)
+
+	// no matches
+	updated, err = box.Query(iot.Event_.Date.GreaterThan(100)).SetProperty(iot.Event_.Device, "none")
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(0), updated)
+}
+
+func TestQuerySetPropertyPointer(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+	env.Populate(3)
+
+	updated, err := env.Box.Query().SetProperty(model.Entity_.Int32Ptr, int32(7))
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(3), updated)
+
+	entities, err := env.Box.GetAll()
+	assert.NoErr(t, err)
+	for _, entity := range entities {
+		assert.Eq(t, int32(7), *entity.Int32Ptr)
+	}
+
+	_, err = env.Box.Query().SetProperty(model.Entity_.Int32Ptr, nil)
+	assert.NoErr(t, err)
+	entities, err = env.Box.GetAll()
+	assert.NoErr(t, err)
+	for _, entity := range entities {
+		assert.True(t, entity.Int32Ptr == nil)
+	}
+}
+
+func TestQuerySetPropertyNumbers(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+	env.Populate(3)
+
+	// float64 values (e.g. untyped constants) may be narrowed to float32 fields
+	_, err := env.Box.Query().SetProperty(model.Entity_.Float32, 0.1)
+	assert.NoErr(t, err)
+	entities, err := env.Box.GetAll()
+	assert.NoErr(t, err)
+	for _, entity := range entities {
+		assert.Eq(t, float32(0.1), entity.Float32)
+	}
+
+	_, err = env.Box.Query().SetProperty(model.Entity_.Float64, math.NaN())
+	assert.NoErr(t, err)
+	entities, err = env.Box.GetAll()
+	assert.NoErr(t, err)
+	for _, entity := range entities {
+		assert.True(t, math.IsNaN(entity.Float64))
+	}
+
+	// values that don't fit the field type leave all objects unchanged
+	_, err = env.Box.Query().SetProperty(model.Entity_.Float32, 1e300)
+	assert.Err(t, err)
+	_, err = env.Box.Query().SetProperty(model.Entity_.Uint64, -1)
+	assert.Err(t, err)
+	_, err = env.Box.Query().SetProperty(model.Entity_.Int8, 128)
+	assert.Err(t, err)
+	_, err = env.Box.Query().SetProperty(model.Entity_.Int64, 1.5)
+	assert.Err(t, err)
+	entities, err = env.Box.GetAll()
+	assert.NoErr(t, err)
+	for _, entity := range entities {
+		assert.Eq(t, float32(0.1), entity.Float32)
+		assert.True(t, entity.Uint64 != math.MaxUint64)
+	}
+}
+
+func TestQueryClone(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	_, err := box.PutMany([]*iot.Event{
+		{Uid: "1", Device: "a"}, {Uid: "2", Device: "a"}, {Uid: "3", Device: "a"}, {Uid: "4", Device: "b"}})
+	assert.NoErr(t, err)
+
+	var base = box.Query(iot.Event_.Device.Equals("a", true))
+	clone, err := base.Clone()
+	assert.NoErr(t, err)
+
+	var count = func(query *objectbox.Query) uint64 {
+		count, err := query.Count()
+		assert.NoErr(t, err)
+		return count
+	}
+	assert.Eq(t, uint64(3), count(clone))
+
+	// changing the clone doesn't affect the original
+	assert.NoErr(t, clone.SetStringParams(iot.Event_.Device, "b"))
+	assert.Eq(t, uint64(1), count(clone))
+	assert.Eq(t, uint64(3), count(base.Query))
+
+	ids, err := clone.Limit(1).FindIds()
+	assert.NoErr(t, err)
+	assert.Eq(t, 1, len(ids))
+	clone.Limit(0)
+	ids, err = base.FindIds()
+	assert.NoErr(t, err)
+	assert.Eq(t, 3, len(ids))
+
+	// ... and vice versa; the current parameters are copied
+	assert.NoErr(t, base.SetStringParams(iot.Event_.Device, "none"))
+	second, err := base.Clone()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1), count(clone))
+	assert.Eq(t, uint64(0), count(second))
+
+	assert.NoErr(t, base.Close())
+	assert.Eq(t, uint64(1), count(clone))
+	_, err = base.Clone()
+	assert.Err(t, err)
+
+	assert.NoErr(t, clone.Close())
+	assert.NoErr(t, second.Close())
+}
+
+func TestQueryStartsWithAny(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	_, err := box.PutMany([]*iot.Event{
+		{Uid: "1", Device: "eu-west-1"}, {Uid: "2", Device: "eu-central-1"}, {Uid: "3", Device: "us-east-1"},
+		{Uid: "4", Device: "US-west-2"}, {Uid: "5", Device: "ap-south-1"}, {Uid: "6", Device: ""}})
+	assert.NoErr(t, err)
+
+	var uids = func(conditions ...objectbox.Condition) []string {
+		query, err := box.QueryOrError(conditions...)
+		assert.NoErr(t, err)
+		defer query.Close()
+		events, err := query.Find()
+		assert.NoErr(t, err)
+		var result = make([]string, 0, len(events))
+		for _, event := range events {
+			result = append(result, event.Uid)
+		}
+		return result
+	}
+
+	var Device = iot.Event_.Device
+
+	// the union of the prefixes; overlapping prefixes don't duplicate results
+	assert.Eq(t, []string{"1", "2", "3"}, uids(Device.StartsWithAny([]string{"eu-", "us-", "eu-west"}, true)))
+	assert.Eq(t, []string{"1", "2", "3", "4"}, uids(Device.StartsWithAny([]string{"eu-", "us-"}, false)))
+	assert.Eq(t, []string{"5"}, uids(Device.StartsWithAny([]string{"ap-"}, true)))
+	assert.Eq(t, []string{}, uids(Device.StartsWithAny([]string{"sa-"}, true)))
+
+	// combined with other conditions
+	assert.Eq(t, []string{"2"}, uids(Device.StartsWithAny([]string{"eu-", "us-"}, true), iot.Event_.Uid.NotEquals("1", true),
+		iot.Event_.Uid.NotEquals("3", true)))
+
+	// an empty list matches nothing, also when nested
+	assert.Eq(t, []string{}, uids(Device.StartsWithAny(nil, true)))
+	assert.Eq(t, []string{}, uids(Device.StartsWithAny([]string{}, false)))
+	assert.Eq(t, []string{"5"}, uids(objectbox.Any(Device.StartsWithAny(nil, true), Device.HasPrefix("ap-", true))))
+}
+
+func TestQueryFindWithBudget(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	const total = 10000
+	var events = make([]*iot.Event, total)
+	for i := range events {
+		events[i] = &iot.Event{Uid: fmt.Sprintf("%d", i), Device: []string{"a", "b"}[i%2], Date: int64(i)}
+	}
+	_, err := box.PutMany(events)
+	assert.NoErr(t, err)
+
+	var query = box.Query(iot.Event_.Device.Equals("a", true))
+	defer query.Close()
+
+	// only the first 100 objects are examined
+	slice, truncated, err := query.FindWithBudget(100)
+	assert.NoErr(t, err)
+	assert.True(t, truncated)
+	var found = slice.([]*iot.Event)
+	assert.Eq(t, 50, len(found))
+	for _, event := range found {
+		assert.Eq(t, "a", event.Device)
+		assert.True(t, event.Id <= events[99].Id)
+	}
+
+	// a budget covering all objects returns the same results as Find()
+	slice, truncated, err = query.FindWithBudget(total)
+	assert.NoErr(t, err)
+	assert.True(t, !truncated)
+	assert.Eq(t, total/2, len(slice.([]*iot.Event)))
+
+	// offset & limit apply to the matches within the budget
+	query.Offset(10).Limit(5)
+	slice, truncated, err = query.FindWithBudget(100)
+	assert.NoErr(t, err)
+	assert.True(t, truncated)
+	found = slice.([]*iot.Event)
+	assert.Eq(t, 5, len(found))
+	assert.Eq(t, int64(20), found[0].Date)
+	query.Offset(0).Limit(0)
+
+	// clones keep the ability
+	clone, err := query.Clone()
+	assert.NoErr(t, err)
+	defer clone.Close()
+	slice, _, err = clone.FindWithBudget(10)
+	assert.NoErr(t, err)
+	assert.Eq(t, 5, len(slice.([]*iot.Event)))
+
+	// the budget caps the ID range regardless of the query: an indexed lookup of a single object within the range is
+	// reported as truncated as well because the box holds more objects, and one beyond the range isn't found
+	var withinCap = box.Query(iot.Event_.Uid.Equals("5", true))
+	defer withinCap.Close()
+	slice, truncated, err = withinCap.FindWithBudget(100)
+	assert.NoErr(t, err)
+	assert.True(t, truncated)
+	assert.Eq(t, 1, len(slice.([]*iot.Event)))
+	var beyondCap = box.Query(iot.Event_.Uid.Equals("500", true))
+	defer beyondCap.Close()
+	slice, truncated, err = beyondCap.FindWithBudget(100)
+	assert.NoErr(t, err)
+	assert.True(t, truncated)
+	assert.Eq(t, 0, len(slice.([]*iot.Event)))
+
+	_, _, err = query.FindWithBudget(0)
+	assert.Err(t, err)
+
+	// changed parameters can't be applied to the restricted query
+	assert.NoErr(t, query.SetStringParams(iot.Event_.Device, "b"))
+	_, _, err = query.FindWithBudget(100)
+	assert.Err(t, err)
+}
+
+func TestQueryExplain(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+	box := iot.BoxForEvent(env.ObjectBox)
+
+	for i := 0; i < 100; i++ {
+		_, err := box.Put(&iot.Event{Uid: fmt.Sprintf("%d", i), Device: []string{"a", "b"}[i%2]})
+		assert.NoErr(t, err)
+	}
+
+	var explain = func(conditions ...objectbox.Condition) objectbox.QueryPlan {
+		query, err := box.QueryOrError(conditions...)
+		assert.NoErr(t, err)
+		defer query.Close()
+		plan, err := query.Explain()
+		assert.NoErr(t, err)
+		return plan
+	}
+
+	var E = iot.Event_
+
+	// the unique (hash-indexed) property
+	var plan = explain(E.Uid.Equals("42", true))
+	assert.True(t, plan.UsesIndex)
+	assert.Eq(t, []objectbox.ConditionPlan{{Description: `Uid == "42"`, Property: "Uid", UsesIndex: true}}, plan.Conditions)
+	assert.Eq(t, uint64(1), plan.EstimatedScan)
+
+	// a non-indexed property is a full scan
+	plan = explain(E.Device.Equals("a", true))
+	assert.True(t, !plan.UsesIndex)
+	assert.Eq(t, 1, len(plan.Conditions))
+	assert.Eq(t, "Device", plan.Conditions[0].Property)
+	assert.True(t, !plan.Conditions[0].UsesIndex)
+	assert.Eq(t, uint64(100), plan.EstimatedScan)
+
+	// a hash index doesn't support prefixes nor case-insensitive comparison
+	assert.True(t, !explain(E.Uid.HasPrefix("4", true)).UsesIndex)
+	assert.True(t, !explain(E.Uid.Equals("42", false)).UsesIndex)
+
+	// the ID is always "indexed"
+	assert.True(t, explain(E.Id.GreaterThan(50)).UsesIndex)
+
+	// an index on one of the AND-combined conditions is enough, but not in an OR
+	plan = explain(E.Device.Equals("a", true), E.Uid.Equals("42", true))
+	assert.True(t, plan.UsesIndex)
+	assert.Eq(t, 2, len(plan.Conditions))
+	assert.True(t, !plan.Conditions[0].UsesIndex)
+	assert.True(t, plan.Conditions[1].UsesIndex)
+
+	plan = explain(objectbox.Any(E.Device.Equals("a", true), E.Uid.Equals("42", true)))
+	assert.True(t, !plan.UsesIndex)
+	assert.Eq(t, 2, len(plan.Conditions))
+
+	// no conditions at all
+	plan = explain()
+	assert.True(t, !plan.UsesIndex)
+	assert.Eq(t, 0, len(plan.Conditions))
+	assert.Eq(t, uint64(100), plan.EstimatedScan)
+}
+
 func TestQueryNil(t *testing.T) {
 	env := model.NewTestEnv(t)
 	defer env.Close()
@@ -1223,3 +1928,77 @@ func matchAllEntityIds(t *testing.T, ids []uint64, items interface{}) {
 
 	assert.EqItems(t, ids, actualIds)
 }
+
+func TestQueryString(t *testing.T) {
+	env := model.NewTestEnv(t)
+	defer env.Close()
+	env.Populate(100)
+
+	var box = env.Box
+	var E = model.Entity_
+	var e = model.Entity47()
+
+	var testCases = []struct {
+		expr     string
+		expected *model.EntityQuery
+	}{
+		{`String == "Val-1"`, box.Query(E.String.Equals(e.String, true))},
+		{`String != 'Val-1'`, box.Query(E.String.NotEquals(e.String, true))},
+		{`String > "Val-1"`, box.Query(E.String.GreaterThan(e.String, true))},
+		{`String <= "Val-1"`, box.Query(E.String.LessOrEqual(e.String, true))},
+		{`Int64 == 47`, box.Query(E.Int64.Equals(47))},
+		{`Int64 >= -47000 && Int64 < 0`, box.Query(E.Int64.GreaterOrEqual(-47000), E.Int64.LessThan(0))},
+		{`Int32 > 0 || Bool == true`, box.Query(objectbox.Any(E.Int32.GreaterThan(0), E.Bool.Equals(true)))},
+		{`Bool == 0 && (Int64 < 0 || String == "Val-1")`,
+			box.Query(E.Bool.Equals(false), objectbox.Any(E.Int64.LessThan(0), E.String.Equals(e.String, true)))},
+		{`((Float64 < 1e3)) && Float64 > -1.5`, box.Query(E.Float64.LessThan(1e3), E.Float64.GreaterThan(-1.5))},
+		{`Uint64 != 18446744073709551615`, box.Query(E.Uint64.NotEquals(18446744073709551615))},
+	}
+
+	for _, tc := range testCases {
+		query, err := box.QueryString(tc.expr)
+		assert.NoErr(t, err)
+
+		expected, err := tc.expected.Count()
+		assert.NoErr(t, err)
+		actual, err := query.Count()
+		assert.NoErr(t, err)
+		if expected == 0 || expected != actual {
+			t.Errorf("%s: expected %d objects, got %d", tc.expr, expected, actual)
+		}
+
+		assert.NoErr(t, query.Close())
+		assert.NoErr(t, tc.expected.Close())
+	}
+
+	var errorCases = []struct {
+		expr     string
+		position int
+	}{
+		{``, 0},
+		{`Int64`, 5},
+		{`Int64 = 1`, 6},
+		{`Int64 == "1"`, 9},
+		{`String == 1`, 10},
+		{`Bool == yes`, 8},
+		{`Unknown == 1`, 0},
+		{`Int64 == 1 &&`, 13},
+		{`(Int64 == 1`, 11},
+		{`Int64 == 1)`, 10},
+		{`String == "Val-1`, 10},
+		{`Int64 == 1 # x`, 11},
+		{`ByteVector == 1`, 0},
+	}
+
+	for _, tc := range errorCases {
+		query, err := box.QueryString(tc.expr)
+		assert.True(t, query == nil)
+
+		var exprErr *objectbox.QueryStringError
+		if !errors.As(err, &exprErr) {
+			t.Errorf("%s: expected a QueryStringError, got %v", tc.expr, err)
+		} else if exprErr.Position != tc.position {
+			t.Errorf("%s: expected an error at position %d, got: %s", tc.expr, tc.position, err)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+/*
+ * Copyright 2018-2021 ObjectBox Ltd. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectbox_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/objectbox/objectbox-go/test/assert"
+	"github.com/objectbox/objectbox-go/test/model"
+)
+
+// Fields tagged `objectbox:"-"` are not part of the model and must not be persisted.
+func TestTransientField(t *testing.T) {
+	var env = model.NewTestEnv(t)
+	defer env.Close()
+
+	var box = model.BoxForTestEntityTransient(env.ObjectBox)
+
+	var object = &model.TestEntityTransient{Name: "foo", Computed: "not persisted", Count: 47}
+	id, err := box.Put(object)
+	assert.NoErr(t, err)
+
+	read, err := box.Get(id)
+	assert.NoErr(t, err)
+	assert.Eq(t, "foo", read.Name)
+	assert.Eq(t, "", read.Computed)
+	assert.Eq(t, 47, read.Count)
+
+	// the properties following the skipped field keep their place in the model, check through the stored values
+	var buf bytes.Buffer
+	assert.NoErr(t, box.ExportCSV(&buf, []string{"Id", "Name", "Count"}))
+	assert.Eq(t, "Id,Name,Count\n1,foo,47\n", buf.String())
+	assert.Err(t, box.ExportCSV(&buf, []string{"Computed"}))
+}
@@ -18,8 +18,12 @@ package objectbox_test
 
 import (
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/objectbox/objectbox-go/objectbox"
 	"github.com/objectbox/objectbox-go/test/assert"
 	"github.com/objectbox/objectbox-go/test/model/iot"
 )
@@ -93,3 +97,394 @@ func TestTransactionRollback(t *testing.T) {
 	assert.Eq(t, 0, int(count))
 
 }
+
+func TestPutWithOutbox(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+
+	var events = iot.BoxForEvent(env.ObjectBox)
+	var outbox = iot.BoxForReading(env.ObjectBox)
+
+	var event = &iot.Event{Device: "sensor", Uid: "unique"}
+	var message = &iot.Reading{ValueName: "event-created"}
+	assert.NoErr(t, env.PutWithOutbox(event, message))
+	assert.True(t, event.Id != 0 && message.Id != 0)
+
+	count, err := outbox.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1), count)
+
+	// when putting the object fails (unique constraint violation), the event isn't stored either
+	assert.Err(t, env.PutWithOutbox(&iot.Event{Device: "other", Uid: "unique"}, &iot.Reading{ValueName: "lost"}))
+
+	count, err = outbox.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1), count)
+
+	count, err = events.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1), count)
+
+	// unknown types are rejected before anything is written
+	assert.Err(t, env.PutWithOutbox(&iot.Event{Device: "third"}, "not an entity"))
+
+	count, err = events.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(1), count)
+}
+
+func TestMoveObject(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+
+	var drafts = iot.BoxForEvent(env.ObjectBox)
+	var published = iot.BoxForReading(env.ObjectBox)
+
+	id, err := drafts.Put(&iot.Event{Device: "draft", Uid: "1", Date: 100})
+	assert.NoErr(t, err)
+
+	var toReading = func(object interface{}) interface{} {
+		var event = object.(*iot.Event)
+		return &iot.Reading{Id: event.Id, Date: event.Date, ValueName: event.Device}
+	}
+
+	var countsAre = func(draftsCount, publishedCount uint64) {
+		count, err := drafts.Count()
+		assert.NoErr(t, err)
+		assert.Eq(t, draftsCount, count)
+		count, err = published.Count()
+		assert.NoErr(t, err)
+		assert.Eq(t, publishedCount, count)
+	}
+
+	// a failing put to the destination rolls back the whole move
+	published.RequireIds(true)
+	_, err = objectbox.MoveObject(drafts.Box, published.Box, id, func(object interface{}) interface{} {
+		return &iot.Reading{ValueName: "no ID"}
+	})
+	assert.Eq(t, objectbox.ErrIdRequired, err)
+	countsAre(1, 0)
+	published.RequireIds(false)
+
+	// as well as a failing transform
+	_, err = objectbox.MoveObject(drafts.Box, published.Box, id, func(object interface{}) interface{} { return nil })
+	assert.Err(t, err)
+	countsAre(1, 0)
+
+	// the ID is preserved by the transform
+	newId, err := objectbox.MoveObject(drafts.Box, published.Box, id, toReading)
+	assert.NoErr(t, err)
+	assert.Eq(t, id, newId)
+	countsAre(0, 1)
+
+	reading, err := published.Get(newId)
+	assert.NoErr(t, err)
+	assert.Eq(t, iot.Reading{Id: id, Date: 100, ValueName: "draft"}, *reading)
+
+	// the object doesn't exist anymore
+	_, err = objectbox.MoveObject(drafts.Box, published.Box, id, toReading)
+	assert.Eq(t, objectbox.ErrNotFound, err)
+	countsAre(0, 1)
+
+	// boxes of other stores are rejected
+	var other = iot.NewTestEnv()
+	defer other.Close()
+	_, err = objectbox.MoveObject(drafts.Box, iot.BoxForReading(other.ObjectBox).Box, id, toReading)
+	assert.Err(t, err)
+}
+
+func TestWriteCoalescing(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+
+	var box = iot.BoxForEvent(env.ObjectBox)
+	assert.NoErr(t, box.RemoveAll())
+
+	assert.Err(t, env.ObjectBox.EnableWriteCoalescing(0))
+	assert.NoErr(t, env.ObjectBox.EnableWriteCoalescing(5*time.Millisecond))
+	assert.Err(t, env.ObjectBox.EnableWriteCoalescing(5*time.Millisecond))
+
+	_, err := box.Put(&iot.Event{Uid: "duplicate-uid"})
+	assert.NoErr(t, err)
+
+	// concurrent puts, one of them violating the unique constraint
+	const count = 50
+	var events = make([]*iot.Event, count)
+	var ids = make([]uint64, count)
+	var errs = make([]error, count)
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		events[i] = &iot.Event{Uid: fmt.Sprintf("uid-%d", i), Device: fmt.Sprintf("device %d", i)}
+		if i == count/2 {
+			events[i].Uid = "duplicate-uid"
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ids[i], errs[i] = box.Put(events[i])
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < count; i++ {
+		if i == count/2 {
+			assert.Err(t, errs[i])
+			continue
+		}
+		assert.NoErr(t, errs[i])
+		assert.Eq(t, ids[i], events[i].Id)
+
+		read, err := box.Get(ids[i])
+		assert.NoErr(t, err)
+		assert.Eq(t, events[i].Device, read.Device)
+	}
+
+	stored, err := box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(count), stored)
+
+	// puts are executed directly afterwards
+	env.ObjectBox.DisableWriteCoalescing()
+	env.ObjectBox.DisableWriteCoalescing()
+	_, err = box.Put(&iot.Event{Uid: "after"})
+	assert.NoErr(t, err)
+}
+
+func TestWriteCoalescingNestedTx(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+
+	var box = iot.BoxForEvent(env.ObjectBox)
+	assert.NoErr(t, env.ObjectBox.EnableWriteCoalescing(5*time.Millisecond))
+	defer env.ObjectBox.DisableWriteCoalescing()
+
+	// PutMany() runs a nested write transaction; the following Put() must still be executed in the outer one instead
+	// of being passed to the coalescer, which would wait for the outer transaction to finish
+	var done = make(chan error, 1)
+	go func() {
+		done <- env.ObjectBox.RunInWriteTx(func() error {
+			if _, err := box.PutMany([]*iot.Event{{Device: "first"}, {Device: "second"}}); err != nil {
+				return err
+			}
+			_, err := box.Put(&iot.Event{Device: "third"})
+			return err
+		})
+	}()
+
+	select {
+	case err := <-done:
+		assert.NoErr(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Put() after a nested transaction is blocked")
+	}
+
+	count, err := box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(3), count)
+}
+
+func TestRunInReadTxCached(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+
+	var box = iot.BoxForEvent(env.ObjectBox)
+	id, err := box.Put(&iot.Event{Device: "dev"})
+	assert.NoErr(t, err)
+
+	var leakedCache *objectbox.ReadCache
+	assert.NoErr(t, env.ObjectBox.RunInReadTxCached(func(cache *objectbox.ReadCache) error {
+		leakedCache = cache
+
+		first, err := cache.Get(box.Box, id)
+		assert.NoErr(t, err)
+		second, err := cache.Get(box.Box, id)
+		assert.NoErr(t, err)
+		assert.True(t, first.(*iot.Event) == second.(*iot.Event))
+		assert.Eq(t, "dev", first.(*iot.Event).Device)
+
+		// a plain Get still creates a new instance
+		plain, err := box.Get(id)
+		assert.NoErr(t, err)
+		assert.True(t, first.(*iot.Event) != plain)
+
+		missing, err := cache.Get(box.Box, id+1)
+		assert.NoErr(t, err)
+		assert.True(t, missing == nil)
+		return nil
+	}))
+
+	_, err = leakedCache.Get(box.Box, id)
+	assert.Err(t, err)
+}
+
+func TestSnapshot(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+
+	var box = iot.BoxForEvent(env.ObjectBox)
+	id1, err := box.Put(&iot.Event{Device: "dev1"})
+	assert.NoErr(t, err)
+	id2, err := box.Put(&iot.Event{Device: "dev2"})
+	assert.NoErr(t, err)
+
+	snapshot, err := env.ObjectBox.Snapshot()
+	assert.NoErr(t, err)
+
+	// change the data after the snapshot has been taken
+	_, err = box.Put(&iot.Event{Id: id1, Device: "changed"})
+	assert.NoErr(t, err)
+	assert.NoErr(t, box.RemoveId(id2))
+	_, err = box.Put(&iot.Event{Device: "dev3"})
+	assert.NoErr(t, err)
+
+	object, err := snapshot.Get(box.Box, id1)
+	assert.NoErr(t, err)
+	assert.Eq(t, "dev1", object.(*iot.Event).Device)
+
+	object, err = snapshot.Get(box.Box, id2)
+	assert.NoErr(t, err)
+	assert.Eq(t, "dev2", object.(*iot.Event).Device)
+
+	objects, err := snapshot.GetAll(box.Box)
+	assert.NoErr(t, err)
+	assert.Eq(t, 2, len(objects.([]*iot.Event)))
+
+	var query = box.Query(iot.Event_.Device.HasPrefix("dev", true))
+	defer query.Close()
+	objects, err = snapshot.Find(query.Query)
+	assert.NoErr(t, err)
+	assert.Eq(t, 2, len(objects.([]*iot.Event)))
+
+	// generated boxes can be used inside Run()
+	assert.NoErr(t, snapshot.Run(func() error {
+		count, err := box.Count()
+		assert.Eq(t, uint64(2), count)
+		return err
+	}))
+
+	// concurrent reads are serialized
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			object, err := snapshot.Get(box.Box, id1)
+			assert.NoErr(t, err)
+			assert.Eq(t, "dev1", object.(*iot.Event).Device)
+		}()
+	}
+	wg.Wait()
+
+	// outside of the snapshot, the current data is visible
+	count, err := box.Count()
+	assert.NoErr(t, err)
+	assert.Eq(t, uint64(2), count)
+	current, err := box.Get(id1)
+	assert.NoErr(t, err)
+	assert.Eq(t, "changed", current.Device)
+
+	assert.NoErr(t, snapshot.Release())
+	assert.NoErr(t, snapshot.Release())
+	_, err = snapshot.Get(box.Box, id1)
+	assert.Eq(t, objectbox.ErrSnapshotReleased, err)
+}
+
+func TestReadersInUse(t *testing.T) {
+	env := iot.NewTestEnv()
+	defer env.Close()
+
+	var readersInUse = func() int {
+		inUse, max, err := env.ObjectBox.ReadersInUse()
+		assert.NoErr(t, err)
+		assert.Eq(t, 126, max)
+		return inUse
+	}
+	assert.Eq(t, 0, readersInUse())
+
+	var count = 5
+	var release = make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoErr(t, env.ObjectBox.RunInReadTx(func() error {
+				<-release
+				return nil
+			}))
+		}()
+	}
+
+	assert.NoErr(t, waitUntil(5*time.Second, func() (bool, error) {
+		return readersInUse() == count, nil
+	}))
+
+	snapshot, err := env.ObjectBox.Snapshot()
+	assert.NoErr(t, err)
+	assert.Eq(t, count+1, readersInUse())
+	assert.NoErr(t, snapshot.Release())
+
+	close(release)
+	wg.Wait()
+	assert.Eq(t, 0, readersInUse())
+
+	// nested transactions reuse the reader slot of the outermost one on the same thread
+	var box = iot.BoxForEvent(env.ObjectBox)
+	assert.NoErr(t, env.ObjectBox.RunInReadTx(func() error {
+		assert.Eq(t, 1, readersInUse())
+		_, err := box.Count()
+		assert.NoErr(t, err)
+		return env.ObjectBox.RunInReadTx(func() error {
+			assert.Eq(t, 1, readersInUse())
+			return nil
+		})
+	}))
+	assert.NoErr(t, env.ObjectBox.RunInWriteTx(func() error {
+		_, err := box.Count()
+		assert.NoErr(t, err)
+		assert.Eq(t, 0, readersInUse())
+		return env.ObjectBox.RunInReadTx(func() error {
+			assert.Eq(t, 0, readersInUse())
+			return nil
+		})
+	}))
+	assert.Eq(t, 0, readersInUse())
+
+	// the threshold callback is called each time the count rises to the threshold
+	assert.Err(t, env.ObjectBox.SetReadersThreshold(0, func(int, int) {}))
+	var notified = make(chan int, 10)
+	assert.NoErr(t, env.ObjectBox.SetReadersThreshold(2, func(inUse, max int) {
+		assert.Eq(t, 126, max)
+		notified <- inUse
+	}))
+	for round := 0; round < 2; round++ {
+		release = make(chan struct{})
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func(release chan struct{}) {
+				defer wg.Done()
+				assert.NoErr(t, env.ObjectBox.RunInReadTx(func() error {
+					<-release
+					return nil
+				}))
+			}(release)
+		}
+		assert.NoErr(t, waitUntil(5*time.Second, func() (bool, error) {
+			return readersInUse() == 3, nil
+		}))
+		close(release)
+		wg.Wait()
+	}
+	assert.Eq(t, 2, len(notified))
+	assert.Eq(t, 2, <-notified)
+	assert.Eq(t, 2, <-notified)
+
+	assert.NoErr(t, env.ObjectBox.SetReadersThreshold(0, nil))
+	assert.NoErr(t, env.ObjectBox.RunInReadTx(func() error { return nil }))
+	assert.Eq(t, 0, len(notified))
+
+	env.ObjectBox.Close()
+	_, _, err = env.ObjectBox.ReadersInUse()
+	assert.Eq(t, objectbox.ErrStoreClosed, err)
+}